@@ -3,40 +3,47 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/achannarasappa/ticker/v5/internal/cli"
 	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/importer"
 	"github.com/achannarasappa/ticker/v5/internal/print"
+	"github.com/achannarasappa/ticker/v5/internal/server"
+	"github.com/achannarasappa/ticker/v5/internal/statusline"
 	"github.com/achannarasappa/ticker/v5/internal/ui"
 )
 
 //nolint:gochecknoglobals
 var (
 	// Version is a placeholder that is replaced at build time with a linker flag (-ldflags)
-	Version      = "v0.0.0"
-	configPath   string
-	dep          c.Dependencies
-	ctx          c.Context
-	config       c.Config
-	options      cli.Options
-	optionsPrint print.Options
-	err          error
-	rootCmd      = &cobra.Command{
+	Version       = "v0.0.0"
+	configPath    string
+	dep           c.Dependencies
+	ctx           c.Context
+	config        c.Config
+	options       cli.Options
+	optionsPrint  print.Options
+	optionsImport importer.Options
+	optionsServe  server.Options
+	optionsStatus statusline.Options
+	err           error
+	rootCmd       = &cobra.Command{
 		Version: Version,
 		Use:     "ticker",
 		Short:   "Terminal stock ticker and stock gain/loss tracker",
 		PreRun:  initContext,
 		Args:    cli.Validate(&config, &options, &err),
-		Run:     cli.Run(ui.Start(&dep, &ctx, Version)),
+		Run:     cli.Run(ui.Start(&dep, &ctx, &configPath, Version)),
 	}
 	printCmd = &cobra.Command{
 		Use:    "print",
 		Short:  "Prints holdings",
 		PreRun: initContext,
 		Args:   cli.Validate(&config, &options, &err),
-		Run:    print.Run(&dep, &ctx, &optionsPrint),
+		RunE:   print.Run(&dep, &ctx, &optionsPrint),
 	}
 	summaryCmd = &cobra.Command{
 		Use:    "summary",
@@ -45,6 +52,26 @@ var (
 		Args:   cli.Validate(&config, &options, &err),
 		Run:    print.RunSummary(&dep, &ctx, &optionsPrint),
 	}
+	serveCmd = &cobra.Command{
+		Use:    "serve",
+		Short:  "Serves quotes and positions for all groups over an HTTP API",
+		PreRun: initContext,
+		Args:   cli.Validate(&config, &options, &err),
+		RunE:   server.Run(&dep, &ctx, &optionsServe),
+	}
+	statuslineCmd = &cobra.Command{
+		Use:    "statusline",
+		Short:  "Prints a compact line of quotes for status bars",
+		PreRun: initContext,
+		Args:   cli.Validate(&config, &options, &err),
+		RunE:   statusline.Run(&dep, &ctx, &optionsStatus),
+	}
+	importCmd = &cobra.Command{
+		Use:   "import <file>",
+		Short: "Imports lots from a broker transaction export into the config file",
+		Args:  cobra.ExactArgs(1),
+		RunE:  importer.Run(&dep, &configPath, &optionsImport),
+	}
 )
 
 // Execute starts the CLI or prints an error is there is one
@@ -67,15 +94,44 @@ func init() { //nolint: gochecknoinits
 	rootCmd.Flags().BoolVar(&options.ShowSummary, "show-summary", false, "display summary of total gain and loss for positions")
 	rootCmd.Flags().BoolVar(&options.ShowPositions, "show-positions", false, "display average unit cost, quantity, portfolio weight")
 	rootCmd.Flags().BoolVar(&options.ShowHoldings, "show-holdings", false, "display average unit cost, quantity, portfolio weight (deprecated: use --show-positions)")
+	rootCmd.Flags().BoolVar(&options.ShowSparkline, "show-sparkline", false, "display a chart of price movement over the current trading session for each quote")
 	rootCmd.Flags().StringVar(&options.Sort, "sort", "", "sort quotes on the UI. Set \"alpha\" to sort by ticker name. Set \"value\" to sort by position value. Keep empty to sort according to change percent")
 	rootCmd.Flags().BoolVar(&options.NoCache, "no-cache", false, "disable the on-disk cache of data retrieved at startup")
 	rootCmd.Flags().BoolVar(&options.Debug, "debug", false, "enable debug logging to ./ticker-log-<date>.log")
 
-	printCmd.PersistentFlags().StringVar(&optionsPrint.Format, "format", "", "output format for printing holdings. Set \"csv\" to print as a CSV or \"json\" for JSON. Defaults to JSON.")
+	printCmd.PersistentFlags().StringVar(&optionsPrint.Format, "format", "", "output format for printing holdings. Set \"csv\" to print as a CSV, \"json\" for JSON, or \"ndjson\" for one JSON object per line. Defaults to JSON.")
 	printCmd.PersistentFlags().StringVar(&configPath, "config", "", "config file (default is $HOME/.ticker.yaml)")
+	printCmd.Flags().BoolVar(&optionsPrint.Watch, "watch", false, "keep running and print a line of JSON for each quote update")
+	printCmd.Flags().IntVar(&optionsPrint.SnapshotInterval, "snapshot-interval", 0, "with --watch, also print a snapshot of positions in the default group every interval in seconds")
 	printCmd.AddCommand(summaryCmd)
 
+	serveCmd.Flags().StringVar(&optionsServe.Address, "address", "localhost:8080", "address to listen on for API requests")
+	serveCmd.Flags().IntVarP(&options.RefreshInterval, "interval", "i", 0, "refresh interval in seconds")
+	serveCmd.Flags().BoolVar(&options.NoCache, "no-cache", false, "disable the on-disk cache of data retrieved at startup")
+	serveCmd.Flags().BoolVar(&options.Debug, "debug", false, "enable debug logging to ./ticker-log-<date>.log")
+	serveCmd.Flags().StringVar(&configPath, "config", "", "config file (default is $HOME/.ticker.yaml)")
+
+	statuslineCmd.Flags().StringVar(&optionsStatus.Format, "format", statusline.FormatPlain, "status bar to format output for. Set to one of \""+strings.Join(statusline.GetFormats(), "\", \"")+"\".")
+	statuslineCmd.Flags().StringVar(&optionsStatus.Template, "template", statusline.DefaultTemplate, "template for the line with {quotes}, {day_change}, {day_change_percent}, {value}, and {group} placeholders")
+	statuslineCmd.Flags().StringVar(&optionsStatus.QuoteTemplate, "quote-template", statusline.DefaultQuoteTemplate, "template for each quote with {symbol}, {name}, {price}, {change}, {change_percent}, and {arrow} placeholders")
+	statuslineCmd.Flags().StringVar(&optionsStatus.Separator, "separator", statusline.DefaultSeparator, "text between each quote")
+	statuslineCmd.Flags().StringVar(&optionsStatus.Group, "group", "", "name of the group to show. Defaults to the first group.")
+	statuslineCmd.Flags().BoolVar(&optionsStatus.Watch, "watch", false, "keep running and print a new line each time it changes")
+	statuslineCmd.Flags().IntVarP(&options.RefreshInterval, "interval", "i", 0, "refresh interval in seconds which is also how long quotes are cached between runs")
+	statuslineCmd.Flags().BoolVar(&options.NoCache, "no-cache", false, "disable the on-disk cache of data retrieved at startup")
+	statuslineCmd.Flags().StringVar(&configPath, "config", "", "config file (default is $HOME/.ticker.yaml)")
+
+	importCmd.Flags().StringVar(&optionsImport.Format, "format", "", "layout of the transaction export. Set to one of \""+strings.Join(importer.GetFormats(), "\", \"")+"\". Defaults to generic.")
+	importCmd.Flags().StringVar(&optionsImport.Columns, "columns", "", "comma separated list of field=header pairs to rename the columns of the generic format (e.g. symbol=Ticker,quantity=Shares,fees=Commission+Fees)")
+	importCmd.Flags().StringVar(&optionsImport.Group, "group", "", "name of the group to import lots into. Defaults to the top level lots.")
+	importCmd.Flags().BoolVar(&optionsImport.DryRun, "dry-run", false, "preview the lots that would be imported without writing to the config file")
+	importCmd.Flags().BoolVarP(&optionsImport.Yes, "yes", "y", false, "write to the config file without confirmation")
+	importCmd.Flags().StringVar(&configPath, "config", "", "config file (default is $HOME/.ticker.yaml)")
+
 	rootCmd.AddCommand(printCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(statuslineCmd)
 }
 
 func initConfig() {
@@ -0,0 +1,269 @@
+package alert
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/achannarasappa/ticker/v5/internal/asset"
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+// Conditions which an alert rule can be set to trigger on
+const (
+	ConditionAbove            = "above"
+	ConditionBelow            = "below"
+	ConditionChange           = "change"
+	ConditionCostBasis        = "cost-basis"
+	ConditionFiftyTwoWeekHigh = "52-week-high"
+	ConditionFiftyTwoWeekLow  = "52-week-low"
+)
+
+const (
+	defaultCooldown   = 300 * time.Second
+	defaultHysteresis = 0.5
+)
+
+// Evaluator checks asset quotes against alert rules and tracks the state of each rule between quotes
+type Evaluator struct {
+	rules       []*rule
+	unitCosts   map[string]float64
+	lotMatching string
+	mu          sync.Mutex
+}
+
+// Config represents the configuration for the alert evaluator
+type Config struct {
+	Rules       []c.ConfigAlert
+	LotMatching string
+}
+
+type rule struct {
+	config     c.ConfigAlert
+	cooldown   time.Duration
+	hysteresis float64
+	// isTriggered is set when the condition is met and reset once the price clears the condition by the hysteresis
+	isTriggered bool
+	// side is the side of the cost basis the price was last on (1 above, -1 below, 0 unknown)
+	side        int
+	timeAlerted time.Time
+}
+
+// NewEvaluator creates a new alert evaluator
+func NewEvaluator(config Config) *Evaluator {
+
+	rules := make([]*rule, 0, len(config.Rules))
+
+	for _, configRule := range config.Rules {
+		r := &rule{
+			config:     configRule,
+			cooldown:   defaultCooldown,
+			hysteresis: defaultHysteresis,
+		}
+
+		if configRule.Cooldown != nil {
+			r.cooldown = time.Duration(*configRule.Cooldown) * time.Second
+		}
+
+		if configRule.Hysteresis != nil {
+			r.hysteresis = *configRule.Hysteresis
+		}
+
+		rules = append(rules, r)
+	}
+
+	return &Evaluator{
+		rules:       rules,
+		unitCosts:   make(map[string]float64),
+		lotMatching: config.LotMatching,
+	}
+}
+
+// IsValidCondition returns whether a condition is one which alert rules can be set to trigger on
+func IsValidCondition(condition string) bool {
+	switch condition {
+	case ConditionAbove, ConditionBelow, ConditionChange, ConditionCostBasis, ConditionFiftyTwoWeekHigh, ConditionFiftyTwoWeekLow:
+		return true
+	default:
+		return false
+	}
+}
+
+// SetLots sets the lots used to find the cost basis of assets for cost basis rules without a set price
+func (e *Evaluator) SetLots(lots []c.Lot) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.unitCosts = make(map[string]float64)
+
+	for symbol, unitCost := range asset.GetUnitCosts(lots, e.lotMatching) {
+		e.unitCosts[strings.ToLower(symbol)] = unitCost
+	}
+}
+
+// Evaluate checks an asset quote against each rule for its symbol and returns alerts for the rules which triggered
+func (e *Evaluator) Evaluate(assetQuote c.AssetQuote, now time.Time) []c.Alert {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	alerts := make([]c.Alert, 0)
+
+	if assetQuote.QuotePrice.Price <= 0 {
+		return alerts
+	}
+
+	for _, r := range e.rules {
+		if !strings.EqualFold(r.config.Symbol, assetQuote.Symbol) {
+			continue
+		}
+
+		var message string
+		var isTriggered bool
+
+		if r.config.Condition == ConditionCostBasis {
+			message, isTriggered = r.evaluateCrossing(assetQuote, e.unitCosts[strings.ToLower(assetQuote.Symbol)], now)
+		} else {
+			message, isTriggered = r.evaluateThreshold(assetQuote, now)
+		}
+
+		if !isTriggered {
+			continue
+		}
+
+		r.timeAlerted = now
+
+		alerts = append(alerts, c.Alert{
+			Symbol:    assetQuote.Symbol,
+			Condition: r.config.Condition,
+			Message:   message,
+			Price:     assetQuote.QuotePrice.Price,
+			Time:      now,
+			Notify:    r.config.Notify,
+		})
+	}
+
+	return alerts
+}
+
+func (r *rule) isCoolingDown(now time.Time) bool {
+	return !r.timeAlerted.IsZero() && now.Sub(r.timeAlerted) < r.cooldown
+}
+
+// evaluateThreshold triggers once when the condition is met and again only after the price has cleared the condition by
+// the hysteresis and the cooldown has passed
+func (r *rule) evaluateThreshold(assetQuote c.AssetQuote, now time.Time) (string, bool) {
+
+	isMet, isCleared, message := r.checkThreshold(assetQuote)
+
+	if r.isTriggered {
+		r.isTriggered = !isCleared
+
+		return "", false
+	}
+
+	if !isMet || r.isCoolingDown(now) {
+		return "", false
+	}
+
+	r.isTriggered = true
+
+	return message, true
+}
+
+// checkThreshold returns whether the condition is met and whether the price has cleared the condition by the hysteresis
+func (r *rule) checkThreshold(assetQuote c.AssetQuote) (bool, bool, string) {
+
+	price := assetQuote.QuotePrice.Price
+	band := r.hysteresis / 100
+	symbol := assetQuote.Symbol
+	isVariablePrecision := assetQuote.Meta.IsVariablePrecision
+
+	switch r.config.Condition {
+	case ConditionAbove:
+		return price >= r.config.Value,
+			price < r.config.Value*(1-band),
+			fmt.Sprintf("%s rose above %s to %s", symbol, formatPrice(r.config.Value, isVariablePrecision), formatPrice(price, isVariablePrecision))
+	case ConditionBelow:
+		return price <= r.config.Value,
+			price > r.config.Value*(1+band),
+			fmt.Sprintf("%s fell below %s to %s", symbol, formatPrice(r.config.Value, isVariablePrecision), formatPrice(price, isVariablePrecision))
+	case ConditionChange:
+		changePercent := assetQuote.QuotePrice.ChangePercent
+		direction := "up"
+
+		if changePercent < 0 {
+			direction = "down"
+		}
+
+		return math.Abs(changePercent) >= r.config.Value,
+			math.Abs(changePercent) < r.config.Value-r.hysteresis,
+			fmt.Sprintf("%s is %s %.2f%% today at %s", symbol, direction, math.Abs(changePercent), formatPrice(price, isVariablePrecision))
+	case ConditionFiftyTwoWeekHigh:
+		high := assetQuote.QuoteExtended.FiftyTwoWeekHigh
+
+		return high > 0 && price >= high,
+			high > 0 && price < high*(1-band),
+			fmt.Sprintf("%s reached a 52-week high of %s", symbol, formatPrice(price, isVariablePrecision))
+	case ConditionFiftyTwoWeekLow:
+		low := assetQuote.QuoteExtended.FiftyTwoWeekLow
+
+		return low > 0 && price <= low,
+			low > 0 && price > low*(1+band),
+			fmt.Sprintf("%s reached a 52-week low of %s", symbol, formatPrice(price, isVariablePrecision))
+	}
+
+	return false, false, ""
+}
+
+// evaluateCrossing triggers when the price moves from one side of the cost basis to the other by more than the
+// hysteresis. The first price only sets the side the price starts on.
+func (r *rule) evaluateCrossing(assetQuote c.AssetQuote, unitCost float64, now time.Time) (string, bool) {
+
+	costBasis := unitCost
+	if r.config.Value > 0 {
+		costBasis = r.config.Value
+	}
+
+	if costBasis <= 0 {
+		return "", false
+	}
+
+	price := assetQuote.QuotePrice.Price
+	band := r.hysteresis / 100
+	side := r.side
+
+	if price > costBasis*(1+band) {
+		side = 1
+	}
+
+	if price < costBasis*(1-band) {
+		side = -1
+	}
+
+	prevSide := r.side
+	r.side = side
+
+	if prevSide == 0 || prevSide == side || r.isCoolingDown(now) {
+		return "", false
+	}
+
+	direction := "above"
+	if side < 0 {
+		direction = "below"
+	}
+
+	isVariablePrecision := assetQuote.Meta.IsVariablePrecision
+
+	return fmt.Sprintf("%s crossed %s cost basis of %s to %s", assetQuote.Symbol, direction, formatPrice(costBasis, isVariablePrecision), formatPrice(price, isVariablePrecision)), true
+}
+
+func formatPrice(price float64, isVariablePrecision bool) string {
+	if isVariablePrecision {
+		return strconv.FormatFloat(price, 'f', -1, 64)
+	}
+
+	return strconv.FormatFloat(price, 'f', 2, 64)
+}
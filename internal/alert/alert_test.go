@@ -0,0 +1,142 @@
+package alert_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/achannarasappa/ticker/v5/internal/alert"
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+var _ = Describe("Alert", func() {
+
+	timeStart := time.Date(2025, 3, 4, 14, 0, 0, 0, time.UTC)
+
+	quote := func(symbol string, price float64) c.AssetQuote {
+		return c.AssetQuote{
+			Symbol:     symbol,
+			QuotePrice: c.QuotePrice{Price: price},
+		}
+	}
+
+	intPtr := func(v int) *int { return &v }
+	floatPtr := func(v float64) *float64 { return &v }
+
+	Describe("Evaluate", func() {
+
+		When("the price rises above a threshold", func() {
+			It("should return an alert once until the price falls below the threshold by the hysteresis", func() {
+				evaluator := NewEvaluator(Config{Rules: []c.ConfigAlert{
+					{Symbol: "aapl", Condition: ConditionAbove, Value: 200, Cooldown: intPtr(0), Hysteresis: floatPtr(1)},
+				}})
+
+				Expect(evaluator.Evaluate(quote("AAPL", 199), timeStart)).To(BeEmpty())
+
+				alerts := evaluator.Evaluate(quote("AAPL", 201), timeStart.Add(time.Second))
+				Expect(alerts).To(Equal([]c.Alert{{
+					Symbol:    "AAPL",
+					Condition: ConditionAbove,
+					Message:   "AAPL rose above 200.00 to 201.00",
+					Price:     201,
+					Time:      timeStart.Add(time.Second),
+				}}))
+
+				Expect(evaluator.Evaluate(quote("AAPL", 199), timeStart.Add(2*time.Second))).To(BeEmpty())
+				Expect(evaluator.Evaluate(quote("AAPL", 201), timeStart.Add(3*time.Second))).To(BeEmpty())
+				Expect(evaluator.Evaluate(quote("AAPL", 197), timeStart.Add(4*time.Second))).To(BeEmpty())
+				Expect(evaluator.Evaluate(quote("AAPL", 201), timeStart.Add(5*time.Second))).To(HaveLen(1))
+			})
+		})
+
+		When("the price falls below a threshold", func() {
+			It("should return an alert", func() {
+				evaluator := NewEvaluator(Config{Rules: []c.ConfigAlert{
+					{Symbol: "AAPL", Condition: ConditionBelow, Value: 150},
+				}})
+
+				alerts := evaluator.Evaluate(quote("AAPL", 149.5), timeStart)
+				Expect(alerts).To(HaveLen(1))
+				Expect(alerts[0].Message).To(Equal("AAPL fell below 150.00 to 149.50"))
+			})
+		})
+
+		When("the rule has triggered within the cooldown", func() {
+			It("should not return another alert until the cooldown has passed", func() {
+				evaluator := NewEvaluator(Config{Rules: []c.ConfigAlert{
+					{Symbol: "AAPL", Condition: ConditionAbove, Value: 200, Cooldown: intPtr(60), Hysteresis: floatPtr(0)},
+				}})
+
+				Expect(evaluator.Evaluate(quote("AAPL", 201), timeStart)).To(HaveLen(1))
+				Expect(evaluator.Evaluate(quote("AAPL", 199), timeStart.Add(10*time.Second))).To(BeEmpty())
+				Expect(evaluator.Evaluate(quote("AAPL", 201), timeStart.Add(20*time.Second))).To(BeEmpty())
+				Expect(evaluator.Evaluate(quote("AAPL", 201), timeStart.Add(61*time.Second))).To(HaveLen(1))
+			})
+		})
+
+		When("the percent change for the day passes a threshold", func() {
+			It("should return an alert in either direction", func() {
+				evaluator := NewEvaluator(Config{Rules: []c.ConfigAlert{
+					{Symbol: "BTC-USD", Condition: ConditionChange, Value: 5},
+				}})
+
+				assetQuote := quote("BTC-USD", 60000)
+				assetQuote.QuotePrice.ChangePercent = -5.25
+
+				alerts := evaluator.Evaluate(assetQuote, timeStart)
+				Expect(alerts).To(HaveLen(1))
+				Expect(alerts[0].Message).To(Equal("BTC-USD is down 5.25% today at 60000.00"))
+			})
+		})
+
+		When("the price crosses the cost basis", func() {
+			It("should return an alert only when the price moves to the other side of the cost basis of the lots", func() {
+				evaluator := NewEvaluator(Config{Rules: []c.ConfigAlert{
+					{Symbol: "AAPL", Condition: ConditionCostBasis, Cooldown: intPtr(0), Hysteresis: floatPtr(1)},
+				}})
+				evaluator.SetLots([]c.Lot{
+					{Symbol: "AAPL", UnitCost: 90, Quantity: 1},
+					{Symbol: "AAPL", UnitCost: 110, Quantity: 1},
+				})
+
+				Expect(evaluator.Evaluate(quote("AAPL", 105), timeStart)).To(BeEmpty())
+				Expect(evaluator.Evaluate(quote("AAPL", 99.5), timeStart.Add(time.Second))).To(BeEmpty())
+
+				alerts := evaluator.Evaluate(quote("AAPL", 98), timeStart.Add(2*time.Second))
+				Expect(alerts).To(HaveLen(1))
+				Expect(alerts[0].Message).To(Equal("AAPL crossed below cost basis of 100.00 to 98.00"))
+
+				Expect(evaluator.Evaluate(quote("AAPL", 97), timeStart.Add(3*time.Second))).To(BeEmpty())
+				Expect(evaluator.Evaluate(quote("AAPL", 102), timeStart.Add(4*time.Second))).To(HaveLen(1))
+			})
+		})
+
+		When("the price reaches a 52-week high or low", func() {
+			It("should return an alert", func() {
+				evaluator := NewEvaluator(Config{Rules: []c.ConfigAlert{
+					{Symbol: "AAPL", Condition: ConditionFiftyTwoWeekHigh},
+					{Symbol: "AAPL", Condition: ConditionFiftyTwoWeekLow},
+				}})
+
+				assetQuote := quote("AAPL", 250)
+				assetQuote.QuoteExtended = c.QuoteExtended{FiftyTwoWeekHigh: 250, FiftyTwoWeekLow: 150}
+
+				alerts := evaluator.Evaluate(assetQuote, timeStart)
+				Expect(alerts).To(HaveLen(1))
+				Expect(alerts[0].Condition).To(Equal(ConditionFiftyTwoWeekHigh))
+				Expect(alerts[0].Message).To(Equal("AAPL reached a 52-week high of 250.00"))
+			})
+		})
+
+		When("the quote is for a different symbol", func() {
+			It("should not return an alert", func() {
+				evaluator := NewEvaluator(Config{Rules: []c.ConfigAlert{
+					{Symbol: "AAPL", Condition: ConditionAbove, Value: 200},
+				}})
+
+				Expect(evaluator.Evaluate(quote("MSFT", 500), timeStart)).To(BeEmpty())
+			})
+		})
+	})
+})
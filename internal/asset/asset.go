@@ -1,6 +1,7 @@
 package asset
 
 import (
+	"slices"
 	"strings"
 
 	c "github.com/achannarasappa/ticker/v5/internal/common"
@@ -16,21 +17,26 @@ type AggregatedLot struct {
 
 // PositionSummary represents a summary of all asset positions at a point in time
 type PositionSummary struct {
-	Value       float64
-	Cost        float64
-	TotalChange c.PositionChange
-	DayChange   c.PositionChange
+	Value        float64
+	Cost         float64
+	TotalChange  c.PositionChange
+	DayChange    c.PositionChange
+	RealizedGain float64
+	Income       float64
+	TotalReturn  c.PositionChange
 }
 
 // GetAssets returns assets from an asset group quote
 func GetAssets(ctx c.Context, assetGroupQuote c.AssetGroupQuote) ([]c.Asset, PositionSummary) {
 
-	lots := assetGroupQuote.AssetGroup.ConfigAssetGroup.Lots
+	lots := append(slices.Clone(assetGroupQuote.AssetGroup.ConfigAssetGroup.Lots), getCashLots(assetGroupQuote.AssetGroup.ConfigAssetGroup.Cash)...)
 
 	var positionSummary PositionSummary
 	assets := make([]c.Asset, 0)
 	summaryValues := make([]float64, 0) // per-asset value expressed in the summary/display currency, used for weights
-	lotsBySymbol := getLots(lots)
+	openLots, realizedGainBySymbol := matchLots(lots, ctx.Config.LotMatching)
+	lotsBySymbol := getLots(openLots)
+	incomeBySymbol := getIncome(assetGroupQuote.AssetGroup.ConfigAssetGroup.Dividends)
 	orderIndex := make(map[string]int)
 
 	for i, lot := range lots {
@@ -50,6 +56,9 @@ func GetAssets(ctx c.Context, assetGroupQuote c.AssetGroupQuote) ([]c.Asset, Pos
 		currencyRateByUse := getCurrencyRateByUse(ctx, assetQuote.Class, assetQuote.Currency.FromCurrencyCode, assetQuote.Currency.ToCurrencyCode, assetQuote.Currency.Rate)
 
 		position := getPositionFromAssetQuote(assetQuote, lotsBySymbol, currencyRateByUse)
+		position.RealizedGain = realizedGainBySymbol[strings.ToUpper(assetQuote.Symbol)] * currencyRateByUse.PositionCost
+		position.Income = incomeBySymbol[strings.ToUpper(assetQuote.Symbol)] * currencyRateByUse.PositionCost
+		position.TotalReturn = getTotalReturn(position.TotalChange.Amount, position.Income, position.Cost)
 		positionSummary = addPositionToPositionSummary(positionSummary, position, currencyRateByUse)
 		summaryValues = append(summaryValues, position.Value*currencyRateByUse.SummaryValue)
 
@@ -68,8 +77,12 @@ func GetAssets(ctx c.Context, assetGroupQuote c.AssetGroupQuote) ([]c.Asset, Pos
 			QuoteSource:   assetQuote.QuoteSource,
 			Exchange:      assetQuote.Exchange,
 			Meta: c.Meta{
-				IsVariablePrecision: assetQuote.Meta.IsVariablePrecision,
-				OrderIndex:          orderIndex[strings.ToLower(assetQuote.Symbol)],
+				IsVariablePrecision:   assetQuote.Meta.IsVariablePrecision,
+				OrderIndex:            orderIndex[strings.ToLower(assetQuote.Symbol)],
+				SymbolInSourceAPI:     assetQuote.Meta.SymbolInSourceAPI,
+				LastUpdated:           assetQuote.Meta.LastUpdated,
+				IsStale:               assetQuote.Meta.IsStale,
+				AlternativeSourceName: assetQuote.Meta.AlternativeSourceName,
 			},
 		})
 
@@ -92,7 +105,13 @@ func calculateChangePercent(changeAmount float64, base float64) float64 {
 
 func addPositionToPositionSummary(positionSummary PositionSummary, position c.Position, currencyRateByUse currencyRateByUse) PositionSummary {
 
+	// Realized gains and income are included for assets that have been sold entirely and no longer have a value
+	positionSummary.RealizedGain += position.RealizedGain * currencyRateByUse.SummaryCost
+	positionSummary.Income += position.Income * currencyRateByUse.SummaryCost
+
 	if position.Value == 0 {
+		positionSummary.TotalReturn = getTotalReturn(positionSummary.TotalChange.Amount, positionSummary.Income, positionSummary.Cost)
+
 		return positionSummary
 	}
 
@@ -115,6 +134,17 @@ func addPositionToPositionSummary(positionSummary PositionSummary, position c.Po
 			Amount:  dayChange,
 			Percent: dayChangePercent,
 		},
+		RealizedGain: positionSummary.RealizedGain,
+		Income:       positionSummary.Income,
+		TotalReturn:  getTotalReturn(totalChange, positionSummary.Income, cost),
+	}
+}
+
+// getTotalReturn returns the change in value of a position including the income it has paid out
+func getTotalReturn(totalChange float64, income float64, cost float64) c.PositionChange {
+	return c.PositionChange{
+		Amount:  totalChange + income,
+		Percent: calculateChangePercent(totalChange+income, cost),
 	}
 }
 
@@ -136,7 +166,7 @@ func updatePositionWeights(assets []c.Asset, summaryValues []float64, positionSu
 
 func getPositionFromAssetQuote(assetQuote c.AssetQuote, lotsBySymbol map[string]AggregatedLot, currencyRateByUse currencyRateByUse) c.Position {
 
-	if aggregatedLot, ok := lotsBySymbol[assetQuote.Symbol]; ok {
+	if aggregatedLot, ok := lotsBySymbol[strings.ToUpper(assetQuote.Symbol)]; ok {
 		// For futures contracts, multiply price by contract size for PnL calculations
 		// The displayed price remains unchanged (uses QuotePrice.Price directly)
 		priceForPosition := assetQuote.QuotePrice.Price
@@ -182,6 +212,37 @@ func getPositionFromAssetQuote(assetQuote c.AssetQuote, lotsBySymbol map[string]
 
 }
 
+// getIncome returns the total dividends and distributions received for each symbol keyed by the uppercase symbol
+func getIncome(dividends []c.Dividend) map[string]float64 {
+
+	incomeBySymbol := make(map[string]float64)
+
+	for _, dividend := range dividends {
+		incomeBySymbol[strings.ToUpper(dividend.Symbol)] += dividend.Amount
+	}
+
+	return incomeBySymbol
+}
+
+// getCashLots represents each cash balance as a lot of its currency's cash symbol (e.g. EUR.CASH) with a unit cost of one
+// so that cash is valued, converted, and weighted in the same way as any other position
+func getCashLots(cash []c.ConfigCash) []c.Lot {
+
+	lots := make([]c.Lot, 0, len(cash))
+
+	for _, balance := range cash {
+		lots = append(lots, c.Lot{
+			Symbol:   strings.ToUpper(balance.Currency) + ".CASH",
+			UnitCost: 1,
+			Quantity: balance.Amount,
+		})
+	}
+
+	return lots
+}
+
+// getLots returns the lots of each symbol aggregated into a single lot keyed by the uppercase symbol since symbols in the
+// config may not match the case of the symbols on quotes
 func getLots(lots []c.Lot) map[string]AggregatedLot {
 
 	if lots == nil {
@@ -192,11 +253,12 @@ func getLots(lots []c.Lot) map[string]AggregatedLot {
 
 	for i, lot := range lots {
 
-		aggregatedLot, ok := aggregatedLots[lot.Symbol]
+		symbol := strings.ToUpper(lot.Symbol)
+		aggregatedLot, ok := aggregatedLots[symbol]
 
 		if !ok {
 
-			aggregatedLots[lot.Symbol] = AggregatedLot{
+			aggregatedLots[symbol] = AggregatedLot{
 				Symbol:     lot.Symbol,
 				Cost:       (lot.UnitCost * lot.Quantity) + lot.FixedCost,
 				Quantity:   lot.Quantity,
@@ -208,7 +270,7 @@ func getLots(lots []c.Lot) map[string]AggregatedLot {
 			aggregatedLot.Quantity += lot.Quantity
 			aggregatedLot.Cost += lot.Quantity * lot.UnitCost
 
-			aggregatedLots[lot.Symbol] = aggregatedLot
+			aggregatedLots[symbol] = aggregatedLot
 
 		}
 
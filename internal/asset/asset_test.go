@@ -244,6 +244,96 @@ var _ = Describe("Asset", func() {
 			})
 		})
 
+		When("there are cash balances", func() {
+			It("should include the converted cash balances in the position summary and weights", func() {
+				inputContext := c.Context{}
+				inputAssetGroupQuote := c.AssetGroupQuote{
+					AssetGroup: c.AssetGroup{
+						ConfigAssetGroup: c.ConfigAssetGroup{
+							Lots: []c.Lot{
+								{Symbol: "TWKS", UnitCost: 100, Quantity: 10},
+							},
+							Cash: []c.ConfigCash{
+								{Currency: "eur", Amount: 500},
+								{Currency: "EUR", Amount: 500},
+							},
+						},
+					},
+					AssetQuotes: []c.AssetQuote{
+						{
+							Symbol:     "TWKS",
+							Class:      c.AssetClassStock,
+							Currency:   c.Currency{FromCurrencyCode: "USD"},
+							QuotePrice: c.QuotePrice{Price: 100, PricePrevClose: 100},
+						},
+						{
+							Symbol:     "EUR.CASH",
+							Class:      c.AssetClassCash,
+							Currency:   c.Currency{FromCurrencyCode: "EUR", ToCurrencyCode: "USD", Rate: 1.5},
+							QuotePrice: c.QuotePrice{Price: 1, PricePrevClose: 1},
+						},
+					},
+				}
+
+				outputAssets, outputPositionSummary := GetAssets(inputContext, inputAssetGroupQuote)
+
+				Expect(outputAssets).To(HaveLen(2))
+				Expect(outputAssets[1].Position.Quantity).To(Equal(1000.0))
+				Expect(outputAssets[1].Position.Value).To(Equal(1000.0))
+				Expect(outputAssets[1].Position.TotalChange.Amount).To(Equal(0.0))
+				// EUR cash is 1500 of a 2500 USD total
+				Expect(outputAssets[1].Position.Weight).To(Equal(60.0))
+				// Cash is ordered after lots
+				Expect(outputAssets[1].Meta.OrderIndex).To(BeNumerically(">", outputAssets[0].Meta.OrderIndex))
+				Expect(outputPositionSummary.Value).To(Equal(2500.0))
+				// Cash cost is converted at the same rate as its value
+				Expect(outputPositionSummary.TotalChange.Amount).To(Equal(0.0))
+			})
+		})
+
+		When("there are dividends", func() {
+			It("should include the income in the total return of the position and summary", func() {
+				inputContext := c.Context{}
+				inputAssetGroupQuote := fixtureAssetGroupQuote
+				inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Lots = []c.Lot{
+					{Symbol: "TWKS", UnitCost: 100, Quantity: 10},
+					{Symbol: "MSFT", UnitCost: 200, Quantity: 10},
+				}
+				inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Dividends = []c.Dividend{
+					{Symbol: "TWKS", Amount: 25, Date: "2024-03-15"},
+					{Symbol: "TWKS", Amount: 25, Date: "2024-06-15"},
+					{Symbol: "SOL1-USD", Amount: 10},
+				}
+
+				outputAssets, outputPositionSummary := GetAssets(inputContext, inputAssetGroupQuote)
+
+				Expect(outputAssets[0].Position.Income).To(Equal(50.0))
+				Expect(outputAssets[0].Position.TotalReturn).To(Equal(c.PositionChange{Amount: 150.0, Percent: 15.0}))
+				Expect(outputAssets[1].Position.Income).To(Equal(0.0))
+				Expect(outputAssets[1].Position.TotalReturn).To(Equal(c.PositionChange{Amount: 200.0, Percent: 10.0}))
+				// Income from an asset without a position is still included in the summary
+				Expect(outputAssets[2].Position.Income).To(Equal(10.0))
+				Expect(outputPositionSummary.Income).To(Equal(60.0))
+				Expect(outputPositionSummary.TotalReturn.Amount).To(Equal(360.0))
+				Expect(outputPositionSummary.TotalReturn.Percent).To(Equal(12.0))
+			})
+
+			When("the dividend symbols differ in case from the symbol of the quote", func() {
+				It("should include the income in the position", func() {
+					inputAssetGroupQuote := fixtureAssetGroupQuote
+					inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Dividends = []c.Dividend{
+						{Symbol: "twks", Amount: 25},
+						{Symbol: "Twks", Amount: 25},
+					}
+
+					outputAssets, _ := GetAssets(c.Context{}, inputAssetGroupQuote)
+
+					Expect(outputAssets[0].Symbol).To(Equal("TWKS"))
+					Expect(outputAssets[0].Position.Income).To(Equal(50.0))
+				})
+			})
+		})
+
 		When("unit cost is zero", func() {
 			When("and fixed cost is also zero", func() {
 				It("should handle zero cost without division by zero", func() {
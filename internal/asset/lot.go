@@ -0,0 +1,214 @@
+package asset
+
+import (
+	"math"
+	"slices"
+	"strings"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+const (
+	lotTypeSell = "sell"
+
+	lotMatchingLIFO     = "lifo"
+	lotMatchingSpecific = "specific"
+	lotMatchingAverage  = "average"
+)
+
+// LotPosition represents an open lot of an asset and its gain or loss at the current price
+type LotPosition struct {
+	Lot         c.Lot // Lot with the quantity and fixed cost remaining after sells
+	Value       float64
+	Cost        float64
+	TotalChange c.PositionChange
+}
+
+// GetLotPositions returns the open lots of a symbol in an asset group quote along with the value and gain or loss of each
+// lot converted in the same way as the asset's position
+func GetLotPositions(ctx c.Context, assetGroupQuote c.AssetGroupQuote, symbol string) []LotPosition {
+
+	lotPositions := make([]LotPosition, 0)
+	assetQuoteIndex := slices.IndexFunc(assetGroupQuote.AssetQuotes, func(assetQuote c.AssetQuote) bool {
+		return assetQuote.Symbol == symbol
+	})
+
+	if assetQuoteIndex == -1 {
+		return lotPositions
+	}
+
+	assetQuote := assetGroupQuote.AssetQuotes[assetQuoteIndex]
+	currencyRateByUse := getCurrencyRateByUse(ctx, assetQuote.Class, assetQuote.Currency.FromCurrencyCode, assetQuote.Currency.ToCurrencyCode, assetQuote.Currency.Rate)
+	lots := append(slices.Clone(assetGroupQuote.AssetGroup.ConfigAssetGroup.Lots), getCashLots(assetGroupQuote.AssetGroup.ConfigAssetGroup.Cash)...)
+	openLots, _ := matchLots(lots, ctx.Config.LotMatching)
+
+	priceForPosition := assetQuote.QuotePrice.Price
+	if assetQuote.Class == c.AssetClassFuturesContract {
+		priceForPosition *= assetQuote.QuoteFutures.ContractSize
+	}
+
+	for _, lot := range openLots {
+		if !strings.EqualFold(lot.Symbol, symbol) {
+			continue
+		}
+
+		value := lot.Quantity * priceForPosition * currencyRateByUse.QuotePrice
+		cost := ((lot.Quantity * lot.UnitCost) + lot.FixedCost) * currencyRateByUse.PositionCost
+
+		lotPositions = append(lotPositions, LotPosition{
+			Lot:   lot,
+			Value: value,
+			Cost:  cost,
+			TotalChange: c.PositionChange{
+				Amount:  value - cost,
+				Percent: calculateChangePercent(value-cost, cost),
+			},
+		})
+	}
+
+	return lotPositions
+}
+
+// GetUnitCosts returns the unit cost of the open lots of each symbol in the currency the lots were entered in
+func GetUnitCosts(lots []c.Lot, lotMatching string) map[string]float64 {
+
+	openLots, _ := matchLots(lots, lotMatching)
+	unitCosts := make(map[string]float64)
+
+	for symbol, aggregatedLot := range getLots(openLots) {
+		if aggregatedLot.Quantity != 0 {
+			unitCosts[symbol] = aggregatedLot.Cost / aggregatedLot.Quantity
+		}
+	}
+
+	return unitCosts
+}
+
+// matchLots matches each sell to the buys of the same symbol in any case listed before it using the lot matching method
+// and returns the quantity of each buy that remains open along with the realized gain by uppercase symbol
+func matchLots(lots []c.Lot, method string) ([]c.Lot, map[string]float64) {
+
+	openLots := make([]c.Lot, 0, len(lots))
+	openLotIndexesBySymbol := make(map[string][]int)
+	realizedGainBySymbol := make(map[string]float64)
+
+	for _, lot := range lots {
+
+		symbol := strings.ToUpper(lot.Symbol)
+
+		if lot.Type != lotTypeSell {
+			openLotIndexesBySymbol[symbol] = append(openLotIndexesBySymbol[symbol], len(openLots))
+			openLots = append(openLots, lot)
+
+			continue
+		}
+
+		indexes := getMatchingLotIndexes(openLots, openLotIndexesBySymbol[symbol], lot, method)
+
+		var costBasis, quantitySold float64
+		if method == lotMatchingAverage {
+			costBasis, quantitySold = sellAverageCost(openLots, indexes, lot.Quantity)
+		} else {
+			costBasis, quantitySold = sellLots(openLots, indexes, lot.Quantity)
+		}
+
+		// Only the quantity matched to open lots has a cost basis so the rest of the sell is not counted in the proceeds
+		proceeds := (quantitySold * lot.UnitCost) - (lot.FixedCost * (quantitySold / lot.Quantity))
+		realizedGainBySymbol[symbol] += proceeds - costBasis
+
+	}
+
+	remainingLots := make([]c.Lot, 0, len(openLots))
+
+	for _, lot := range openLots {
+		if lot.Quantity != 0 {
+			remainingLots = append(remainingLots, lot)
+		}
+	}
+
+	return remainingLots, realizedGainBySymbol
+}
+
+// getMatchingLotIndexes returns the indexes of the open lots a sell is matched against in the order they are sold. A sell
+// of a specific lot larger than the lot is filled from the other open lots first in first out.
+func getMatchingLotIndexes(openLots []c.Lot, indexes []int, sell c.Lot, method string) []int {
+
+	if method == lotMatchingSpecific && sell.LotID != "" {
+		for n, i := range indexes {
+			if openLots[i].ID == sell.LotID {
+				return append([]int{i}, slices.Delete(slices.Clone(indexes), n, n+1)...)
+			}
+		}
+	}
+
+	if method == lotMatchingLIFO {
+		reversed := make([]int, len(indexes))
+		for i, index := range indexes {
+			reversed[len(indexes)-1-i] = index
+		}
+
+		return reversed
+	}
+
+	return indexes
+}
+
+// sellLots reduces the quantity of each open lot in order until the quantity sold is filled and returns the cost basis of
+// the quantity sold including the proportional share of each lot's fixed cost along with the quantity which was matched
+func sellLots(openLots []c.Lot, indexes []int, quantity float64) (float64, float64) {
+
+	var costBasis, quantityMatched float64
+
+	for _, i := range indexes {
+
+		if quantity <= 0 {
+			break
+		}
+
+		if openLots[i].Quantity <= 0 {
+			continue
+		}
+
+		quantitySold := math.Min(quantity, openLots[i].Quantity)
+		fixedCostSold := openLots[i].FixedCost * (quantitySold / openLots[i].Quantity)
+
+		costBasis += (quantitySold * openLots[i].UnitCost) + fixedCostSold
+
+		openLots[i].Quantity -= quantitySold
+		openLots[i].FixedCost -= fixedCostSold
+		quantity -= quantitySold
+		quantityMatched += quantitySold
+
+	}
+
+	return costBasis, quantityMatched
+}
+
+// sellAverageCost reduces all open lots by the same proportion and returns the cost basis of the quantity sold at the
+// average cost of the open lots along with the quantity which was matched
+func sellAverageCost(openLots []c.Lot, indexes []int, quantity float64) (float64, float64) {
+
+	var openQuantity, openCost float64
+
+	for _, i := range indexes {
+		if openLots[i].Quantity > 0 {
+			openQuantity += openLots[i].Quantity
+			openCost += (openLots[i].Quantity * openLots[i].UnitCost) + openLots[i].FixedCost
+		}
+	}
+
+	if openQuantity == 0 {
+		return 0, 0
+	}
+
+	proportionSold := math.Min(quantity/openQuantity, 1)
+
+	for _, i := range indexes {
+		if openLots[i].Quantity > 0 {
+			openLots[i].Quantity -= openLots[i].Quantity * proportionSold
+			openLots[i].FixedCost -= openLots[i].FixedCost * proportionSold
+		}
+	}
+
+	return openCost * proportionSold, openQuantity * proportionSold
+}
@@ -0,0 +1,206 @@
+package asset_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/achannarasappa/ticker/v5/internal/asset"
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+var _ = Describe("Lot", func() {
+
+	Describe("GetAssets", func() {
+
+		When("there are sells", func() {
+
+			var inputAssetGroupQuote c.AssetGroupQuote
+
+			BeforeEach(func() {
+				inputAssetGroupQuote = fixtureAssetGroupQuote
+				inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Lots = []c.Lot{
+					{Symbol: "TWKS", UnitCost: 100, Quantity: 10, FixedCost: 10, ID: "a"},
+					{Symbol: "TWKS", UnitCost: 80, Quantity: 10, ID: "b"},
+					{Symbol: "TWKS", UnitCost: 120, Quantity: 5, FixedCost: 5, Type: "sell", LotID: "b"},
+				}
+			})
+
+			DescribeTable("should match sells to buys and calculate the realized gain",
+				func(lotMatching string, expectedRealizedGain float64, expectedQuantity float64, expectedCost float64) {
+					inputContext := c.Context{Config: c.Config{LotMatching: lotMatching}}
+
+					outputAssets, outputPositionSummary := GetAssets(inputContext, inputAssetGroupQuote)
+
+					Expect(outputAssets[0].Position.RealizedGain).To(Equal(expectedRealizedGain))
+					Expect(outputAssets[0].Position.Quantity).To(Equal(expectedQuantity))
+					Expect(outputAssets[0].Position.Cost).To(Equal(expectedCost))
+					Expect(outputPositionSummary.RealizedGain).To(Equal(expectedRealizedGain))
+				},
+				// Proceeds are 5 * 120 - 5 = 595
+				Entry("first in first out by default", "", 90.0, 15.0, 1305.0),
+				Entry("first in first out", "fifo", 90.0, 15.0, 1305.0),
+				Entry("last in first out", "lifo", 195.0, 15.0, 1410.0),
+				Entry("specific lot", "specific", 195.0, 15.0, 1410.0),
+				Entry("average cost", "average", 142.5, 15.0, 1357.5),
+			)
+
+			When("an asset has been sold entirely", func() {
+				It("should include the realized gain in the position summary", func() {
+					inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Lots = append(
+						inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Lots,
+						c.Lot{Symbol: "MSFT", UnitCost: 400, Quantity: 10},
+						c.Lot{Symbol: "MSFT", UnitCost: 220, Quantity: 10, Type: "sell"},
+					)
+
+					outputAssets, outputPositionSummary := GetAssets(c.Context{}, inputAssetGroupQuote)
+
+					Expect(outputAssets[1].Position.Value).To(Equal(0.0))
+					Expect(outputAssets[1].Position.Quantity).To(Equal(0.0))
+					Expect(outputAssets[1].Position.RealizedGain).To(Equal(-1800.0))
+					Expect(outputPositionSummary.RealizedGain).To(Equal(-1710.0))
+					Expect(outputPositionSummary.Value).To(Equal(1650.0))
+				})
+			})
+
+			When("the specific lot to sell is not set", func() {
+				It("should match the sell first in first out", func() {
+					inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Lots[2].LotID = ""
+
+					outputAssets, _ := GetAssets(c.Context{Config: c.Config{LotMatching: "specific"}}, inputAssetGroupQuote)
+
+					Expect(outputAssets[0].Position.RealizedGain).To(Equal(90.0))
+				})
+			})
+
+			When("a sell is larger than the open quantity", func() {
+				It("should only count the proceeds of the quantity matched to open lots", func() {
+					inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Lots = []c.Lot{
+						{Symbol: "TWKS", UnitCost: 100, Quantity: 10},
+						{Symbol: "TWKS", UnitCost: 120, Quantity: 20, FixedCost: 10, Type: "sell"},
+					}
+
+					outputAssets, _ := GetAssets(c.Context{}, inputAssetGroupQuote)
+
+					// Proceeds are 10 * 120 - 5 = 1195
+					Expect(outputAssets[0].Position.RealizedGain).To(Equal(195.0))
+					Expect(outputAssets[0].Position.Quantity).To(Equal(0.0))
+				})
+			})
+
+			When("a sell has no earlier buy of the same symbol", func() {
+				It("should not have a realized gain", func() {
+					inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Lots = []c.Lot{
+						{Symbol: "TWKS", UnitCost: 120, Quantity: 5, Type: "sell"},
+						{Symbol: "TWKS", UnitCost: 100, Quantity: 10},
+					}
+
+					outputAssets, _ := GetAssets(c.Context{}, inputAssetGroupQuote)
+
+					Expect(outputAssets[0].Position.RealizedGain).To(Equal(0.0))
+					Expect(outputAssets[0].Position.Quantity).To(Equal(10.0))
+				})
+			})
+
+			When("a sell of a specific lot is larger than the lot", func() {
+				It("should fill the rest of the sell from the other open lots first in first out", func() {
+					inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Lots = []c.Lot{
+						{Symbol: "TWKS", UnitCost: 100, Quantity: 10, ID: "a"},
+						{Symbol: "TWKS", UnitCost: 90, Quantity: 10, ID: "b"},
+						{Symbol: "TWKS", UnitCost: 80, Quantity: 10, ID: "c"},
+						{Symbol: "TWKS", UnitCost: 120, Quantity: 15, Type: "sell", LotID: "c"},
+					}
+
+					outputAssets, _ := GetAssets(c.Context{Config: c.Config{LotMatching: "specific"}}, inputAssetGroupQuote)
+
+					// Cost basis is 10 * 80 + 5 * 100 = 1300
+					Expect(outputAssets[0].Position.RealizedGain).To(Equal(500.0))
+					Expect(outputAssets[0].Position.Quantity).To(Equal(15.0))
+					Expect(outputAssets[0].Position.Cost).To(Equal(1400.0))
+				})
+			})
+
+			When("the lot symbols differ in case from the symbol of the quote", func() {
+				It("should match the lots to the quote and sells to buys in any case", func() {
+					inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Lots = []c.Lot{
+						{Symbol: "twks", UnitCost: 100, Quantity: 10},
+						{Symbol: "Twks", UnitCost: 80, Quantity: 10},
+						{Symbol: "TWKS", UnitCost: 120, Quantity: 5, Type: "sell"},
+					}
+
+					outputAssets, _ := GetAssets(c.Context{}, inputAssetGroupQuote)
+					outputLotPositions := GetLotPositions(c.Context{}, inputAssetGroupQuote, "TWKS")
+
+					Expect(outputAssets[0].Symbol).To(Equal("TWKS"))
+					Expect(outputAssets[0].Position.Quantity).To(Equal(15.0))
+					Expect(outputAssets[0].Position.Cost).To(Equal(1300.0))
+					Expect(outputAssets[0].Position.RealizedGain).To(Equal(100.0))
+					Expect(outputLotPositions).To(HaveLen(2))
+				})
+			})
+
+			When("the asset quote is in a different currency", func() {
+				It("should convert the realized gain", func() {
+					inputAssetQuotes := make([]c.AssetQuote, len(fixtureAssetGroupQuote.AssetQuotes))
+					copy(inputAssetQuotes, fixtureAssetGroupQuote.AssetQuotes)
+					inputAssetQuotes[0].Currency = c.Currency{FromCurrencyCode: "USD", ToCurrencyCode: "EUR", Rate: 0.5}
+					inputAssetGroupQuote.AssetQuotes = inputAssetQuotes
+
+					outputAssets, outputPositionSummary := GetAssets(c.Context{Config: c.Config{Currency: "EUR"}}, inputAssetGroupQuote)
+
+					Expect(outputAssets[0].Position.RealizedGain).To(Equal(45.0))
+					Expect(outputPositionSummary.RealizedGain).To(Equal(45.0))
+				})
+			})
+		})
+	})
+
+	Describe("GetLotPositions", func() {
+
+		It("should return the open lots of the symbol with the gain or loss of each", func() {
+			inputAssetGroupQuote := fixtureAssetGroupQuote
+			inputAssetGroupQuote.AssetGroup.ConfigAssetGroup.Lots = []c.Lot{
+				{Symbol: "TWKS", UnitCost: 100, Quantity: 10, FixedCost: 10},
+				{Symbol: "MSFT", UnitCost: 200, Quantity: 1},
+				{Symbol: "TWKS", UnitCost: 80, Quantity: 10},
+				{Symbol: "TWKS", UnitCost: 120, Quantity: 5, Type: "sell"},
+			}
+
+			outputLotPositions := GetLotPositions(c.Context{}, inputAssetGroupQuote, "TWKS")
+
+			Expect(outputLotPositions).To(HaveLen(2))
+			Expect(outputLotPositions[0].Lot).To(Equal(c.Lot{Symbol: "TWKS", UnitCost: 100, Quantity: 5, FixedCost: 5}))
+			Expect(outputLotPositions[0].Value).To(Equal(550.0))
+			Expect(outputLotPositions[0].Cost).To(Equal(505.0))
+			Expect(outputLotPositions[0].TotalChange.Amount).To(Equal(45.0))
+			Expect(outputLotPositions[0].TotalChange.Percent).To(BeNumerically("~", 8.91, 0.01))
+			Expect(outputLotPositions[1]).To(Equal(LotPosition{
+				Lot:         c.Lot{Symbol: "TWKS", UnitCost: 80, Quantity: 10},
+				Value:       1100,
+				Cost:        800,
+				TotalChange: c.PositionChange{Amount: 300, Percent: 37.5},
+			}))
+		})
+
+		When("there is no quote for the symbol", func() {
+			It("should return no lots", func() {
+				Expect(GetLotPositions(c.Context{}, fixtureAssetGroupQuote, "NOPE")).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("GetUnitCosts", func() {
+		It("should return the unit cost of the open lots of each symbol", func() {
+			outputUnitCosts := GetUnitCosts([]c.Lot{
+				{Symbol: "TWKS", UnitCost: 100, Quantity: 10},
+				{Symbol: "TWKS", UnitCost: 120, Quantity: 10},
+				{Symbol: "TWKS", UnitCost: 130, Quantity: 10, Type: "sell"},
+				{Symbol: "MSFT", UnitCost: 50, Quantity: 4, FixedCost: 4},
+			}, "fifo")
+
+			Expect(outputUnitCosts).To(Equal(map[string]float64{
+				"TWKS": 120,
+				"MSFT": 51,
+			}))
+		})
+	})
+})
@@ -24,6 +24,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/achannarasappa/ticker/v5/internal/metrics"
 	"github.com/adrg/xdg"
 	"github.com/spf13/afero"
 )
@@ -87,18 +88,20 @@ func (c *Cache) Get(key string, out any) bool {
 	cached, exists := c.entries[keyPrefix()+key]
 	c.mu.Unlock()
 
-	if !exists {
-		return false
-	}
+	if !exists || !time.Now().Before(cached.ExpiresAt) {
+		metrics.CacheLookups.WithLabelValues(metrics.ResultMiss).Inc()
 
-	if !time.Now().Before(cached.ExpiresAt) {
 		return false
 	}
 
 	if err := json.Unmarshal(cached.Payload, out); err != nil {
+		metrics.CacheLookups.WithLabelValues(metrics.ResultMiss).Inc()
+
 		return false
 	}
 
+	metrics.CacheLookups.WithLabelValues(metrics.ResultHit).Inc()
+
 	return true
 }
 
@@ -0,0 +1,292 @@
+package calendar
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+	_ "time/tzdata" // Exchange time zones are embedded so they do not depend on the system time zone database
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+// maxDaysToSearch is the number of days searched for the next open or close which is longer than any run of holidays
+const maxDaysToSearch = 14
+
+// clock is a time of day in minutes after midnight
+type clock int
+
+func hm(hour int, minute int) clock {
+	return clock(hour*60 + minute)
+}
+
+// session is a period of continuous trading in a day
+type session struct {
+	open  clock
+	close clock
+}
+
+// Exchange is the trading calendar of a stock exchange with its hours in local time and holidays
+type Exchange struct {
+	Code            string
+	Name            string
+	location        *time.Location
+	sessions        []session // Regular trading sessions in order with more than one when trading breaks for lunch
+	preMarketOpen   clock     // Start of trading before the regular session or zero if there is none
+	postMarketClose clock     // End of trading after the regular session or zero if there is none
+	holidays        []holiday
+	earlyCloses     []earlyClose
+	isBridged       bool // Whether a weekday between two holidays is also a holiday
+}
+
+// GetState returns whether the exchange is in a regular or extended trading session or closed at a time
+func (e *Exchange) GetState(t time.Time) c.ExchangeState {
+
+	local := t.In(e.location)
+	sessions := e.getSessions(local)
+
+	if len(sessions) == 0 {
+		return c.ExchangeStateClosed
+	}
+
+	now := clock(local.Hour()*60 + local.Minute())
+
+	for _, s := range sessions {
+		if now >= s.open && now < s.close {
+			return c.ExchangeStateOpen
+		}
+	}
+
+	if e.preMarketOpen != 0 && now >= e.preMarketOpen && now < sessions[0].open {
+		return c.ExchangeStatePremarket
+	}
+
+	if e.postMarketClose != 0 && now >= sessions[len(sessions)-1].close && now < e.postMarketClose {
+		return c.ExchangeStatePostmarket
+	}
+
+	return c.ExchangeStateClosed
+}
+
+// IsOpen returns whether the exchange is in a regular trading session at a time
+func (e *Exchange) IsOpen(t time.Time) bool {
+	return e.GetState(t) == c.ExchangeStateOpen
+}
+
+// IsActive returns whether the exchange is in a regular or extended trading session at a time
+func (e *Exchange) IsActive(t time.Time) bool {
+	return e.GetState(t) != c.ExchangeStateClosed
+}
+
+// NextOpen returns the start of the next regular trading session after a time
+func (e *Exchange) NextOpen(t time.Time) time.Time {
+	return e.next(t, func(s session) clock { return s.open })
+}
+
+// NextClose returns the end of the current or next regular trading session after a time
+func (e *Exchange) NextClose(t time.Time) time.Time {
+	return e.next(t, func(s session) clock { return s.close })
+}
+
+// nextActive returns the start of the next regular or extended trading session after a time
+func (e *Exchange) nextActive(t time.Time) time.Time {
+	return e.next(t, func(s session) clock {
+		if e.preMarketOpen != 0 && e.preMarketOpen < s.open {
+			return e.preMarketOpen
+		}
+
+		return s.open
+	})
+}
+
+// IsHoliday returns whether the exchange is closed on a weekday for a holiday
+func (e *Exchange) IsHoliday(t time.Time) bool {
+
+	local := t.In(e.location)
+
+	return getHolidays(e.holidays, local.Year(), e.isBridged)[date(local.Year(), local.Month(), local.Day())]
+}
+
+func (e *Exchange) next(t time.Time, boundary func(session) clock) time.Time {
+
+	local := t.In(e.location)
+
+	for i := range maxDaysToSearch {
+		day := time.Date(local.Year(), local.Month(), local.Day()+i, 0, 0, 0, 0, e.location)
+
+		for _, s := range e.getSessions(day) {
+			// Times are set from the wall clock rather than added to midnight so they are correct on days clocks change
+			at := time.Date(day.Year(), day.Month(), day.Day(), 0, int(boundary(s)), 0, 0, e.location)
+
+			if at.After(t) {
+				return at
+			}
+		}
+	}
+
+	return time.Time{}
+}
+
+// getSessions returns the regular trading sessions on the day of a time in local time and none if the exchange is
+// closed for the day
+func (e *Exchange) getSessions(local time.Time) []session {
+
+	d := date(local.Year(), local.Month(), local.Day())
+
+	if isWeekend(d) || getHolidays(e.holidays, d.Year(), e.isBridged)[d] {
+		return nil
+	}
+
+	for _, rule := range e.earlyCloses {
+		if !rule.date(d.Year()).Equal(d) {
+			continue
+		}
+
+		sessions := make([]session, 0, len(e.sessions))
+
+		for _, s := range e.sessions {
+			if s.open >= rule.close {
+				break
+			}
+
+			sessions = append(sessions, session{open: s.open, close: min(s.close, rule.close)})
+		}
+
+		return sessions
+	}
+
+	return e.sessions
+}
+
+// GetExchange returns the calendar for the exchange an asset is traded on or false if the asset trades at all hours or
+// its exchange does not have a calendar
+func GetExchange(assetQuote c.AssetQuote) (*Exchange, bool) {
+
+	if assetQuote.QuoteSource != c.QuoteSourceYahoo {
+		return nil, false
+	}
+
+	if assetQuote.Class == c.AssetClassCryptocurrency || assetQuote.Class == c.AssetClassCurrency || assetQuote.Class == c.AssetClassFuturesContract {
+		return nil, false
+	}
+
+	if e, exists := exchangesByName[strings.ToLower(assetQuote.Exchange.Name)]; exists {
+		return e, true
+	}
+
+	symbol := assetQuote.Meta.SymbolInSourceAPI
+	if symbol == "" {
+		symbol = assetQuote.Symbol
+	}
+
+	if i := strings.LastIndex(symbol, "."); i >= 0 {
+		e, exists := exchangesBySuffix[strings.ToUpper(symbol[i:])]
+
+		return e, exists
+	}
+
+	return nil, false
+}
+
+// GetExchanges returns the calendars for the exchanges of a set of assets without duplicates and whether all of the
+// assets have a calendar
+func GetExchanges(assetQuotes []c.AssetQuote) ([]*Exchange, bool) {
+
+	exchanges := make([]*Exchange, 0)
+	isAdded := make(map[*Exchange]bool)
+	hasCalendar := true
+
+	for _, assetQuote := range assetQuotes {
+		e, exists := GetExchange(assetQuote)
+		if !exists {
+			hasCalendar = false
+
+			continue
+		}
+
+		if !isAdded[e] {
+			isAdded[e] = true
+			exchanges = append(exchanges, e)
+		}
+	}
+
+	return exchanges, hasCalendar
+}
+
+// GetCountdown returns the time until the exchange which closes soonest closes if any are open or otherwise until the
+// exchange which opens soonest opens
+func GetCountdown(exchanges []*Exchange, now time.Time) string {
+
+	var next *Exchange
+	var nextAt time.Time
+
+	isOpen := slices.ContainsFunc(exchanges, func(e *Exchange) bool { return e.IsOpen(now) })
+
+	for _, e := range exchanges {
+		if isOpen && !e.IsOpen(now) {
+			continue
+		}
+
+		at := e.NextOpen(now)
+		if isOpen {
+			at = e.NextClose(now)
+		}
+
+		if at.IsZero() {
+			continue
+		}
+
+		if next == nil || at.Before(nextAt) {
+			next = e
+			nextAt = at
+		}
+	}
+
+	if next == nil {
+		return ""
+	}
+
+	if isOpen {
+		return next.Code + " closes in " + formatDuration(nextAt.Sub(now))
+	}
+
+	return next.Code + " opens in " + formatDuration(nextAt.Sub(now))
+}
+
+// formatDuration returns a duration rounded up to the minute in days, hours, and minutes with only the two largest units
+func formatDuration(d time.Duration) string {
+
+	minutes := int(math.Ceil(d.Minutes()))
+	days := minutes / (24 * 60)
+	hours := (minutes / 60) % 24
+	minutes %= 60
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
+
+// IsClosedBetween returns whether all of a set of assets have a calendar and none of their exchanges had a regular or
+// extended trading session at any point between two times
+func IsClosedBetween(assetQuotes []c.AssetQuote, from time.Time, to time.Time) bool {
+
+	exchanges, hasCalendar := GetExchanges(assetQuotes)
+
+	if !hasCalendar || len(exchanges) == 0 {
+		return false
+	}
+
+	for _, e := range exchanges {
+		if e.IsActive(from) || !e.nextActive(from).After(to) {
+			return false
+		}
+	}
+
+	return true
+}
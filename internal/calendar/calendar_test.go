@@ -0,0 +1,217 @@
+package calendar_test
+
+import (
+	"time"
+
+	"github.com/achannarasappa/ticker/v5/internal/calendar"
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func newAssetQuote(symbol string, exchangeName string) c.AssetQuote {
+	return c.AssetQuote{
+		Symbol:      symbol,
+		Class:       c.AssetClassStock,
+		QuoteSource: c.QuoteSourceYahoo,
+		Exchange:    c.Exchange{Name: exchangeName},
+		Meta:        c.Meta{SymbolInSourceAPI: symbol},
+	}
+}
+
+func getExchange(exchangeName string) *calendar.Exchange {
+	e, exists := calendar.GetExchange(newAssetQuote("", exchangeName))
+	Expect(exists).To(BeTrue())
+
+	return e
+}
+
+func at(location string, year int, month time.Month, day int, hour int, minute int) time.Time {
+	l, err := time.LoadLocation(location)
+	Expect(err).NotTo(HaveOccurred())
+
+	return time.Date(year, month, day, hour, minute, 0, 0, l)
+}
+
+var _ = Describe("Calendar", func() {
+
+	Describe("GetExchange", func() {
+
+		It("should find the exchange by the exchange name", func() {
+			e, exists := calendar.GetExchange(newAssetQuote("MSFT", "NasdaqGS"))
+
+			Expect(exists).To(BeTrue())
+			Expect(e.Code).To(Equal("NASDAQ"))
+		})
+
+		It("should find the exchange by the symbol suffix when the exchange name is not known", func() {
+			e, exists := calendar.GetExchange(newAssetQuote("SHOP.TO", ""))
+
+			Expect(exists).To(BeTrue())
+			Expect(e.Code).To(Equal("TSX"))
+		})
+
+		When("the asset trades at all hours", func() {
+			It("should not return an exchange", func() {
+				assetQuote := newAssetQuote("BTC-USD", "CCC")
+				assetQuote.Class = c.AssetClassCryptocurrency
+
+				_, exists := calendar.GetExchange(assetQuote)
+
+				Expect(exists).To(BeFalse())
+			})
+		})
+
+		When("the quote is not from Yahoo Finance", func() {
+			It("should not return an exchange", func() {
+				assetQuote := newAssetQuote("MSFT", "NasdaqGS")
+				assetQuote.QuoteSource = c.QuoteSourceUserDefined
+
+				_, exists := calendar.GetExchange(assetQuote)
+
+				Expect(exists).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("IsHoliday", func() {
+
+		DescribeTable("should return whether the exchange is closed for a holiday",
+			func(exchangeName string, location string, year int, month time.Month, day int, expected bool) {
+				Expect(getExchange(exchangeName).IsHoliday(at(location, year, month, day, 12, 0))).To(Equal(expected))
+			},
+			Entry("NYSE Good Friday", "NYSE", "America/New_York", 2026, time.April, 3, true),
+			Entry("NYSE Independence Day observed on Friday", "NYSE", "America/New_York", 2026, time.July, 3, true),
+			Entry("NYSE Thanksgiving", "NYSE", "America/New_York", 2026, time.November, 26, true),
+			Entry("NYSE Christmas observed on Friday", "NYSE", "America/New_York", 2027, time.December, 24, true),
+			Entry("NYSE New Year's Day on Saturday is not observed", "NYSE", "America/New_York", 2021, time.December, 31, false),
+			Entry("NYSE regular day", "NYSE", "America/New_York", 2026, time.October, 19, false),
+			Entry("LSE Boxing Day on Saturday observed on Monday", "LSE", "Europe/London", 2026, time.December, 28, true),
+			Entry("LSE Christmas on Sunday observed after Boxing Day", "LSE", "Europe/London", 2022, time.December, 27, true),
+			Entry("LSE Easter Monday", "LSE", "Europe/London", 2026, time.April, 6, true),
+			Entry("TSX Victoria Day", "Toronto", "America/Toronto", 2026, time.May, 18, true),
+			Entry("XETRA Christmas Eve", "XETRA", "Europe/Berlin", 2026, time.December, 24, true),
+			Entry("TSE substitute holiday for Constitution Day", "Tokyo", "Asia/Tokyo", 2026, time.May, 6, true),
+			Entry("TSE day between two holidays", "Tokyo", "Asia/Tokyo", 2026, time.September, 22, true),
+			Entry("TSE autumnal equinox", "Tokyo", "Asia/Tokyo", 2026, time.September, 23, true),
+			Entry("ASX Australia Day", "ASX", "Australia/Sydney", 2026, time.January, 26, true),
+		)
+	})
+
+	Describe("GetState", func() {
+
+		var nyse *calendar.Exchange
+
+		BeforeEach(func() {
+			nyse = getExchange("NYSE")
+		})
+
+		DescribeTable("should return the state of the exchange",
+			func(t time.Time, expected c.ExchangeState) {
+				Expect(nyse.GetState(t)).To(Equal(expected))
+			},
+			Entry("before pre-market", at("America/New_York", 2026, time.October, 19, 3, 59), c.ExchangeStateClosed),
+			Entry("pre-market", at("America/New_York", 2026, time.October, 19, 4, 0), c.ExchangeStatePremarket),
+			Entry("regular session", at("America/New_York", 2026, time.October, 19, 9, 30), c.ExchangeStateOpen),
+			Entry("post-market", at("America/New_York", 2026, time.October, 19, 16, 0), c.ExchangeStatePostmarket),
+			Entry("after post-market", at("America/New_York", 2026, time.October, 19, 20, 0), c.ExchangeStateClosed),
+			Entry("weekend", at("America/New_York", 2026, time.October, 18, 12, 0), c.ExchangeStateClosed),
+			Entry("holiday", at("America/New_York", 2026, time.November, 26, 12, 0), c.ExchangeStateClosed),
+			Entry("after an early close", at("America/New_York", 2026, time.November, 27, 13, 30), c.ExchangeStatePostmarket),
+			Entry("from another time zone", at("Europe/London", 2026, time.October, 19, 15, 0), c.ExchangeStateOpen),
+		)
+
+		It("should be closed during the lunch break on exchanges which have one", func() {
+			tse := getExchange("Tokyo")
+
+			Expect(tse.GetState(at("Asia/Tokyo", 2026, time.October, 19, 11, 0))).To(Equal(c.ExchangeStateOpen))
+			Expect(tse.GetState(at("Asia/Tokyo", 2026, time.October, 19, 12, 0))).To(Equal(c.ExchangeStateClosed))
+			Expect(tse.GetState(at("Asia/Tokyo", 2026, time.October, 19, 12, 30))).To(Equal(c.ExchangeStateOpen))
+		})
+	})
+
+	Describe("NextOpen", func() {
+
+		It("should skip weekends and holidays", func() {
+			nyse := getExchange("NYSE")
+
+			Expect(nyse.NextOpen(at("America/New_York", 2026, time.April, 2, 17, 0))).To(BeTemporally("==", at("America/New_York", 2026, time.April, 6, 9, 30)))
+		})
+
+		It("should return the local open time on days clocks change", func() {
+			lse := getExchange("LSE")
+
+			Expect(lse.NextOpen(at("Europe/London", 2026, time.March, 27, 17, 0))).To(BeTemporally("==", at("Europe/London", 2026, time.March, 30, 8, 0)))
+		})
+	})
+
+	Describe("NextClose", func() {
+
+		It("should return the early close time", func() {
+			nyse := getExchange("NYSE")
+
+			Expect(nyse.NextClose(at("America/New_York", 2026, time.December, 24, 10, 0))).To(BeTemporally("==", at("America/New_York", 2026, time.December, 24, 13, 0)))
+		})
+	})
+
+	Describe("GetCountdown", func() {
+
+		var exchanges []*calendar.Exchange
+
+		BeforeEach(func() {
+			exchanges = []*calendar.Exchange{getExchange("NYSE"), getExchange("LSE")}
+		})
+
+		When("an exchange is open", func() {
+			It("should return the time until the open exchange which closes first closes", func() {
+				Expect(calendar.GetCountdown(exchanges, at("America/New_York", 2026, time.October, 19, 11, 5))).To(Equal("LSE closes in 25m"))
+			})
+		})
+
+		When("all exchanges are closed", func() {
+			It("should return the time until the exchange which opens first opens", func() {
+				Expect(calendar.GetCountdown(exchanges, at("America/New_York", 2026, time.October, 19, 17, 47))).To(Equal("LSE opens in 9h 13m"))
+			})
+
+			It("should show days when the next open is more than a day away", func() {
+				Expect(calendar.GetCountdown(exchanges, at("America/New_York", 2026, time.October, 17, 12, 0))).To(Equal("LSE opens in 1d 15h"))
+			})
+		})
+
+		When("there are no exchanges", func() {
+			It("should return an empty string", func() {
+				Expect(calendar.GetCountdown([]*calendar.Exchange{}, time.Now())).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("IsClosedBetween", func() {
+
+		var assetQuotes []c.AssetQuote
+
+		BeforeEach(func() {
+			assetQuotes = []c.AssetQuote{newAssetQuote("MSFT", "NasdaqGS"), newAssetQuote("IBM", "NYSE")}
+		})
+
+		It("should return true when the markets for all assets were closed", func() {
+			Expect(calendar.IsClosedBetween(assetQuotes, at("America/New_York", 2026, time.October, 17, 12, 0), at("America/New_York", 2026, time.October, 17, 12, 5))).To(BeTrue())
+		})
+
+		It("should return false when a market opened in between", func() {
+			Expect(calendar.IsClosedBetween(assetQuotes, at("America/New_York", 2026, time.October, 19, 3, 58), at("America/New_York", 2026, time.October, 19, 4, 1))).To(BeFalse())
+		})
+
+		It("should return false when a market is open", func() {
+			Expect(calendar.IsClosedBetween(assetQuotes, at("America/New_York", 2026, time.October, 19, 12, 0), at("America/New_York", 2026, time.October, 19, 12, 5))).To(BeFalse())
+		})
+
+		It("should return false when an asset does not have a calendar", func() {
+			crypto := newAssetQuote("BTC-USD", "CCC")
+			crypto.Class = c.AssetClassCryptocurrency
+			assetQuotes = append(assetQuotes, crypto)
+
+			Expect(calendar.IsClosedBetween(assetQuotes, at("America/New_York", 2026, time.October, 17, 12, 0), at("America/New_York", 2026, time.October, 17, 12, 5))).To(BeFalse())
+		})
+	})
+})
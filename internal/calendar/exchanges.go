@@ -0,0 +1,188 @@
+package calendar
+
+import (
+	"time"
+)
+
+//nolint:gochecknoglobals
+var (
+	holidaysUS = []holiday{
+		{date: fixed(time.January, 1), observance: observeSundayToMonday},
+		{date: nthWeekday(time.January, time.Monday, 3)},  // Martin Luther King Jr. Day
+		{date: nthWeekday(time.February, time.Monday, 3)}, // Presidents' Day
+		{date: easter(-2)}, // Good Friday
+		{date: nthWeekday(time.May, time.Monday, -1)}, // Memorial Day
+		{date: fixed(time.June, 19), observance: observeNearestWeekday},
+		{date: fixed(time.July, 4), observance: observeNearestWeekday},
+		{date: nthWeekday(time.September, time.Monday, 1)},  // Labor Day
+		{date: nthWeekday(time.November, time.Thursday, 4)}, // Thanksgiving
+		{date: fixed(time.December, 25), observance: observeNearestWeekday},
+	}
+	earlyClosesUS = []earlyClose{
+		{date: fixed(time.July, 3), close: hm(13, 0)},
+		{date: func(year int) time.Time { return nthWeekday(time.November, time.Thursday, 4)(year).AddDate(0, 0, 1) }, close: hm(13, 0)},
+		{date: fixed(time.December, 24), close: hm(13, 0)},
+	}
+
+	exchangeNYSE = &Exchange{
+		Code:            "NYSE",
+		Name:            "New York Stock Exchange",
+		location:        loadLocation("America/New_York"),
+		sessions:        []session{{open: hm(9, 30), close: hm(16, 0)}},
+		preMarketOpen:   hm(4, 0),
+		postMarketClose: hm(20, 0),
+		holidays:        holidaysUS,
+		earlyCloses:     earlyClosesUS,
+	}
+	exchangeNASDAQ = &Exchange{
+		Code:            "NASDAQ",
+		Name:            "Nasdaq",
+		location:        loadLocation("America/New_York"),
+		sessions:        []session{{open: hm(9, 30), close: hm(16, 0)}},
+		preMarketOpen:   hm(4, 0),
+		postMarketClose: hm(20, 0),
+		holidays:        holidaysUS,
+		earlyCloses:     earlyClosesUS,
+	}
+	exchangeTSX = &Exchange{
+		Code:     "TSX",
+		Name:     "Toronto Stock Exchange",
+		location: loadLocation("America/Toronto"),
+		sessions: []session{{open: hm(9, 30), close: hm(16, 0)}},
+		holidays: []holiday{
+			{date: fixed(time.January, 1), observance: observeNextWeekday},
+			{date: nthWeekday(time.February, time.Monday, 3)}, // Family Day
+			{date: easter(-2)}, // Good Friday
+			{date: weekdayOnOrBefore(time.May, 24, time.Monday)}, // Victoria Day
+			{date: fixed(time.July, 1), observance: observeNextWeekday},
+			{date: nthWeekday(time.August, time.Monday, 1)},    // Civic Holiday
+			{date: nthWeekday(time.September, time.Monday, 1)}, // Labour Day
+			{date: nthWeekday(time.October, time.Monday, 2)},   // Thanksgiving
+			{date: fixed(time.December, 25), observance: observeNextWeekday},
+			{date: fixed(time.December, 26), observance: observeNextWeekday},
+		},
+		earlyCloses: []earlyClose{
+			{date: fixed(time.December, 24), close: hm(13, 0)},
+		},
+	}
+	exchangeLSE = &Exchange{
+		Code:     "LSE",
+		Name:     "London Stock Exchange",
+		location: loadLocation("Europe/London"),
+		sessions: []session{{open: hm(8, 0), close: hm(16, 30)}},
+		holidays: []holiday{
+			{date: fixed(time.January, 1), observance: observeNextWeekday},
+			{date: easter(-2)}, // Good Friday
+			{date: easter(1)},  // Easter Monday
+			{date: nthWeekday(time.May, time.Monday, 1)},     // Early May bank holiday
+			{date: nthWeekday(time.May, time.Monday, -1)},    // Spring bank holiday
+			{date: nthWeekday(time.August, time.Monday, -1)}, // Summer bank holiday
+			{date: fixed(time.December, 25), observance: observeNextWeekday},
+			{date: fixed(time.December, 26), observance: observeNextWeekday},
+		},
+		earlyCloses: []earlyClose{
+			{date: fixed(time.December, 24), close: hm(12, 30)},
+			{date: fixed(time.December, 31), close: hm(12, 30)},
+		},
+	}
+	exchangeXETRA = &Exchange{
+		Code:     "XETRA",
+		Name:     "Xetra",
+		location: loadLocation("Europe/Berlin"),
+		sessions: []session{{open: hm(9, 0), close: hm(17, 30)}},
+		holidays: []holiday{
+			{date: fixed(time.January, 1)},
+			{date: easter(-2)}, // Good Friday
+			{date: easter(1)},  // Easter Monday
+			{date: fixed(time.May, 1)},
+			{date: fixed(time.December, 24)},
+			{date: fixed(time.December, 25)},
+			{date: fixed(time.December, 26)},
+			{date: fixed(time.December, 31)},
+		},
+	}
+	exchangeTSE = &Exchange{
+		Code:     "TSE",
+		Name:     "Tokyo Stock Exchange",
+		location: loadLocation("Asia/Tokyo"),
+		sessions: []session{{open: hm(9, 0), close: hm(11, 30)}, {open: hm(12, 30), close: hm(15, 30)}},
+		holidays: []holiday{
+			{date: fixed(time.January, 1)},
+			{date: fixed(time.January, 2)},
+			{date: fixed(time.January, 3)},
+			{date: nthWeekday(time.January, time.Monday, 2)}, // Coming of Age Day
+			{date: fixed(time.February, 11), observance: observeSundayToNextWeekday},
+			{date: fixed(time.February, 23), observance: observeSundayToNextWeekday},
+			{date: vernalEquinox, observance: observeSundayToNextWeekday},
+			{date: fixed(time.April, 29), observance: observeSundayToNextWeekday},
+			{date: fixed(time.May, 3), observance: observeSundayToNextWeekday},
+			{date: fixed(time.May, 4), observance: observeSundayToNextWeekday},
+			{date: fixed(time.May, 5), observance: observeSundayToNextWeekday},
+			{date: nthWeekday(time.July, time.Monday, 3)}, // Marine Day
+			{date: fixed(time.August, 11), observance: observeSundayToNextWeekday},
+			{date: nthWeekday(time.September, time.Monday, 3)}, // Respect for the Aged Day
+			{date: autumnalEquinox, observance: observeSundayToNextWeekday},
+			{date: nthWeekday(time.October, time.Monday, 2)}, // Sports Day
+			{date: fixed(time.November, 3), observance: observeSundayToNextWeekday},
+			{date: fixed(time.November, 23), observance: observeSundayToNextWeekday},
+			{date: fixed(time.December, 31)},
+		},
+		isBridged: true,
+	}
+	exchangeASX = &Exchange{
+		Code:     "ASX",
+		Name:     "Australian Securities Exchange",
+		location: loadLocation("Australia/Sydney"),
+		sessions: []session{{open: hm(10, 0), close: hm(16, 0)}},
+		holidays: []holiday{
+			{date: fixed(time.January, 1), observance: observeNextWeekday},
+			{date: fixed(time.January, 26), observance: observeNextWeekday},
+			{date: easter(-2)}, // Good Friday
+			{date: easter(1)},  // Easter Monday
+			{date: fixed(time.April, 25)},
+			{date: nthWeekday(time.June, time.Monday, 2)}, // King's Birthday
+			{date: fixed(time.December, 25), observance: observeNextWeekday},
+			{date: fixed(time.December, 26), observance: observeNextWeekday},
+		},
+		earlyCloses: []earlyClose{
+			{date: fixed(time.December, 24), close: hm(14, 10)},
+			{date: fixed(time.December, 31), close: hm(14, 10)},
+		},
+	}
+
+	// exchangesByName are exchanges keyed by the lowercase exchange names returned by Yahoo Finance
+	exchangesByName = map[string]*Exchange{
+		"nyse":          exchangeNYSE,
+		"nyse american": exchangeNYSE,
+		"nysearca":      exchangeNYSE,
+		"nyse arca":     exchangeNYSE,
+		"nasdaq":        exchangeNASDAQ,
+		"nasdaqgs":      exchangeNASDAQ,
+		"nasdaqgm":      exchangeNASDAQ,
+		"nasdaqcm":      exchangeNASDAQ,
+		"toronto":       exchangeTSX,
+		"lse":           exchangeLSE,
+		"xetra":         exchangeXETRA,
+		"tokyo":         exchangeTSE,
+		"asx":           exchangeASX,
+	}
+	// exchangesBySuffix are exchanges keyed by the suffix of symbols on Yahoo Finance
+	exchangesBySuffix = map[string]*Exchange{
+		".TO": exchangeTSX,
+		".L":  exchangeLSE,
+		".DE": exchangeXETRA,
+		".T":  exchangeTSE,
+		".AX": exchangeASX,
+	}
+)
+
+// loadLocation returns a time zone by name and falls back to UTC since all time zones used are embedded
+func loadLocation(name string) *time.Location {
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+
+	return location
+}
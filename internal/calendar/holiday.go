@@ -0,0 +1,165 @@
+package calendar
+
+import (
+	"time"
+)
+
+// observance moves a holiday which falls on a weekend to the day the exchange is closed in its place
+type observance int
+
+const (
+	// observeNone does not move the holiday so it has no effect when it falls on a weekend
+	observeNone observance = iota
+	// observeNearestWeekday moves a holiday on a Saturday to Friday and on a Sunday to Monday
+	observeNearestWeekday
+	// observeSundayToMonday moves a holiday on a Sunday to Monday and does not move a holiday on a Saturday
+	observeSundayToMonday
+	// observeNextWeekday moves a holiday to the next weekday which is not already a holiday
+	observeNextWeekday
+	// observeSundayToNextWeekday moves a holiday on a Sunday to the next weekday which is not already a holiday and
+	// does not move a holiday on a Saturday
+	observeSundayToNextWeekday
+)
+
+// holiday is a rule for the date a market is closed each year
+type holiday struct {
+	date       func(year int) time.Time
+	observance observance
+}
+
+// earlyClose is a rule for the date a market closes early each year
+type earlyClose struct {
+	date  func(year int) time.Time
+	close clock
+}
+
+// getHolidays returns the dates the market is closed on weekdays in a year
+func getHolidays(rules []holiday, year int, isBridged bool) map[time.Time]bool {
+
+	holidays := make(map[time.Time]bool)
+	dates := make([]time.Time, len(rules))
+
+	// Holidays on weekdays are added first so holidays moved from a weekend are moved past them
+	for i, rule := range rules {
+		dates[i] = rule.date(year)
+
+		if !isWeekend(dates[i]) {
+			holidays[dates[i]] = true
+		}
+	}
+
+	for i, rule := range rules {
+		if !isWeekend(dates[i]) {
+			continue
+		}
+
+		switch rule.observance {
+		case observeNearestWeekday:
+			if dates[i].Weekday() == time.Saturday {
+				holidays[dates[i].AddDate(0, 0, -1)] = true
+			} else {
+				holidays[dates[i].AddDate(0, 0, 1)] = true
+			}
+		case observeSundayToMonday:
+			if dates[i].Weekday() == time.Sunday {
+				holidays[dates[i].AddDate(0, 0, 1)] = true
+			}
+		case observeNextWeekday, observeSundayToNextWeekday:
+			if rule.observance == observeSundayToNextWeekday && dates[i].Weekday() == time.Saturday {
+				continue
+			}
+
+			observed := dates[i]
+			for isWeekend(observed) || holidays[observed] {
+				observed = observed.AddDate(0, 0, 1)
+			}
+			holidays[observed] = true
+		case observeNone:
+		}
+	}
+
+	// A weekday between two holidays is also a holiday on some exchanges
+	if isBridged {
+		for _, d := range dates {
+			bridge := d.AddDate(0, 0, 1)
+			if !isWeekend(bridge) && !holidays[bridge] && holidays[d] && holidays[bridge.AddDate(0, 0, 1)] {
+				holidays[bridge] = true
+			}
+		}
+	}
+
+	return holidays
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func isWeekend(d time.Time) bool {
+	return d.Weekday() == time.Saturday || d.Weekday() == time.Sunday
+}
+
+// fixed returns a rule for a holiday on the same date each year
+func fixed(month time.Month, day int) func(year int) time.Time {
+	return func(year int) time.Time {
+		return date(year, month, day)
+	}
+}
+
+// nthWeekday returns a rule for a holiday on the nth weekday of a month or the last weekday of the month when n is -1
+func nthWeekday(month time.Month, weekday time.Weekday, n int) func(year int) time.Time {
+	return func(year int) time.Time {
+
+		if n < 0 {
+			last := date(year, month+1, 0)
+
+			return last.AddDate(0, 0, -((int(last.Weekday()) - int(weekday) + 7) % 7))
+		}
+
+		first := date(year, month, 1)
+
+		return first.AddDate(0, 0, (int(weekday)-int(first.Weekday())+7)%7+(n-1)*7)
+	}
+}
+
+// weekdayOnOrBefore returns a rule for a holiday on the last weekday on or before a date
+func weekdayOnOrBefore(month time.Month, day int, weekday time.Weekday) func(year int) time.Time {
+	return func(year int) time.Time {
+		d := date(year, month, day)
+
+		return d.AddDate(0, 0, -((int(d.Weekday()) - int(weekday) + 7) % 7))
+	}
+}
+
+// easter returns a rule for a holiday a number of days from Easter Sunday
+func easter(days int) func(year int) time.Time {
+	return func(year int) time.Time {
+		// Anonymous Gregorian algorithm
+		a := year % 19
+		b := year / 100
+		c := year % 100
+		d := b / 4
+		e := b % 4
+		f := (b + 8) / 25
+		g := (b - f + 1) / 3
+		h := (19*a + b - d - g + 15) % 30
+		i := c / 4
+		k := c % 4
+		l := (32 + 2*e + 2*i - h - k) % 7
+		m := (a + 11*h + 22*l) / 451
+		month := (h + l - 7*m + 114) / 31
+		day := (h+l-7*m+114)%31 + 1
+
+		return date(year, time.Month(month), day).AddDate(0, 0, days)
+	}
+}
+
+// vernalEquinox returns the date of the March equinox in Japan which is accurate from 1980 to 2099
+func vernalEquinox(year int) time.Time {
+	return date(year, time.March, int(20.8431+0.242194*float64(year-1980))-(year-1980)/4)
+}
+
+// autumnalEquinox returns the date of the September equinox in Japan which is accurate from 1980 to 2099
+func autumnalEquinox(year int) time.Time {
+	return date(year, time.September, int(23.2488+0.242194*float64(year-1980))-(year-1980)/4)
+}
@@ -4,13 +4,19 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math"
+	"net/url"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/achannarasappa/ticker/v5/internal/alert"
 	"github.com/achannarasappa/ticker/v5/internal/cache"
 	"github.com/achannarasappa/ticker/v5/internal/cli/symbol"
 	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/notifier"
+	"github.com/achannarasappa/ticker/v5/internal/ui/component/watchlist/row"
 	"github.com/achannarasappa/ticker/v5/internal/ui/util"
 
 	"github.com/adrg/xdg"
@@ -31,11 +37,16 @@ type Options struct {
 	ShowSummary           bool
 	ShowHoldings          bool // Deprecated: use ShowPositions instead, kept for backwards compatibility
 	ShowPositions         bool // Preferred field name
+	ShowSparkline         bool
 	Sort                  string
 	NoCache               bool
 	Debug                 bool
 }
 
+// lotQuantityTolerance is how much a sell can exceed the open quantity before it is invalid so that rounding in the sum of
+// fractional quantities is not treated as an oversell
+const lotQuantityTolerance = 1e-9
+
 type symbolSource struct {
 	symbol string
 	source c.QuoteSource
@@ -70,6 +81,191 @@ func validateLot(lot c.Lot, groupName string, lotIndex int) error {
 		return fmt.Errorf("invalid config: lot #%d for symbol '%s' in group '%s' has invalid fixed_cost (must be zero or positive, got %f)", lotIndex+1, lot.Symbol, groupName, lot.FixedCost) //nolint:goerr113
 	}
 
+	if lot.Type != "" && lot.Type != "buy" && lot.Type != "sell" {
+		return fmt.Errorf("invalid config: lot #%d for symbol '%s' in group '%s' has invalid type (must be buy or sell, got '%s')", lotIndex+1, lot.Symbol, groupName, lot.Type) //nolint:goerr113
+	}
+
+	if lot.Type == "sell" && lot.Quantity < 0 {
+		return fmt.Errorf("invalid config: lot #%d for symbol '%s' in group '%s' has invalid quantity for a sell (must be positive, got %f)", lotIndex+1, lot.Symbol, groupName, lot.Quantity) //nolint:goerr113
+	}
+
+	return nil
+}
+
+// validateLots validates each lot in a group, that each sell of a specific lot refers to a buy of the same symbol before it,
+// and that no sell is larger than the quantity left open by the buys of the same symbol before it
+func validateLots(lots []c.Lot, groupName string) error {
+	lotIDs := make(map[string]string)
+	openQuantityBySymbol := make(map[string]float64)
+
+	for i, lot := range lots {
+		if err := validateLot(lot, groupName, i); err != nil {
+			return err
+		}
+
+		symbol := strings.ToUpper(lot.Symbol)
+
+		if lot.Type != "sell" && lot.ID != "" {
+			lotIDs[lot.ID] = symbol
+		}
+
+		if lot.Type == "sell" && lot.LotID != "" && lotIDs[lot.LotID] != symbol {
+			return fmt.Errorf("invalid config: lot #%d for symbol '%s' in group '%s' has invalid lot_id (no earlier buy of '%s' with id '%s')", i+1, lot.Symbol, groupName, lot.Symbol, lot.LotID) //nolint:goerr113
+		}
+
+		if lot.Type != "sell" {
+			openQuantityBySymbol[symbol] += math.Max(lot.Quantity, 0)
+
+			continue
+		}
+
+		if lot.Quantity-openQuantityBySymbol[symbol] > lotQuantityTolerance {
+			return fmt.Errorf("invalid config: lot #%d for symbol '%s' in group '%s' has invalid quantity for a sell (must not exceed the open quantity of earlier buys of '%s' of %f, got %f)", i+1, lot.Symbol, groupName, lot.Symbol, openQuantityBySymbol[symbol], lot.Quantity) //nolint:goerr113
+		}
+
+		openQuantityBySymbol[symbol] -= lot.Quantity
+	}
+
+	return nil
+}
+
+// validateCash validates a single cash balance and returns an error if invalid
+func validateCash(cash c.ConfigCash, groupName string, cashIndex int) error {
+	if len(cash.Currency) != 3 {
+		return fmt.Errorf("invalid config: cash balance #%d in group '%s' has invalid currency (must be an ISO 4217 currency code, got '%s')", cashIndex+1, groupName, cash.Currency) //nolint:goerr113
+	}
+
+	if cash.Amount == 0 {
+		return fmt.Errorf("invalid config: cash balance #%d for currency '%s' in group '%s' has invalid amount (cannot be zero)", cashIndex+1, cash.Currency, groupName) //nolint:goerr113
+	}
+
+	return nil
+}
+
+// validateDividend validates a single dividend and returns an error if invalid
+func validateDividend(dividend c.Dividend, groupName string, dividendIndex int) error {
+	if dividend.Symbol == "" {
+		return fmt.Errorf("invalid config: dividend #%d in group '%s' has empty symbol", dividendIndex+1, groupName) //nolint:goerr113
+	}
+
+	if dividend.Date != "" {
+		if _, err := time.Parse("2006-01-02", dividend.Date); err != nil {
+			return fmt.Errorf("invalid config: dividend #%d for symbol '%s' in group '%s' has invalid date (must be in YYYY-MM-DD format, got '%s')", dividendIndex+1, dividend.Symbol, groupName, dividend.Date) //nolint:goerr113
+		}
+	}
+
+	return nil
+}
+
+// validateUserDefinedPrice validates a single user defined price and returns an error if invalid
+func validateUserDefinedPrice(price c.ConfigUserDefinedPrice, index int) error {
+	if price.Symbol == "" {
+		return fmt.Errorf("invalid config: user defined price #%d has empty symbol", index+1) //nolint:goerr113
+	}
+
+	if price.Price < 0 {
+		return fmt.Errorf("invalid config: user defined price for symbol '%s' has invalid price (must be zero or positive, got %f)", price.Symbol, price.Price) //nolint:goerr113
+	}
+
+	if price.PricePrevClose < 0 {
+		return fmt.Errorf("invalid config: user defined price for symbol '%s' has invalid prev_close (must be zero or positive, got %f)", price.Symbol, price.PricePrevClose) //nolint:goerr113
+	}
+
+	if len(price.Currency) > 0 && len(price.Currency) != 3 {
+		return fmt.Errorf("invalid config: user defined price for symbol '%s' has invalid currency (must be an ISO 4217 currency code, got '%s')", price.Symbol, price.Currency) //nolint:goerr113
+	}
+
+	if price.AsOf != "" {
+		if _, err := time.Parse("2006-01-02", price.AsOf); err != nil {
+			return fmt.Errorf("invalid config: user defined price for symbol '%s' has invalid as_of date (must be in YYYY-MM-DD format, got '%s')", price.Symbol, price.AsOf) //nolint:goerr113
+		}
+	}
+
+	return nil
+}
+
+// validateAlert validates a single alert rule and returns an error if invalid
+func validateAlert(rule c.ConfigAlert, index int, notifiers []c.ConfigNotifier) error {
+	if rule.Symbol == "" {
+		return fmt.Errorf("invalid config: alert #%d has empty symbol", index+1) //nolint:goerr113
+	}
+
+	if !alert.IsValidCondition(rule.Condition) {
+		return fmt.Errorf("invalid config: alert #%d for symbol '%s' has invalid condition (must be one of above, below, change, cost-basis, 52-week-high, or 52-week-low, got '%s')", index+1, rule.Symbol, rule.Condition) //nolint:goerr113
+	}
+
+	if (rule.Condition == alert.ConditionAbove || rule.Condition == alert.ConditionBelow || rule.Condition == alert.ConditionChange) && rule.Value <= 0 {
+		return fmt.Errorf("invalid config: alert #%d for symbol '%s' has invalid value (must be positive for condition %s, got %f)", index+1, rule.Symbol, rule.Condition, rule.Value) //nolint:goerr113
+	}
+
+	if rule.Value < 0 {
+		return fmt.Errorf("invalid config: alert #%d for symbol '%s' has invalid value (must be zero or positive, got %f)", index+1, rule.Symbol, rule.Value) //nolint:goerr113
+	}
+
+	if rule.Cooldown != nil && *rule.Cooldown < 0 {
+		return fmt.Errorf("invalid config: alert #%d for symbol '%s' has invalid cooldown (must be zero or positive, got %d)", index+1, rule.Symbol, *rule.Cooldown) //nolint:goerr113
+	}
+
+	if rule.Hysteresis != nil && *rule.Hysteresis < 0 {
+		return fmt.Errorf("invalid config: alert #%d for symbol '%s' has invalid hysteresis (must be zero or positive, got %f)", index+1, rule.Symbol, *rule.Hysteresis) //nolint:goerr113
+	}
+
+	for _, name := range rule.Notify {
+		if !slices.ContainsFunc(notifiers, func(n c.ConfigNotifier) bool { return n.Name == name }) {
+			return fmt.Errorf("invalid config: alert #%d for symbol '%s' has invalid notify (no notifier named '%s')", index+1, rule.Symbol, name) //nolint:goerr113
+		}
+	}
+
+	return nil
+}
+
+// validateSourceAlternatives validates the alternative sources for a single symbol and returns an error if invalid
+func validateSourceAlternatives(sourceAlternatives c.ConfigSourceAlternatives, index int) error {
+	if sourceAlternatives.Symbol == "" {
+		return fmt.Errorf("invalid config: source alternatives #%d has empty symbol", index+1) //nolint:goerr113
+	}
+
+	if len(sourceAlternatives.Alternatives) == 0 {
+		return fmt.Errorf("invalid config: source alternatives for symbol '%s' has no alternatives", sourceAlternatives.Symbol) //nolint:goerr113
+	}
+
+	for _, alternative := range sourceAlternatives.Alternatives {
+		if alternative == "" {
+			return fmt.Errorf("invalid config: source alternatives for symbol '%s' has empty alternative", sourceAlternatives.Symbol) //nolint:goerr113
+		}
+	}
+
+	return nil
+}
+
+// validateNotifier validates a single notifier and returns an error if invalid
+func validateNotifier(n c.ConfigNotifier, index int, names map[string]bool) error {
+	if n.Name == "" {
+		return fmt.Errorf("invalid config: notifier #%d has empty name", index+1) //nolint:goerr113
+	}
+
+	if names[n.Name] {
+		return fmt.Errorf("invalid config: notifier #%d has duplicate name '%s'", index+1, n.Name) //nolint:goerr113
+	}
+
+	if !notifier.IsValidType(n.Type) {
+		return fmt.Errorf("invalid config: notifier '%s' has invalid type (must be one of desktop, command, or webhook, got '%s')", n.Name, n.Type) //nolint:goerr113
+	}
+
+	if n.Type == notifier.TypeCommand && len(n.Command) == 0 {
+		return fmt.Errorf("invalid config: notifier '%s' has empty command", n.Name) //nolint:goerr113
+	}
+
+	if n.Type == notifier.TypeWebhook {
+		if u, err := url.Parse(n.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid config: notifier '%s' has invalid url (must be an http or https URL, got '%s')", n.Name, n.URL) //nolint:goerr113
+		}
+	}
+
+	if n.Retries != nil && *n.Retries < 0 {
+		return fmt.Errorf("invalid config: notifier '%s' has invalid retries (must be zero or positive, got %d)", n.Name, *n.Retries) //nolint:goerr113
+	}
+
 	return nil
 }
 
@@ -81,7 +277,7 @@ func Validate(config *c.Config, options *Options, prevErr *error) func(*cobra.Co
 			return *prevErr
 		}
 
-		if len(config.Watchlist) == 0 && len(options.Watchlist) == 0 && len(config.Lots) == 0 && len(config.AssetGroup) == 0 {
+		if len(config.Watchlist) == 0 && len(options.Watchlist) == 0 && len(config.Lots) == 0 && len(config.Cash) == 0 && len(config.AssetGroup) == 0 {
 			return errors.New("invalid config: No watchlist provided") //nolint:goerr113
 		}
 
@@ -89,9 +285,57 @@ func Validate(config *c.Config, options *Options, prevErr *error) func(*cobra.Co
 			return errors.New("invalid config: Display currency may only be an ISO 4217 major currency or blank (eg GBP not GBp; default: USD)") //nolint:goerr113
 		}
 
+		for i, userDefinedPrice := range config.UserDefinedPrices {
+			if err := validateUserDefinedPrice(userDefinedPrice, i); err != nil {
+				return err
+			}
+		}
+
+		notifierNames := make(map[string]bool)
+		for i, n := range config.Notifiers {
+			if err := validateNotifier(n, i, notifierNames); err != nil {
+				return err
+			}
+			notifierNames[n.Name] = true
+		}
+
+		for i, rule := range config.Alerts {
+			if err := validateAlert(rule, i, config.Notifiers); err != nil {
+				return err
+			}
+		}
+
+		for i, sourceAlternatives := range config.SourceAlternatives {
+			if err := validateSourceAlternatives(sourceAlternatives, i); err != nil {
+				return err
+			}
+		}
+
+		for _, column := range config.Columns {
+			if !row.IsValidColumn(column) {
+				return fmt.Errorf("invalid config: columns must only include %s (got '%s')", strings.Join(row.GetColumns(), ", "), column) //nolint:goerr113
+			}
+		}
+
+		switch config.LotMatching {
+		case "", "fifo", "lifo", "specific", "average":
+		default:
+			return fmt.Errorf("invalid config: lot-matching must be one of fifo, lifo, specific, or average (got '%s')", config.LotMatching) //nolint:goerr113
+		}
+
 		// Validate lots in config.Lots (default group)
-		for i, lot := range config.Lots {
-			if err := validateLot(lot, "default", i); err != nil {
+		if err := validateLots(config.Lots, "default"); err != nil {
+			return err
+		}
+
+		for i, cash := range config.Cash {
+			if err := validateCash(cash, "default", i); err != nil {
+				return err
+			}
+		}
+
+		for i, dividend := range config.Dividends {
+			if err := validateDividend(dividend, "default", i); err != nil {
 				return err
 			}
 		}
@@ -107,8 +351,16 @@ func Validate(config *c.Config, options *Options, prevErr *error) func(*cobra.Co
 			if len(lots) == 0 {
 				lots = assetGroup.Holdings
 			}
-			for i, lot := range lots {
-				if err := validateLot(lot, groupName, i); err != nil {
+			if err := validateLots(lots, groupName); err != nil {
+				return err
+			}
+			for i, cash := range assetGroup.Cash {
+				if err := validateCash(cash, groupName, i); err != nil {
+					return err
+				}
+			}
+			for i, dividend := range assetGroup.Dividends {
+				if err := validateDividend(dividend, groupName, i); err != nil {
 					return err
 				}
 			}
@@ -127,8 +379,12 @@ func GetDependencies() c.Dependencies {
 		MonitorYahooSessionRootURL:       "https://finance.yahoo.com",
 		MonitorYahooSessionCrumbURL:      "https://query2.finance.yahoo.com",
 		MonitorYahooSessionConsentURL:    "https://consent.yahoo.com",
+		MonitorYahooStreamingURL:         "wss://streamer.finance.yahoo.com/?version=2",
 		MonitorPriceCoinbaseBaseURL:      "https://api.coinbase.com",
 		MonitorPriceCoinbaseStreamingURL: "wss://ws-feed.exchange.coinbase.com",
+		MonitorPriceCoingeckoBaseURL:     "https://api.coingecko.com",
+		MonitorPriceCoinCapBaseURL:       "https://rest.coincap.io",
+		MonitorPriceCoinCapStreamingURL:  "wss://wss.coincap.io/prices",
 	}
 }
 
@@ -177,7 +433,7 @@ func GetContext(d c.Dependencies, config c.Config) (c.Context, error) {
 
 func readConfig(fs afero.Fs, configPathOption string) (c.Config, error) {
 	var config c.Config
-	configPath, err := getConfigPath(fs, configPathOption)
+	configPath, err := GetConfigPath(fs, configPathOption)
 
 	if err != nil {
 		return config, nil //nolint:nilerr
@@ -237,6 +493,7 @@ func GetConfig(dep c.Dependencies, configPath string, options Options) (c.Config
 		// Otherwise, fall back to Holdings
 		config.ShowPositions = showHoldingsFromCLI || showHoldingsFromConfig
 	}
+	config.ShowSparkline = getBoolOption(options.ShowSparkline, config.ShowSparkline)
 	config.Sort = getStringOption(options.Sort, config.Sort)
 	config.Cache = getCacheOption(options.NoCache, config.Cache)
 	config.Debug = getBoolOption(options.Debug, config.Debug)
@@ -267,7 +524,8 @@ func getCacheOption(noCacheFlag bool, configValue *bool) *bool {
 	return &enabled
 }
 
-func getConfigPath(fs afero.Fs, configPathOption string) (string, error) {
+// GetConfigPath returns the config path option when set or otherwise the path of the first config file found in the default locations
+func GetConfigPath(fs afero.Fs, configPathOption string) (string, error) {
 	var err error
 	if configPathOption != "" {
 		return configPathOption, nil
@@ -342,27 +600,50 @@ func getGroups(config c.Config, d c.Dependencies, cache c.Cache) ([]c.AssetGroup
 		return []c.AssetGroup{}, err
 	}
 
-	if len(config.Watchlist) > 0 || len(config.Lots) > 0 {
+	if tickerSymbolToSourceSymbol == nil {
+		tickerSymbolToSourceSymbol = make(symbol.TickerSymbolToSourceSymbol)
+	}
+
+	// Symbols with a user defined price take precedence over any market data source
+	for _, userDefinedPrice := range config.UserDefinedPrices {
+		symbolUppercase := strings.ToUpper(userDefinedPrice.Symbol)
+		tickerSymbolToSourceSymbol[symbolUppercase] = symbol.SymbolSourceMap{
+			TickerSymbol: symbolUppercase,
+			SourceSymbol: symbolUppercase,
+			Source:       c.QuoteSourceUserDefined,
+		}
+	}
+
+	if len(config.Watchlist) > 0 || len(config.Lots) > 0 || len(config.Cash) > 0 {
 		configAssetGroups = append(configAssetGroups, c.ConfigAssetGroup{
 			Name:      "default",
 			Watchlist: config.Watchlist,
 			Lots:      config.Lots,
+			Cash:      config.Cash,
+			Dividends: config.Dividends,
 		})
 	}
 
 	configAssetGroups = append(configAssetGroups, config.AssetGroup...)
 
+	alternativesBySymbol := make(map[string][]string)
+	for _, sourceAlternatives := range config.SourceAlternatives {
+		alternativesBySymbol[strings.ToUpper(sourceAlternatives.Symbol)] = sourceAlternatives.Alternatives
+	}
+
 	for _, configAssetGroup := range configAssetGroups {
 
 		symbols := make(map[string]bool)
 		symbolsUnique := make(map[c.QuoteSource]c.AssetGroupSymbolsBySource)
 		var assetGroupSymbolsBySource []c.AssetGroupSymbolsBySource
+		var assetGroupSymbolAlternatives []c.AssetGroupSymbolAlternatives
 
 		for _, symbol := range configAssetGroup.Watchlist {
 			if !symbols[symbol] {
 				symbols[symbol] = true
 				symbolAndSource := getSymbolAndSource(symbol, tickerSymbolToSourceSymbol)
 				symbolsUnique = appendSymbol(symbolsUnique, symbolAndSource)
+				assetGroupSymbolAlternatives = appendSymbolAlternatives(assetGroupSymbolAlternatives, symbol, symbolAndSource, alternativesBySymbol, tickerSymbolToSourceSymbol)
 			}
 		}
 
@@ -378,6 +659,17 @@ func getGroups(config c.Config, d c.Dependencies, cache c.Cache) ([]c.AssetGroup
 				symbols[lot.Symbol] = true
 				symbolAndSource := getSymbolAndSource(lot.Symbol, tickerSymbolToSourceSymbol)
 				symbolsUnique = appendSymbol(symbolsUnique, symbolAndSource)
+				assetGroupSymbolAlternatives = appendSymbolAlternatives(assetGroupSymbolAlternatives, lot.Symbol, symbolAndSource, alternativesBySymbol, tickerSymbolToSourceSymbol)
+			}
+		}
+
+		// Cash balances are quoted as a symbol per currency (e.g. EUR.CASH) so that they are converted like any other asset
+		for _, cash := range configAssetGroup.Cash {
+			cashSymbol := strings.ToUpper(cash.Currency) + ".CASH"
+			if !symbols[cashSymbol] {
+				symbols[cashSymbol] = true
+				symbolAndSource := getSymbolAndSource(cashSymbol, tickerSymbolToSourceSymbol)
+				symbolsUnique = appendSymbol(symbolsUnique, symbolAndSource)
 			}
 		}
 
@@ -386,8 +678,9 @@ func getGroups(config c.Config, d c.Dependencies, cache c.Cache) ([]c.AssetGroup
 		}
 
 		groups = append(groups, c.AssetGroup{
-			ConfigAssetGroup: mergedConfigAssetGroup,
-			SymbolsBySource:  assetGroupSymbolsBySource,
+			ConfigAssetGroup:   mergedConfigAssetGroup,
+			SymbolsBySource:    assetGroupSymbolsBySource,
+			SymbolAlternatives: assetGroupSymbolAlternatives,
 		})
 
 	}
@@ -412,6 +705,22 @@ func getSymbolAndSource(symbol string, tickerSymbolToSourceSymbol symbol.TickerS
 
 	symbolUppercase := strings.ToUpper(symbol)
 
+	if tickerSymbolToSource, exists := tickerSymbolToSourceSymbol[symbolUppercase]; exists && tickerSymbolToSource.Source == c.QuoteSourceUserDefined {
+
+		return symbolSource{
+			source: tickerSymbolToSource.Source,
+			symbol: tickerSymbolToSource.SourceSymbol,
+		}
+	}
+
+	if strings.HasSuffix(symbolUppercase, ".CASH") {
+
+		return symbolSource{
+			source: c.QuoteSourceUserDefined,
+			symbol: symbolUppercase,
+		}
+	}
+
 	if strings.HasSuffix(symbolUppercase, ".CB") {
 
 		symbol = strings.ToUpper(symbol)[:len(symbol)-3]
@@ -430,6 +739,24 @@ func getSymbolAndSource(symbol string, tickerSymbolToSourceSymbol symbol.TickerS
 		}
 	}
 
+	if strings.HasSuffix(symbolUppercase, ".CG") {
+
+		// CoinGecko refers to coins by a lowercase id (e.g. bitcoin) rather than a trading symbol
+		return symbolSource{
+			source: c.QuoteSourceCoingecko,
+			symbol: strings.ToLower(symbol[:len(symbol)-3]),
+		}
+	}
+
+	if strings.HasSuffix(symbolUppercase, ".CC") {
+
+		// CoinCap refers to assets by a lowercase id (e.g. bitcoin) rather than a trading symbol
+		return symbolSource{
+			source: c.QuoteSourceCoinCap,
+			symbol: strings.ToLower(symbol[:len(symbol)-3]),
+		}
+	}
+
 	if strings.HasSuffix(symbolUppercase, ".X") {
 
 		if tickerSymbolToSource, exists := tickerSymbolToSourceSymbol[symbolUppercase]; exists {
@@ -469,3 +796,39 @@ func appendSymbol(symbolsUnique map[c.QuoteSource]c.AssetGroupSymbolsBySource, s
 	return symbolsUnique
 
 }
+
+// appendSymbolAlternatives adds the alternative sources set in the config for a symbol if there are any. Alternatives
+// from the same source as the symbol are skipped since they would fail along with it.
+func appendSymbolAlternatives(symbolAlternatives []c.AssetGroupSymbolAlternatives, symbol string, symbolAndSource symbolSource, alternativesBySymbol map[string][]string, tickerSymbolToSourceSymbol symbol.TickerSymbolToSourceSymbol) []c.AssetGroupSymbolAlternatives {
+
+	symbolUppercase := strings.ToUpper(symbol)
+	alternatives := make([]c.AssetGroupSymbolSource, 0)
+
+	if configAlternatives, exists := alternativesBySymbol[symbolUppercase]; exists {
+		for _, configAlternative := range configAlternatives {
+			alternativeSymbolAndSource := getSymbolAndSource(configAlternative, tickerSymbolToSourceSymbol)
+			alternatives = append(alternatives, c.AssetGroupSymbolSource{
+				Symbol: alternativeSymbolAndSource.symbol,
+				Source: alternativeSymbolAndSource.source,
+			})
+		}
+	}
+
+	alternatives = slices.DeleteFunc(alternatives, func(alternative c.AssetGroupSymbolSource) bool {
+		return alternative.Source == symbolAndSource.source || alternative.Source == c.QuoteSourceUnknown
+	})
+
+	if len(alternatives) == 0 {
+		return symbolAlternatives
+	}
+
+	return append(symbolAlternatives, c.AssetGroupSymbolAlternatives{
+		Symbol: symbolUppercase,
+		Source: c.AssetGroupSymbolSource{
+			Symbol: symbolAndSource.symbol,
+			Source: symbolAndSource.source,
+		},
+		Alternatives: alternatives,
+	})
+
+}
@@ -84,6 +84,7 @@ var _ = Describe("Cli", func() {
 		responseFixture := `"ADA.X","ADA-USD","cb"
 "ALGO.X","ALGO-USD","cb"
 "BTC.X","BTC-USD","cb"
+"BTC.X","bitcoin","cg"
 "ETH.X","ETH-USD","cb"
 "SOL.X","SOL-USD","cb"
 "XRP.X","XRP-USD","cb"
@@ -302,6 +303,15 @@ var _ = Describe("Cli", func() {
 						"  - ADA.CB",             // coinbase
 						"  - BIT-31JAN25-CDE.CB", // coinbase futures
 						"  - SOL.X",              // ticker
+						"  - Bitcoin.CG",         // coingecko
+						"  - ethereum.cc",        // coincap
+						"  - acme",               // user defined
+						"user-defined-prices:",
+						"  - symbol: ACME",
+						"    price: 12.5",
+						"cash:",
+						"  - currency: eur", // cash
+						"    amount: 1000",
 					}, "\n"),
 					AssertionErr: BeNil(),
 					AssertionCtx: g.MatchFields(g.IgnoreExtras, g.Fields{
@@ -316,6 +326,25 @@ var _ = Describe("Cli", func() {
 										}),
 										"Source": Equal(c.QuoteSourceYahoo),
 									}),
+									"2": g.MatchFields(g.IgnoreExtras, g.Fields{
+										"Symbols": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+											"0": Equal("bitcoin"),
+										}),
+										"Source": Equal(c.QuoteSourceCoingecko),
+									}),
+									"4": g.MatchFields(g.IgnoreExtras, g.Fields{
+										"Symbols": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+											"0": Equal("ethereum"),
+										}),
+										"Source": Equal(c.QuoteSourceCoinCap),
+									}),
+									"1": g.MatchFields(g.IgnoreExtras, g.Fields{
+										"Symbols": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+											"0": Equal("ACME"),
+											"1": Equal("EUR.CASH"),
+										}),
+										"Source": Equal(c.QuoteSourceUserDefined),
+									}),
 									"5": g.MatchFields(g.IgnoreExtras, g.Fields{
 										"Symbols": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
 											"0": Equal("ADA-USD"),
@@ -329,6 +358,40 @@ var _ = Describe("Cli", func() {
 						}),
 					}),
 				}),
+
+				// alternative sources
+				Entry("when symbols have alternative sources", Case{
+					InputOptions: cli.Options{},
+					InputConfigFileContents: strings.Join([]string{
+						"watchlist:",
+						"  - TSLA",   // no alternatives
+						"  - BTC.X",  // later rows in the symbol map are not alternatives
+						"  - eth.x",  // alternatives from the config
+						"source-alternatives:",
+						"  - symbol: ETH.X",
+						"    alternatives:",
+						"      - ethereum.cg",
+						"      - ETH.CB", // same source as the symbol
+						"      - ETH-USD",
+					}, "\n"),
+					AssertionErr: BeNil(),
+					AssertionCtx: g.MatchFields(g.IgnoreExtras, g.Fields{
+						"Groups": g.MatchAllElementsWithIndex(g.IndexIdentity, g.Elements{
+							"0": g.MatchFields(g.IgnoreExtras, g.Fields{
+								"SymbolAlternatives": Equal([]c.AssetGroupSymbolAlternatives{
+									{
+										Symbol: "ETH.X",
+										Source: c.AssetGroupSymbolSource{Symbol: "ETH-USD", Source: c.QuoteSourceCoinbase},
+										Alternatives: []c.AssetGroupSymbolSource{
+											{Symbol: "ethereum", Source: c.QuoteSourceCoingecko},
+											{Symbol: "ETH-USD", Source: c.QuoteSourceYahoo},
+										},
+									},
+								}),
+							}),
+						}),
+					}),
+				}),
 			)
 
 		})
@@ -823,6 +886,465 @@ var _ = Describe("Cli", func() {
 					Expect(outputErr).To(MatchError(ContainSubstring("lot #1 for symbol 'SYM1' in group 'default' has invalid quantity")))
 				})
 			})
+
+			When("lot has an invalid type", func() {
+				It("should return an error", func() {
+					config = c.Config{
+						Lots: []c.Lot{
+							{Symbol: "SYM", UnitCost: 1.0, Quantity: 1.0, Type: "short"},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError("invalid config: lot #1 for symbol 'SYM' in group 'default' has invalid type (must be buy or sell, got 'short')"))
+				})
+			})
+
+			When("a sell has a negative quantity", func() {
+				It("should return an error", func() {
+					config = c.Config{
+						Lots: []c.Lot{
+							{Symbol: "SYM", UnitCost: 1.0, Quantity: 2.0},
+							{Symbol: "SYM", UnitCost: 1.0, Quantity: -1.0, Type: "sell"},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError(ContainSubstring("lot #2 for symbol 'SYM' in group 'default' has invalid quantity for a sell")))
+				})
+			})
+
+			When("a sell is larger than the open quantity of earlier buys of the same symbol", func() {
+				It("should return an error", func() {
+					config = c.Config{
+						Lots: []c.Lot{
+							{Symbol: "SYM", UnitCost: 1.0, Quantity: 2.0},
+							{Symbol: "OTHER", UnitCost: 1.0, Quantity: 5.0},
+							{Symbol: "SYM", UnitCost: 1.0, Quantity: 1.5, Type: "sell"},
+							{Symbol: "SYM", UnitCost: 1.0, Quantity: 1.0, Type: "sell"},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError("invalid config: lot #4 for symbol 'SYM' in group 'default' has invalid quantity for a sell (must not exceed the open quantity of earlier buys of 'SYM' of 0.500000, got 1.000000)"))
+				})
+			})
+
+			When("a sell refers to a specific lot", func() {
+				It("should not return an error when the lot is an earlier buy of the same symbol", func() {
+					config = c.Config{
+						LotMatching: "specific",
+						Lots: []c.Lot{
+							{Symbol: "SYM", UnitCost: 1.0, Quantity: 2.0, ID: "first"},
+							{Symbol: "SYM", UnitCost: 2.0, Quantity: 1.0, Type: "sell", LotID: "first"},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).NotTo(HaveOccurred())
+				})
+
+				It("should not return an error when the lot is an earlier buy of the same symbol in a different case", func() {
+					config = c.Config{
+						LotMatching: "specific",
+						Lots: []c.Lot{
+							{Symbol: "acme.x", UnitCost: 1.0, Quantity: 2.0, ID: "first"},
+							{Symbol: "ACME.X", UnitCost: 2.0, Quantity: 2.0, Type: "sell", LotID: "first"},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).NotTo(HaveOccurred())
+				})
+
+				It("should return an error when there is no earlier buy of the same symbol with the id", func() {
+					config = c.Config{
+						AssetGroup: []c.ConfigAssetGroup{
+							{
+								Name: "brokerage",
+								Lots: []c.Lot{
+									{Symbol: "OTHER", UnitCost: 1.0, Quantity: 2.0, ID: "first"},
+									{Symbol: "SYM", UnitCost: 2.0, Quantity: 1.0, Type: "sell", LotID: "first"},
+								},
+							},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError("invalid config: lot #2 for symbol 'SYM' in group 'brokerage' has invalid lot_id (no earlier buy of 'SYM' with id 'first')"))
+				})
+			})
+
+			When("the lot matching method is not valid", func() {
+				It("should return an error", func() {
+					config = c.Config{
+						LotMatching: "hifo",
+						Lots: []c.Lot{
+							{Symbol: "SYM", UnitCost: 1.0, Quantity: 1.0},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError("invalid config: lot-matching must be one of fifo, lifo, specific, or average (got 'hifo')"))
+				})
+			})
+
+			When("a column is not valid", func() {
+				It("should return an error", func() {
+					config = c.Config{
+						Columns: []string{"price", "dividend-yield"},
+						Lots: []c.Lot{
+							{Symbol: "SYM", UnitCost: 1.0, Quantity: 1.0},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError(HavePrefix("invalid config: columns must only include price, change, change-percent")))
+					Expect(outputErr).To(MatchError(HaveSuffix("(got 'dividend-yield')")))
+				})
+			})
+		})
+
+		Describe("user defined price validation", func() {
+			BeforeEach(func() {
+				options.Watchlist = "PRIV"
+			})
+
+			When("a user defined price is valid", func() {
+				It("should not return an error", func() {
+					config = c.Config{
+						UserDefinedPrices: []c.ConfigUserDefinedPrice{
+							{Symbol: "PRIV", Price: 10.0, Currency: "EUR", AsOf: "2024-06-30"},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).NotTo(HaveOccurred())
+				})
+			})
+
+			When("a user defined price has an empty symbol", func() {
+				It("should return an error", func() {
+					config = c.Config{
+						UserDefinedPrices: []c.ConfigUserDefinedPrice{
+							{Price: 10.0},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError("invalid config: user defined price #1 has empty symbol"))
+				})
+			})
+
+			When("a user defined price has a negative price", func() {
+				It("should return an error", func() {
+					config = c.Config{
+						UserDefinedPrices: []c.ConfigUserDefinedPrice{
+							{Symbol: "PRIV", Price: -1.0},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError(ContainSubstring("user defined price for symbol 'PRIV' has invalid price")))
+				})
+			})
+
+			When("a user defined price has a negative previous close", func() {
+				It("should return an error", func() {
+					config = c.Config{
+						UserDefinedPrices: []c.ConfigUserDefinedPrice{
+							{Symbol: "PRIV", Price: 1.0, PricePrevClose: -1.0},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError(ContainSubstring("user defined price for symbol 'PRIV' has invalid prev_close")))
+				})
+			})
+
+			When("a user defined price has an invalid currency", func() {
+				It("should return an error", func() {
+					config = c.Config{
+						UserDefinedPrices: []c.ConfigUserDefinedPrice{
+							{Symbol: "PRIV", Price: 1.0, Currency: "EURO"},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError(ContainSubstring("user defined price for symbol 'PRIV' has invalid currency")))
+				})
+			})
+
+			When("a user defined price has an invalid as of date", func() {
+				It("should return an error", func() {
+					config = c.Config{
+						UserDefinedPrices: []c.ConfigUserDefinedPrice{
+							{Symbol: "PRIV", Price: 1.0, AsOf: "30/06/2024"},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError(ContainSubstring("user defined price for symbol 'PRIV' has invalid as_of date")))
+				})
+			})
+		})
+
+		Describe("alert validation", func() {
+			BeforeEach(func() {
+				options.Watchlist = "AAPL"
+			})
+
+			When("an alert is valid", func() {
+				It("should not return an error", func() {
+					cooldown := 60
+					config = c.Config{
+						Alerts: []c.ConfigAlert{
+							{Symbol: "AAPL", Condition: "above", Value: 200, Cooldown: &cooldown},
+							{Symbol: "AAPL", Condition: "cost-basis"},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).NotTo(HaveOccurred())
+				})
+			})
+
+			When("an alert has an empty symbol", func() {
+				It("should return an error", func() {
+					config = c.Config{
+						Alerts: []c.ConfigAlert{
+							{Condition: "above", Value: 200},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError("invalid config: alert #1 has empty symbol"))
+				})
+			})
+
+			When("an alert has an unknown condition", func() {
+				It("should return an error", func() {
+					config = c.Config{
+						Alerts: []c.ConfigAlert{
+							{Symbol: "AAPL", Condition: "crosses", Value: 200},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError(ContainSubstring("alert #1 for symbol 'AAPL' has invalid condition")))
+				})
+			})
+
+			When("an alert for a price threshold has no value", func() {
+				It("should return an error", func() {
+					config = c.Config{
+						Alerts: []c.ConfigAlert{
+							{Symbol: "AAPL", Condition: "below"},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError(ContainSubstring("alert #1 for symbol 'AAPL' has invalid value")))
+				})
+			})
+
+			When("an alert has a negative hysteresis", func() {
+				It("should return an error", func() {
+					hysteresis := -1.0
+					config = c.Config{
+						Alerts: []c.ConfigAlert{
+							{Symbol: "AAPL", Condition: "change", Value: 5, Hysteresis: &hysteresis},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError(ContainSubstring("alert #1 for symbol 'AAPL' has invalid hysteresis")))
+				})
+			})
+		})
+
+		Describe("source alternatives validation", func() {
+			BeforeEach(func() {
+				options.Watchlist = "BTC.X"
+			})
+
+			When("source alternatives are valid", func() {
+				It("should not return an error", func() {
+					config = c.Config{
+						SourceAlternatives: []c.ConfigSourceAlternatives{
+							{Symbol: "BTC.X", Alternatives: []string{"BITCOIN.CG", "BTC-USD"}},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).NotTo(HaveOccurred())
+				})
+			})
+
+			When("source alternatives have an empty symbol", func() {
+				It("should return an error", func() {
+					config = c.Config{
+						SourceAlternatives: []c.ConfigSourceAlternatives{
+							{Alternatives: []string{"BTC-USD"}},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError("invalid config: source alternatives #1 has empty symbol"))
+				})
+			})
+
+			When("source alternatives have no alternatives", func() {
+				It("should return an error", func() {
+					config = c.Config{
+						SourceAlternatives: []c.ConfigSourceAlternatives{
+							{Symbol: "BTC.X"},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError("invalid config: source alternatives for symbol 'BTC.X' has no alternatives"))
+				})
+			})
+		})
+
+		Describe("notifier validation", func() {
+			BeforeEach(func() {
+				options.Watchlist = "AAPL"
+			})
+
+			When("notifiers are valid and referenced by an alert", func() {
+				It("should not return an error", func() {
+					config = c.Config{
+						Notifiers: []c.ConfigNotifier{
+							{Name: "desktop", Type: "desktop"},
+							{Name: "script", Type: "command", Command: []string{"/usr/local/bin/on-alert"}},
+							{Name: "chat", Type: "webhook", URL: "https://example.com/hooks/ticker"},
+						},
+						Alerts: []c.ConfigAlert{
+							{Symbol: "AAPL", Condition: "above", Value: 200, Notify: []string{"desktop", "chat"}},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).NotTo(HaveOccurred())
+				})
+			})
+
+			When("two notifiers have the same name", func() {
+				It("should return an error", func() {
+					config = c.Config{
+						Notifiers: []c.ConfigNotifier{
+							{Name: "desktop", Type: "desktop"},
+							{Name: "desktop", Type: "desktop"},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError("invalid config: notifier #2 has duplicate name 'desktop'"))
+				})
+			})
+
+			When("a notifier has an unknown type", func() {
+				It("should return an error", func() {
+					config = c.Config{
+						Notifiers: []c.ConfigNotifier{
+							{Name: "pager", Type: "sms"},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError(ContainSubstring("notifier 'pager' has invalid type")))
+				})
+			})
+
+			When("a webhook notifier has an invalid url", func() {
+				It("should return an error", func() {
+					config = c.Config{
+						Notifiers: []c.ConfigNotifier{
+							{Name: "chat", Type: "webhook", URL: "example.com/hooks/ticker"},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError(ContainSubstring("notifier 'chat' has invalid url")))
+				})
+			})
+
+			When("an alert refers to a notifier which is not set", func() {
+				It("should return an error", func() {
+					config = c.Config{
+						Alerts: []c.ConfigAlert{
+							{Symbol: "AAPL", Condition: "above", Value: 200, Notify: []string{"desktop"}},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError(ContainSubstring("alert #1 for symbol 'AAPL' has invalid notify (no notifier named 'desktop')")))
+				})
+			})
+		})
+
+		Describe("cash validation", func() {
+			When("a cash balance is valid", func() {
+				It("should not return an error", func() {
+					config = c.Config{
+						Cash: []c.ConfigCash{
+							{Currency: "EUR", Amount: 1000.0},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).NotTo(HaveOccurred())
+				})
+			})
+
+			When("a cash balance has an invalid currency", func() {
+				It("should return an error", func() {
+					config = c.Config{
+						Cash: []c.ConfigCash{
+							{Currency: "EURO", Amount: 1000.0},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError("invalid config: cash balance #1 in group 'default' has invalid currency (must be an ISO 4217 currency code, got 'EURO')"))
+				})
+			})
+
+			When("a cash balance in a group has a zero amount", func() {
+				It("should return an error", func() {
+					config = c.Config{
+						AssetGroup: []c.ConfigAssetGroup{
+							{
+								Name: "savings",
+								Cash: []c.ConfigCash{
+									{Currency: "USD", Amount: 0},
+								},
+							},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError(ContainSubstring("cash balance #1 for currency 'USD' in group 'savings' has invalid amount")))
+				})
+			})
+		})
+
+		Describe("dividend validation", func() {
+			When("a dividend is valid", func() {
+				It("should not return an error", func() {
+					config = c.Config{
+						Lots: []c.Lot{
+							{Symbol: "SYM", UnitCost: 1.0, Quantity: 1.0},
+						},
+						Dividends: []c.Dividend{
+							{Symbol: "SYM", Amount: 1.5, Date: "2024-03-15"},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).NotTo(HaveOccurred())
+				})
+			})
+
+			When("a dividend has an empty symbol", func() {
+				It("should return an error", func() {
+					config = c.Config{
+						Dividends: []c.Dividend{
+							{Amount: 1.5},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError("invalid config: dividend #1 in group 'default' has empty symbol"))
+				})
+			})
+
+			When("a dividend in a group has an invalid date", func() {
+				It("should return an error", func() {
+					config = c.Config{
+						AssetGroup: []c.ConfigAssetGroup{
+							{
+								Name: "income",
+								Dividends: []c.Dividend{
+									{Symbol: "SYM", Amount: 1.5, Date: "15/03/2024"},
+								},
+							},
+						},
+					}
+					outputErr := Validate(&config, &options, nil)(&cobra.Command{}, []string{})
+					Expect(outputErr).To(MatchError(ContainSubstring("dividend #1 for symbol 'SYM' in group 'income' has invalid date")))
+				})
+			})
 		})
 
 		Describe("currency", func() {
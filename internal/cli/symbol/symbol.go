@@ -29,6 +29,14 @@ func parseQuoteSource(id string) c.QuoteSource {
 		return c.QuoteSourceCoinbase
 	}
 
+	if id == "cg" {
+		return c.QuoteSourceCoingecko
+	}
+
+	if id == "cc" {
+		return c.QuoteSourceCoinCap
+	}
+
 	return c.QuoteSourceUnknown
 }
 
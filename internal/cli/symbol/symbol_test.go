@@ -35,6 +35,8 @@ var _ = Describe("Symbol", func() {
 "ETH.X","ETH-USD","cb"
 "SOL.X","SOL-USD","cb"
 "SUI.X","SUI-USD","cb"
+"PEPE.X","pepe","cg"
+"DOGE.X","dogecoin","cc"
 `
 			server.RouteToHandler("GET", "/symbols.csv",
 				ghttp.CombineHandlers(
@@ -69,6 +71,16 @@ var _ = Describe("Symbol", func() {
 					SourceSymbol: "SUI-USD",
 					Source:       c.QuoteSourceCoinbase,
 				},
+				"PEPE.X": symbol.SymbolSourceMap{
+					TickerSymbol: "PEPE.X",
+					SourceSymbol: "pepe",
+					Source:       c.QuoteSourceCoingecko,
+				},
+				"DOGE.X": symbol.SymbolSourceMap{
+					TickerSymbol: "DOGE.X",
+					SourceSymbol: "dogecoin",
+					Source:       c.QuoteSourceCoinCap,
+				},
 			}
 
 			outputSymbols, outputErr := symbol.GetTickerSymbols(server.URL()+"/symbols.csv", nil)
@@ -105,6 +117,36 @@ var _ = Describe("Symbol", func() {
 
 		})
 
+		When("a ticker symbol has multiple rows", func() {
+
+			It("should use the first row", func() {
+				// Set up mock response
+				responseFixture := `"BTC.X","BTC-USDC","cb"
+"BTC.X","bitcoin","cg"
+"BTC.X","bitcoin","cc"
+`
+				server.RouteToHandler("GET", "/symbols.csv",
+					ghttp.CombineHandlers(
+						ghttp.RespondWith(http.StatusOK, responseFixture, http.Header{"Content-Type": []string{"text/plain; charset=utf-8"}}),
+					),
+				)
+
+				expectedSymbols := symbol.TickerSymbolToSourceSymbol{
+					"BTC.X": symbol.SymbolSourceMap{
+						TickerSymbol: "BTC.X",
+						SourceSymbol: "BTC-USDC",
+						Source:       c.QuoteSourceCoinbase,
+					},
+				}
+
+				outputSymbols, outputErr := symbol.GetTickerSymbols(server.URL()+"/symbols.csv", nil)
+
+				Expect(outputSymbols).To(Equal(expectedSymbols))
+				Expect(outputErr).NotTo(HaveOccurred())
+			})
+
+		})
+
 		When("a malformed CSV is returned", func() {
 
 			It("should get ticker symbols", func() {
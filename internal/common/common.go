@@ -32,22 +32,32 @@ type Cache interface {
 
 // Config represents user defined configuration
 type Config struct {
-	RefreshInterval                   int                `yaml:"interval"`
-	Watchlist                         []string           `yaml:"watchlist"`
-	Lots                              []Lot              `yaml:"lots"`
-	Separate                          bool               `yaml:"show-separator"`
-	ExtraInfoExchange                 bool               `yaml:"show-tags"`
-	ExtraInfoFundamentals             bool               `yaml:"show-fundamentals"`
-	ShowSummary                       bool               `yaml:"show-summary"`
-	ShowHoldings                      bool               `yaml:"show-holdings"`  // Deprecated: use ShowPositions instead, kept for backwards compatibility
-	ShowPositions                     bool               `yaml:"show-positions"` // Preferred field name
-	Sort                              string             `yaml:"sort"`
-	Currency                          string             `yaml:"currency"`
-	CurrencyConvertSummaryOnly        bool               `yaml:"currency-summary-only"`
-	CurrencyDisableUnitCostConversion bool               `yaml:"currency-disable-unit-cost-conversion"`
-	ColorScheme                       ConfigColorScheme  `yaml:"colors"`
-	AssetGroup                        []ConfigAssetGroup `yaml:"groups"`
-	Debug                             bool               `yaml:"debug"`
+	RefreshInterval                   int                        `yaml:"interval"`
+	Watchlist                         []string                   `yaml:"watchlist"`
+	Lots                              []Lot                      `yaml:"lots"`
+	Cash                              []ConfigCash               `yaml:"cash"`
+	Dividends                         []Dividend                 `yaml:"dividends"`
+	LotMatching                       string                     `yaml:"lot-matching"` // Method used to match sells to buys: fifo (default), lifo, specific, or average
+	Separate                          bool                       `yaml:"show-separator"`
+	ExtraInfoExchange                 bool                       `yaml:"show-tags"`
+	ExtraInfoFundamentals             bool                       `yaml:"show-fundamentals"`
+	ShowSummary                       bool                       `yaml:"show-summary"`
+	ShowHoldings                      bool                       `yaml:"show-holdings"`  // Deprecated: use ShowPositions instead, kept for backwards compatibility
+	ShowPositions                     bool                       `yaml:"show-positions"` // Preferred field name
+	ShowSparkline                     bool                       `yaml:"show-sparkline"`
+	Columns                           []string                   `yaml:"columns"` // Optional fields to show in each row in order which replaces the fields set by show-fundamentals and show-positions
+	Sort                              string                     `yaml:"sort"`
+	Currency                          string                     `yaml:"currency"`
+	CurrencyConvertSummaryOnly        bool                       `yaml:"currency-summary-only"`
+	CurrencyDisableUnitCostConversion bool                       `yaml:"currency-disable-unit-cost-conversion"`
+	ColorScheme                       ConfigColorScheme          `yaml:"colors"`
+	AssetGroup                        []ConfigAssetGroup         `yaml:"groups"`
+	UserDefinedPrices                 []ConfigUserDefinedPrice   `yaml:"user-defined-prices"`
+	Alerts                            []ConfigAlert              `yaml:"alerts"`
+	Notifiers                         []ConfigNotifier           `yaml:"notifiers"`
+	SourceAlternatives                []ConfigSourceAlternatives `yaml:"source-alternatives"`
+	CoinCapAPIKey                     string                     `yaml:"coincap-api-key"` // API key required by CoinCap for .CC symbols
+	Debug                             bool                       `yaml:"debug"`
 	// Cache enables the on-disk cache. It is a pointer so that an unset config
 	// value (nil) can be distinguished from an explicit false, allowing the
 	// cache to default to on while still being disableable via config or
@@ -65,16 +75,62 @@ type ConfigColorScheme struct {
 	BackgroundTag string `yaml:"background-tag"`
 }
 
+// ConfigUserDefinedPrice represents a price set manually for an asset without a market data source such as a private security
+type ConfigUserDefinedPrice struct {
+	Symbol         string  `yaml:"symbol"`
+	Name           string  `yaml:"name"`
+	Price          float64 `yaml:"price"`
+	Currency       string  `yaml:"currency"`
+	PricePrevClose float64 `yaml:"prev_close"` // Optional, defaults to price when not set
+	AsOf           string  `yaml:"as_of"`      // Optional date the price was last set in YYYY-MM-DD format
+}
+
+// ConfigAlert represents a rule which triggers an alert when the price of an asset meets a condition
+type ConfigAlert struct {
+	Symbol     string   `yaml:"symbol"`
+	Condition  string   `yaml:"condition"`  // One of above, below, change, cost-basis, 52-week-high, or 52-week-low
+	Value      float64  `yaml:"value"`      // Price for above and below, percent for change, and an optional price for cost-basis
+	Cooldown   *int     `yaml:"cooldown"`   // Optional minimum number of seconds between alerts from the rule, defaults to 300
+	Hysteresis *float64 `yaml:"hysteresis"` // Optional percent of the price the condition must clear by before the rule triggers again, defaults to 0.5
+	Notify     []string `yaml:"notify"`     // Optional names of the notifiers to send alerts from the rule to
+}
+
+// ConfigNotifier represents a destination outside of the terminal which alerts can be sent to
+type ConfigNotifier struct {
+	Name    string            `yaml:"name"`
+	Type    string            `yaml:"type"`    // One of desktop, command, or webhook
+	Command []string          `yaml:"command"` // For command, the program to run followed by its arguments
+	URL     string            `yaml:"url"`     // For webhook, the URL to send a POST request to
+	Headers map[string]string `yaml:"headers"` // For webhook, optional headers to set on the request
+	Retries *int              `yaml:"retries"` // For webhook, optional number of times to retry a failed request, defaults to 3
+}
+
+// ConfigSourceAlternatives represents symbols for the same asset from other sources to switch to when the source for a
+// symbol is failing
+type ConfigSourceAlternatives struct {
+	Symbol       string   `yaml:"symbol"`
+	Alternatives []string `yaml:"alternatives"` // Symbols in the order to switch to (e.g. BTC.CG, BTC-USD)
+}
+
+// ConfigCash represents a cash balance held in a single currency
+type ConfigCash struct {
+	Currency string  `yaml:"currency"`
+	Amount   float64 `yaml:"amount"`
+}
+
 type ConfigAssetGroup struct {
-	Name      string   `yaml:"name"`
-	Watchlist []string `yaml:"watchlist"`
-	Lots      []Lot    `yaml:"lots"`     // Preferred field name
-	Holdings  []Lot    `yaml:"holdings"` // Deprecated: use Lots instead, kept for backwards compatibility
+	Name      string       `yaml:"name"`
+	Watchlist []string     `yaml:"watchlist"`
+	Lots      []Lot        `yaml:"lots"`     // Preferred field name
+	Holdings  []Lot        `yaml:"holdings"` // Deprecated: use Lots instead, kept for backwards compatibility
+	Cash      []ConfigCash `yaml:"cash"`
+	Dividends []Dividend   `yaml:"dividends"`
 }
 
 type AssetGroup struct {
 	ConfigAssetGroup
-	SymbolsBySource []AssetGroupSymbolsBySource
+	SymbolsBySource    []AssetGroupSymbolsBySource
+	SymbolAlternatives []AssetGroupSymbolAlternatives
 }
 
 type AssetGroupSymbolsBySource struct {
@@ -82,6 +138,20 @@ type AssetGroupSymbolsBySource struct {
 	Source  QuoteSource
 }
 
+// AssetGroupSymbolAlternatives represents a symbol and the same asset from other sources to switch to in order when the
+// source for the symbol is failing
+type AssetGroupSymbolAlternatives struct {
+	Symbol       string // Symbol as set in the config (e.g. BTC.X)
+	Source       AssetGroupSymbolSource
+	Alternatives []AssetGroupSymbolSource
+}
+
+// AssetGroupSymbolSource represents a symbol in the API of a source
+type AssetGroupSymbolSource struct {
+	Symbol string
+	Source QuoteSource
+}
+
 type AssetGroupQuote struct {
 	AssetGroup  AssetGroup
 	AssetQuotes []AssetQuote
@@ -99,10 +169,14 @@ type Dependencies struct {
 	GitHubReleasesURL                string
 	MonitorPriceCoinbaseBaseURL      string
 	MonitorPriceCoinbaseStreamingURL string
+	MonitorPriceCoingeckoBaseURL     string
+	MonitorPriceCoinCapBaseURL       string
+	MonitorPriceCoinCapStreamingURL  string
 	MonitorYahooBaseURL              string
 	MonitorYahooSessionRootURL       string
 	MonitorYahooSessionCrumbURL      string
 	MonitorYahooSessionConsentURL    string
+	MonitorYahooStreamingURL         string
 }
 
 type Monitor interface {
@@ -122,9 +196,19 @@ type MonitorCurrencyRate interface {
 // Lot represents a cost basis lot
 type Lot struct {
 	Symbol    string  `yaml:"symbol"`
-	UnitCost  float64 `yaml:"unit_cost"`
+	UnitCost  float64 `yaml:"unit_cost"` // For a sell, the unit price the asset was sold at
 	Quantity  float64 `yaml:"quantity"`
-	FixedCost float64 `yaml:"fixed_cost"`
+	FixedCost float64 `yaml:"fixed_cost"` // For a sell, fees deducted from the proceeds
+	Type      string  `yaml:"type"`       // Optional, either buy (default) or sell
+	ID        string  `yaml:"id"`         // Optional identifier of a buy lot used with specific lot matching
+	LotID     string  `yaml:"lot_id"`     // For a sell with specific lot matching, the id of the buy lot that was sold
+}
+
+// Dividend represents a dividend or distribution received from an asset
+type Dividend struct {
+	Symbol string  `yaml:"symbol"`
+	Amount float64 `yaml:"amount"` // Total amount received in the currency of the asset
+	Date   string  `yaml:"date"`   // Optional date the income was received in YYYY-MM-DD format
 }
 
 // CurrencyRates is a map of currency rates for lookup by currency that needs to be converted
@@ -157,20 +241,26 @@ type PositionChange struct {
 }
 
 type Meta struct {
-	IsVariablePrecision bool
-	OrderIndex          int
-	SymbolInSourceAPI   string
+	IsVariablePrecision   bool
+	OrderIndex            int
+	SymbolInSourceAPI     string
+	LastUpdated           time.Time // Time the quote was last received from its source or zero if it is not known
+	IsStale               bool      // Whether requests to the source have been failing since the quote was last received
+	AlternativeSourceName string    // Name of the source serving the quote in place of the failing source for the symbol or blank if it is served by its own source
 }
 
 type Position struct {
-	Value       float64
-	Cost        float64
-	Quantity    float64
-	UnitValue   float64
-	UnitCost    float64
-	DayChange   PositionChange
-	TotalChange PositionChange
-	Weight      float64
+	Value        float64
+	Cost         float64
+	Quantity     float64
+	UnitValue    float64
+	UnitCost     float64
+	DayChange    PositionChange
+	TotalChange  PositionChange
+	Weight       float64
+	RealizedGain float64
+	Income       float64
+	TotalReturn  PositionChange
 }
 
 // Currency is the original and converted currency if applicable
@@ -241,6 +331,27 @@ type Asset struct {
 	Meta          Meta
 }
 
+// BreakerState is whether requests to a quote source are being made or paused after repeated failures
+type BreakerState int
+
+const (
+	BreakerStateClosed   BreakerState = iota // Requests are made and retried with backoff when they fail
+	BreakerStateOpen                         // Requests are paused until the next probe after repeated failures
+	BreakerStateHalfOpen                     // A probe request is being made to check if the source has recovered
+)
+
+// SourceStatus represents the state of requests to a quote source
+type SourceStatus struct {
+	Source       QuoteSource
+	Name         string
+	BreakerState BreakerState
+	Failures     int       // Number of consecutive failed requests
+	FailingSince time.Time // Time of the first of the consecutive failed requests or zero if there are none
+	RetryAt      time.Time // Time of the next request after a failure or zero if the last request succeeded
+	LastUpdate   time.Time // Time of the most recent successful request or zero if there has not been one
+	LastError    error
+}
+
 type AssetClass int
 
 const (
@@ -278,6 +389,45 @@ type AssetQuote struct {
 	Meta          Meta
 }
 
+// Alert represents an alert rule which was triggered by a price update
+type Alert struct {
+	Symbol    string
+	Condition string
+	Message   string
+	Price     float64
+	Time      time.Time
+	Notify    []string // Names of the notifiers to send the alert to
+}
+
+// SymbolSearchResult represents a symbol matching a search query from a quote source
+type SymbolSearchResult struct {
+	Symbol            string // Symbol as it would be set in the config file (e.g. BTC.CB)
+	SymbolInSourceAPI string
+	Name              string
+	Exchange          string
+	Type              string // Description of the kind of security (e.g. Equity, ETF, Futures)
+	Source            QuoteSource
+}
+
+// PricePoint represents the price of an asset at a point in time
+type PricePoint struct {
+	Time   int64 // Unix timestamp in seconds
+	Price  float64
+	Volume float64 // Volume traded over the interval starting at Time
+}
+
+// PriceHistoryRange is the period of time covered by a price history ending at the current time
+type PriceHistoryRange int
+
+const (
+	PriceHistoryRange1D PriceHistoryRange = iota
+	PriceHistoryRange5D
+	PriceHistoryRange1M
+	PriceHistoryRange6M
+	PriceHistoryRange1Y
+	PriceHistoryRange5Y
+)
+
 type MessageUpdate[T any] struct {
 	Data          T
 	ID            string
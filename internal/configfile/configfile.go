@@ -0,0 +1,259 @@
+package configfile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/afero"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/achannarasappa/ticker/v5/internal/cli"
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+// Read returns the path and contents of the config file
+func Read(fs afero.Fs, configPathOption string) (string, []byte, error) {
+
+	path, err := cli.GetConfigPath(fs, configPathOption)
+
+	if err != nil {
+		return "", nil, fmt.Errorf("%w (set the config file with --config)", err)
+	}
+
+	contents, err := afero.ReadFile(fs, path)
+
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	return path, contents, nil
+}
+
+// Write saves changes to the config file after saving the previous contents alongside it as a backup. An existing backup
+// is not replaced so that it keeps the config file as it was before the first change.
+func Write(fs afero.Fs, path string, previousContents []byte, contents []byte) error {
+
+	backupExists, err := afero.Exists(fs, path+".bak")
+
+	if err != nil {
+		return fmt.Errorf("failed to back up config: %w", err)
+	}
+
+	if !backupExists {
+		if err := afero.WriteFile(fs, path+".bak", previousContents, 0644); err != nil {
+			return fmt.Errorf("failed to back up config: %w", err)
+		}
+	}
+
+	if err := afero.WriteFile(fs, path, contents, 0644); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+
+	return nil
+}
+
+// AppendLots appends lots to a group in the contents of a config file, creating the group if it does not exist, while
+// keeping the order of existing properties and comments. Lots are added to the top level when the group name is not set.
+func AppendLots(contents []byte, groupName string, lots []c.Lot) ([]byte, error) {
+
+	items := make([]*yaml.Node, 0, len(lots))
+
+	for _, lot := range lots {
+		items = append(items, getLotNode(lot))
+	}
+
+	return appendItems(contents, groupName, "lots", items)
+}
+
+// AppendWatchlist appends symbols to the watchlist of a group in the contents of a config file in the same way as AppendLots.
+// Symbols already in the watchlist are skipped and the contents are returned unchanged when there are no symbols to add.
+func AppendWatchlist(contents []byte, groupName string, symbols []string) ([]byte, error) {
+
+	items := make([]*yaml.Node, 0, len(symbols))
+
+	for _, symbol := range symbols {
+		items = append(items, newScalarNode(symbol))
+	}
+
+	return appendItems(contents, groupName, "watchlist", items)
+}
+
+func appendItems(contents []byte, groupName string, key string, items []*yaml.Node) ([]byte, error) {
+
+	var document yaml.Node
+
+	if err := yaml.Unmarshal(contents, &document); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	// An empty config file has no content so start from an empty document
+	if len(document.Content) == 0 {
+		document = yaml.Node{
+			Kind:    yaml.DocumentNode,
+			Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}},
+		}
+	}
+
+	root := document.Content[0]
+
+	if root.Kind != yaml.MappingNode {
+		return nil, errors.New("invalid config: expected a map at the top level") //nolint:goerr113
+	}
+
+	var appended int
+
+	if groupName == "" {
+		appended = appendToKey(root, key, items)
+	} else {
+		appended = appendToGroup(root, groupName, key, items)
+	}
+
+	if appended == 0 {
+		return contents, nil
+	}
+
+	var out bytes.Buffer
+
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	encoder.CompactSeqIndent()
+
+	if err := encoder.Encode(&document); err != nil {
+		return nil, fmt.Errorf("failed to write config: %w", err)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write config: %w", err)
+	}
+
+	return out.Bytes(), nil
+}
+
+func appendToGroup(root *yaml.Node, groupName string, key string, items []*yaml.Node) int {
+
+	groups := getValue(root, "groups")
+
+	if groups == nil || groups.Kind != yaml.SequenceNode {
+		groups = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		setValue(root, "groups", groups)
+	}
+
+	for _, group := range groups.Content {
+		if group.Kind != yaml.MappingNode {
+			continue
+		}
+
+		name := getValue(group, "name")
+
+		if name == nil || name.Value != groupName {
+			continue
+		}
+
+		// Lots are added to the deprecated holdings property when it is the one in use for the group
+		if existingLots := getValue(group, "lots"); key == "lots" && (existingLots == nil || len(existingLots.Content) == 0) && getValue(group, "holdings") != nil {
+			key = "holdings"
+		}
+
+		return appendToKey(group, key, items)
+	}
+
+	group := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	setValue(group, "name", newScalarNode(groupName))
+	appended := appendToKey(group, key, items)
+
+	groups.Style = 0
+	groups.Content = append(groups.Content, group)
+
+	return appended
+}
+
+// appendToKey appends items to the sequence at key, skipping scalar items such as symbols which are already in it, and
+// returns the number of items appended
+func appendToKey(mapping *yaml.Node, key string, items []*yaml.Node) int {
+
+	existing := getValue(mapping, key)
+
+	if existing == nil || existing.Kind != yaml.SequenceNode {
+		setValue(mapping, key, &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: items})
+
+		return len(items)
+	}
+
+	newItems := make([]*yaml.Node, 0, len(items))
+
+	for _, item := range items {
+		if item.Kind == yaml.ScalarNode && slices.ContainsFunc(existing.Content, func(existingItem *yaml.Node) bool {
+			return existingItem.Kind == yaml.ScalarNode && strings.EqualFold(existingItem.Value, item.Value)
+		}) {
+			continue
+		}
+
+		newItems = append(newItems, item)
+	}
+
+	if len(newItems) == 0 {
+		return 0
+	}
+
+	// Flow style sequences such as [] are written in block style once they have items
+	existing.Style = 0
+	existing.Content = append(existing.Content, newItems...)
+
+	return len(newItems)
+}
+
+func getValue(mapping *yaml.Node, key string) *yaml.Node {
+
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+func setValue(mapping *yaml.Node, key string, value *yaml.Node) {
+
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = value
+
+			return
+		}
+	}
+
+	mapping.Content = append(mapping.Content, newScalarNode(key), value)
+}
+
+func newScalarNode(value interface{}) *yaml.Node {
+
+	var node yaml.Node
+
+	_ = node.Encode(value)
+
+	return &node
+}
+
+func getLotNode(lot c.Lot) *yaml.Node {
+
+	lotNode := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+
+	setValue(lotNode, "symbol", newScalarNode(lot.Symbol))
+
+	if lot.Type == "sell" {
+		setValue(lotNode, "type", newScalarNode(lot.Type))
+	}
+
+	setValue(lotNode, "quantity", newScalarNode(lot.Quantity))
+	setValue(lotNode, "unit_cost", newScalarNode(lot.UnitCost))
+
+	if lot.FixedCost != 0 {
+		setValue(lotNode, "fixed_cost", newScalarNode(lot.FixedCost))
+	}
+
+	return lotNode
+}
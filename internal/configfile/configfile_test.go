@@ -0,0 +1,172 @@
+package configfile_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/configfile"
+)
+
+var _ = Describe("Configfile", func() {
+
+	Describe("Read", func() {
+		It("should return the path and contents of the config file", func() {
+			fs := afero.NewMemMapFs()
+			afero.WriteFile(fs, "/ticker.yaml", []byte("watchlist:\n- GOOG\n"), 0644)
+
+			path, contents, err := configfile.Read(fs, "/ticker.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(path).To(Equal("/ticker.yaml"))
+			Expect(string(contents)).To(Equal("watchlist:\n- GOOG\n"))
+		})
+
+		When("the config file does not exist", func() {
+			It("should return an error", func() {
+				_, _, err := configfile.Read(afero.NewMemMapFs(), "/ticker.yaml")
+				Expect(err).To(MatchError(ContainSubstring("invalid config")))
+			})
+		})
+	})
+
+	Describe("Write", func() {
+		It("should write the config file and keep a backup of the previous contents", func() {
+			fs := afero.NewMemMapFs()
+
+			err := configfile.Write(fs, "/ticker.yaml", []byte("previous"), []byte("next"))
+			Expect(err).NotTo(HaveOccurred())
+
+			contents, _ := afero.ReadFile(fs, "/ticker.yaml")
+			backup, _ := afero.ReadFile(fs, "/ticker.yaml.bak")
+			Expect(string(contents)).To(Equal("next"))
+			Expect(string(backup)).To(Equal("previous"))
+		})
+
+		When("a backup already exists", func() {
+			It("should not replace the backup", func() {
+				fs := afero.NewMemMapFs()
+				afero.WriteFile(fs, "/ticker.yaml.bak", []byte("original"), 0644)
+
+				err := configfile.Write(fs, "/ticker.yaml", []byte("previous"), []byte("next"))
+				Expect(err).NotTo(HaveOccurred())
+
+				contents, _ := afero.ReadFile(fs, "/ticker.yaml")
+				backup, _ := afero.ReadFile(fs, "/ticker.yaml.bak")
+				Expect(string(contents)).To(Equal("next"))
+				Expect(string(backup)).To(Equal("original"))
+			})
+		})
+	})
+
+	Describe("AppendLots", func() {
+		inputLots := []c.Lot{
+			{Symbol: "AAPL", UnitCost: 150, Quantity: 2, FixedCost: 1.5, Type: "sell"},
+		}
+
+		It("should append lots to the top level lots", func() {
+			output, err := configfile.AppendLots([]byte("watchlist:\n- GOOG\nlots:\n- symbol: MSFT\n  quantity: 1\n  unit_cost: 300\n"), "", inputLots)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(output)).To(Equal(strings.Join([]string{
+				"watchlist:",
+				"- GOOG",
+				"lots:",
+				"- symbol: MSFT",
+				"  quantity: 1",
+				"  unit_cost: 300",
+				"- symbol: AAPL",
+				"  type: sell",
+				"  quantity: 2",
+				"  unit_cost: 150",
+				"  fixed_cost: 1.5",
+				"",
+			}, "\n")))
+		})
+
+		When("the group exists", func() {
+			It("should append lots to the group", func() {
+				output, err := configfile.AppendLots([]byte("groups:\n- name: brokerage\n  holdings:\n  - symbol: MSFT\n    quantity: 1\n    unit_cost: 300\n"), "brokerage", inputLots)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(output)).To(Equal(strings.Join([]string{
+					"groups:",
+					"- name: brokerage",
+					"  holdings:",
+					"  - symbol: MSFT",
+					"    quantity: 1",
+					"    unit_cost: 300",
+					"  - symbol: AAPL",
+					"    type: sell",
+					"    quantity: 2",
+					"    unit_cost: 150",
+					"    fixed_cost: 1.5",
+					"",
+				}, "\n")))
+			})
+		})
+
+		When("the group does not exist", func() {
+			It("should create the group", func() {
+				output, err := configfile.AppendLots([]byte("watchlist:\n- GOOG\n"), "brokerage", inputLots[:1])
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(output)).To(ContainSubstring("groups:\n- name: brokerage\n  lots:\n  - symbol: AAPL\n"))
+			})
+		})
+	})
+
+	Describe("AppendWatchlist", func() {
+		It("should append symbols to the top level watchlist", func() {
+			output, err := configfile.AppendWatchlist([]byte("watchlist:\n- GOOG\nlots: []\n"), "", []string{"BTC.CB"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(output)).To(Equal("watchlist:\n- GOOG\n- BTC.CB\nlots: []\n"))
+		})
+
+		When("the group does not have a watchlist", func() {
+			It("should add the watchlist to the group", func() {
+				output, err := configfile.AppendWatchlist([]byte("groups:\n- name: crypto\n  lots: []\n"), "crypto", []string{"BTC.CB"})
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(output)).To(Equal("groups:\n- name: crypto\n  lots: []\n  watchlist:\n  - BTC.CB\n"))
+			})
+		})
+
+		When("the symbol is already in the watchlist", func() {
+			It("should return the contents unchanged", func() {
+				output, err := configfile.AppendWatchlist([]byte("watchlist: [GOOG, btc.cb]\n"), "", []string{"BTC.CB"})
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(output)).To(Equal("watchlist: [GOOG, btc.cb]\n"))
+			})
+		})
+
+		When("the config file has comments", func() {
+			It("should keep the comments", func() {
+				output, err := configfile.AppendWatchlist([]byte("# symbols to watch\nwatchlist:\n- GOOG # alphabet\nlots: []\n"), "", []string{"BTC.CB"})
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(output)).To(Equal("# symbols to watch\nwatchlist:\n- GOOG # alphabet\n- BTC.CB\nlots: []\n"))
+			})
+		})
+
+		When("the config file is empty", func() {
+			It("should add the watchlist", func() {
+				output, err := configfile.AppendWatchlist([]byte(""), "", []string{"BTC.CB"})
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(output)).To(Equal("watchlist:\n- BTC.CB\n"))
+			})
+		})
+
+		When("the config file is invalid", func() {
+			It("should return an error", func() {
+				_, err := configfile.AppendWatchlist([]byte("watchlist: ["), "", []string{"BTC.CB"})
+				Expect(err).To(MatchError(ContainSubstring("invalid config")))
+			})
+		})
+	})
+})
@@ -0,0 +1,200 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/configfile"
+	"github.com/achannarasappa/ticker/v5/internal/ui/util"
+)
+
+// Options to configure import behavior
+type Options struct {
+	Format  string
+	Columns string
+	Group   string
+	DryRun  bool
+	Yes     bool
+}
+
+// Changes are the lots from a transaction export that will be added to a group
+type Changes struct {
+	Lots       []c.Lot
+	Duplicates int
+}
+
+// Run imports lots from the transaction export in the first argument into the config file
+func Run(dep *c.Dependencies, configPath *string, options *Options) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+
+		parser, err := GetParser(options.Format, options.Columns)
+
+		if err != nil {
+			return err
+		}
+
+		handle, err := dep.Fs.Open(args[0])
+
+		if err != nil {
+			return fmt.Errorf("failed to open transaction export: %w", err)
+		}
+
+		defer handle.Close()
+
+		lots, err := parser.Parse(handle)
+
+		if err != nil {
+			return fmt.Errorf("failed to parse transaction export: %w", err)
+		}
+
+		path, contents, err := configfile.Read(dep.Fs, *configPath)
+
+		if err != nil {
+			return err
+		}
+
+		var config c.Config
+
+		if err = yaml.Unmarshal(contents, &config); err != nil {
+			return fmt.Errorf("invalid config: %w", err)
+		}
+
+		changes := GetChanges(getGroupLots(config, options.Group), lots)
+		out := cmd.OutOrStdout()
+
+		fmt.Fprint(out, FormatChanges(changes, options.Group, path))
+
+		if len(changes.Lots) == 0 || options.DryRun {
+			return nil
+		}
+
+		if !options.Yes && !confirm(cmd.InOrStdin(), out) {
+			fmt.Fprintln(out, "Import cancelled")
+
+			return nil
+		}
+
+		merged, err := configfile.AppendLots(contents, options.Group, changes.Lots)
+
+		if err != nil {
+			return err
+		}
+
+		if err = configfile.Write(dep.Fs, path, contents, merged); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(out, "Imported %d lots into %s\n", len(changes.Lots), path)
+
+		return nil
+	}
+}
+
+// getGroupLots returns the lots in a group or the default group when the group name is not set
+func getGroupLots(config c.Config, groupName string) []c.Lot {
+
+	if groupName == "" {
+		return config.Lots
+	}
+
+	for _, group := range config.AssetGroup {
+		if group.Name == groupName {
+			if len(group.Lots) == 0 {
+				return group.Holdings
+			}
+
+			return group.Lots
+		}
+	}
+
+	return []c.Lot{}
+}
+
+// GetChanges returns the imported lots that are not already in the group. Each existing lot is only matched once so that
+// separate transactions with the same details are kept.
+func GetChanges(existingLots []c.Lot, importedLots []c.Lot) Changes {
+
+	existingCount := make(map[c.Lot]int)
+
+	for _, lot := range existingLots {
+		existingCount[normalizeLot(lot)]++
+	}
+
+	changes := Changes{Lots: make([]c.Lot, 0)}
+
+	for _, lot := range importedLots {
+		if existingCount[normalizeLot(lot)] > 0 {
+			existingCount[normalizeLot(lot)]--
+			changes.Duplicates++
+
+			continue
+		}
+
+		changes.Lots = append(changes.Lots, lot)
+	}
+
+	return changes
+}
+
+// normalizeLot removes differences between lots that do not change the transaction
+func normalizeLot(lot c.Lot) c.Lot {
+
+	lotType := strings.ToLower(lot.Type)
+	if lotType == "buy" {
+		lotType = ""
+	}
+
+	return c.Lot{
+		Symbol:    strings.ToUpper(lot.Symbol),
+		UnitCost:  lot.UnitCost,
+		Quantity:  lot.Quantity,
+		FixedCost: lot.FixedCost,
+		Type:      lotType,
+	}
+}
+
+// FormatChanges returns a preview of the lots that will be imported
+func FormatChanges(changes Changes, groupName string, path string) string {
+
+	if groupName == "" {
+		groupName = "default"
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "%d new lots for group '%s' in %s (%d duplicates skipped)\n", len(changes.Lots), groupName, path, changes.Duplicates)
+
+	for _, lot := range changes.Lots {
+		lotType := "buy"
+		if lot.Type == "sell" {
+			lotType = "sell"
+		}
+
+		fmt.Fprintf(&sb, "  + %-4s %-10s %s @ %s", lotType, lot.Symbol, strconv.FormatFloat(lot.Quantity, 'f', -1, 64), util.ConvertFloatToString(lot.UnitCost, true))
+
+		if lot.FixedCost != 0 {
+			fmt.Fprintf(&sb, " (fees %s)", util.ConvertFloatToString(lot.FixedCost, true))
+		}
+
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+func confirm(in io.Reader, out io.Writer) bool {
+
+	fmt.Fprint(out, "Write changes to config? A backup will be saved alongside it if there is not one already [y/N]: ")
+
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,236 @@
+package importer_test
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/importer"
+)
+
+var _ = Describe("Importer", func() {
+
+	Describe("GetParser", func() {
+		It("should return a parser for a supported format", func() {
+			parser, err := importer.GetParser("schwab", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parser).NotTo(BeNil())
+		})
+
+		When("the format is not supported", func() {
+			It("should return an error listing the supported formats", func() {
+				_, err := importer.GetParser("robinhood", "")
+				Expect(err).To(MatchError("unknown format 'robinhood' (must be one of generic, fidelity, ibkr, schwab)"))
+			})
+		})
+
+		When("columns are set for a format other than generic", func() {
+			It("should return an error", func() {
+				_, err := importer.GetParser("schwab", "symbol=Ticker")
+				Expect(err).To(MatchError("columns can only be set for the generic format"))
+			})
+		})
+
+		When("a column mapping is invalid", func() {
+			It("should return an error", func() {
+				_, err := importer.GetParser("", "ticker=Symbol")
+				Expect(err).To(MatchError(ContainSubstring("invalid column mapping 'ticker=Symbol'")))
+			})
+		})
+	})
+
+	Describe("Parse", func() {
+		It("should parse a generic CSV file", func() {
+			parser, _ := importer.GetParser("generic", "")
+			lots, err := parser.Parse(strings.NewReader(strings.Join([]string{
+				"date,symbol,type,quantity,price,fees",
+				"2024-01-02,msft,buy,10,$300.00,1",
+				"2024-03-04,MSFT,sell,5,\"$1,350.50\",",
+				"2024-03-05,MSFT,dividend,0,12.00,",
+			}, "\n")))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lots).To(Equal([]c.Lot{
+				{Symbol: "MSFT", UnitCost: 300, Quantity: 10, FixedCost: 1},
+				{Symbol: "MSFT", UnitCost: 1350.5, Quantity: 5, Type: "sell"},
+			}))
+		})
+
+		When("columns are renamed", func() {
+			It("should use the renamed columns and treat negative quantities as sells when there is no type column", func() {
+				parser, _ := importer.GetParser("", "symbol=Ticker,quantity=Shares,price=Cost,type=,fees=Commission+Fee")
+				lots, err := parser.Parse(strings.NewReader(strings.Join([]string{
+					"Ticker,Shares,Cost,Commission,Fee",
+					"AAPL,-2,150,1,0.5",
+				}, "\n")))
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(lots).To(Equal([]c.Lot{
+					{Symbol: "AAPL", UnitCost: 150, Quantity: 2, FixedCost: 1.5, Type: "sell"},
+				}))
+			})
+		})
+
+		When("the export is ordered from newest to oldest", func() {
+			It("should return lots from oldest to newest", func() {
+				parser, _ := importer.GetParser("schwab", "")
+				lots, err := parser.Parse(strings.NewReader(strings.Join([]string{
+					"\"Date\",\"Action\",\"Symbol\",\"Description\",\"Quantity\",\"Price\",\"Fees & Comm\",\"Amount\"",
+					"\"03/15/2024 as of 03/14/2024\",\"Sell\",\"VTI\",\"VANGUARD TOTAL\",\"5\",\"$250.00\",\"$0.01\",\"$1249.99\"",
+					"\"02/01/2024\",\"Qualified Dividend\",\"VTI\",\"VANGUARD TOTAL\",\"\",\"\",\"\",\"$12.00\"",
+					"\"01/10/2024\",\"Buy\",\"VTI\",\"VANGUARD TOTAL\",\"10\",\"$230.00\",\"\",\"-$2300.00\"",
+				}, "\n")))
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(lots).To(Equal([]c.Lot{
+					{Symbol: "VTI", UnitCost: 230, Quantity: 10},
+					{Symbol: "VTI", UnitCost: 250, Quantity: 5, FixedCost: 0.01, Type: "sell"},
+				}))
+			})
+		})
+
+		When("the header is not the first row", func() {
+			It("should find the header row", func() {
+				parser, _ := importer.GetParser("fidelity", "")
+				lots, err := parser.Parse(strings.NewReader(strings.Join([]string{
+					"",
+					"Brokerage",
+					"Run Date,Action,Symbol,Price ($),Quantity,Commission ($),Fees ($),Amount ($)",
+					"01/05/2024,YOU BOUGHT APPLE INC (AAPL),AAPL,185.50,4,,0.02,-742.02",
+					"02/05/2024,YOU SOLD APPLE INC (AAPL),AAPL,190,-4,,0.03,759.97",
+				}, "\n")))
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(lots).To(Equal([]c.Lot{
+					{Symbol: "AAPL", UnitCost: 185.5, Quantity: 4, FixedCost: 0.02},
+					{Symbol: "AAPL", UnitCost: 190, Quantity: 4, FixedCost: 0.03, Type: "sell"},
+				}))
+			})
+		})
+
+		When("the header row is missing", func() {
+			It("should return an error", func() {
+				parser, _ := importer.GetParser("generic", "")
+				_, err := parser.Parse(strings.NewReader("a,b,c\n1,2,3"))
+				Expect(err).To(MatchError("header row with columns 'symbol', 'quantity', and 'price' not found"))
+			})
+		})
+
+		When("a quantity is not a number", func() {
+			It("should return an error", func() {
+				parser, _ := importer.GetParser("generic", "")
+				_, err := parser.Parse(strings.NewReader("symbol,quantity,price\nMSFT,ten,300"))
+				Expect(err).To(MatchError("invalid quantity 'ten' for symbol 'MSFT'"))
+			})
+		})
+	})
+
+	Describe("GetChanges", func() {
+		It("should skip lots that are already in the group once for each existing lot", func() {
+			changes := importer.GetChanges(
+				[]c.Lot{
+					{Symbol: "msft", UnitCost: 300, Quantity: 10, Type: "buy"},
+				},
+				[]c.Lot{
+					{Symbol: "MSFT", UnitCost: 300, Quantity: 10},
+					{Symbol: "MSFT", UnitCost: 300, Quantity: 10},
+					{Symbol: "MSFT", UnitCost: 310, Quantity: 5, Type: "sell"},
+				},
+			)
+
+			Expect(changes.Duplicates).To(Equal(1))
+			Expect(changes.Lots).To(Equal([]c.Lot{
+				{Symbol: "MSFT", UnitCost: 300, Quantity: 10},
+				{Symbol: "MSFT", UnitCost: 310, Quantity: 5, Type: "sell"},
+			}))
+		})
+	})
+
+	Describe("Run", func() {
+
+		var (
+			inputDependencies c.Dependencies
+			inputConfigPath   string
+			inputOptions      importer.Options
+			outputBuffer      *bytes.Buffer
+			cmd               *cobra.Command
+		)
+
+		BeforeEach(func() {
+			inputDependencies = c.Dependencies{Fs: afero.NewMemMapFs()}
+			inputConfigPath = "/ticker.yaml"
+			inputOptions = importer.Options{}
+			outputBuffer = new(bytes.Buffer)
+			cmd = &cobra.Command{}
+			cmd.SetOut(outputBuffer)
+
+			afero.WriteFile(inputDependencies.Fs, "/ticker.yaml", []byte("lots:\n- symbol: MSFT\n  quantity: 10\n  unit_cost: 300\n"), 0644)
+			afero.WriteFile(inputDependencies.Fs, "/export.csv", []byte("symbol,quantity,price\nMSFT,10,300\nAAPL,2,150\n"), 0644)
+		})
+
+		It("should write new lots to the config after confirmation and keep a backup", func() {
+			cmd.SetIn(strings.NewReader("y\n"))
+
+			err := importer.Run(&inputDependencies, &inputConfigPath, &inputOptions)(cmd, []string{"/export.csv"})
+			Expect(err).NotTo(HaveOccurred())
+
+			output, _ := afero.ReadFile(inputDependencies.Fs, "/ticker.yaml")
+			backup, _ := afero.ReadFile(inputDependencies.Fs, "/ticker.yaml.bak")
+			Expect(string(output)).To(HaveSuffix("- symbol: AAPL\n  quantity: 2\n  unit_cost: 150\n"))
+			Expect(string(backup)).To(Equal("lots:\n- symbol: MSFT\n  quantity: 10\n  unit_cost: 300\n"))
+			Expect(outputBuffer.String()).To(ContainSubstring("1 new lots for group 'default' in /ticker.yaml (1 duplicates skipped)"))
+			Expect(outputBuffer.String()).To(ContainSubstring("Imported 1 lots into /ticker.yaml"))
+		})
+
+		When("the import is not confirmed", func() {
+			It("should not change the config", func() {
+				cmd.SetIn(strings.NewReader("n\n"))
+
+				err := importer.Run(&inputDependencies, &inputConfigPath, &inputOptions)(cmd, []string{"/export.csv"})
+				Expect(err).NotTo(HaveOccurred())
+
+				exists, _ := afero.Exists(inputDependencies.Fs, "/ticker.yaml.bak")
+				Expect(exists).To(BeFalse())
+				Expect(outputBuffer.String()).To(ContainSubstring("Import cancelled"))
+			})
+		})
+
+		When("the dry run option is set", func() {
+			It("should only show the changes", func() {
+				inputOptions.DryRun = true
+
+				err := importer.Run(&inputDependencies, &inputConfigPath, &inputOptions)(cmd, []string{"/export.csv"})
+				Expect(err).NotTo(HaveOccurred())
+
+				output, _ := afero.ReadFile(inputDependencies.Fs, "/ticker.yaml")
+				Expect(string(output)).To(Equal("lots:\n- symbol: MSFT\n  quantity: 10\n  unit_cost: 300\n"))
+				Expect(outputBuffer.String()).To(ContainSubstring("  + buy  AAPL       2 @ 150.00"))
+			})
+		})
+
+		When("the yes option is set", func() {
+			It("should write the changes without confirmation", func() {
+				inputOptions.Yes = true
+				inputOptions.Group = "brokerage"
+
+				err := importer.Run(&inputDependencies, &inputConfigPath, &inputOptions)(cmd, []string{"/export.csv"})
+				Expect(err).NotTo(HaveOccurred())
+
+				output, _ := afero.ReadFile(inputDependencies.Fs, "/ticker.yaml")
+				Expect(string(output)).To(ContainSubstring("groups:\n- name: brokerage\n  lots:\n  - symbol: MSFT\n"))
+			})
+		})
+
+		When("the transaction export does not exist", func() {
+			It("should return an error", func() {
+				err := importer.Run(&inputDependencies, &inputConfigPath, &inputOptions)(cmd, []string{"/missing.csv"})
+				Expect(err).To(MatchError(ContainSubstring("failed to open transaction export")))
+			})
+		})
+	})
+})
@@ -0,0 +1,369 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+// Parser converts a broker transaction export into lots
+type Parser interface {
+	Parse(r io.Reader) ([]c.Lot, error)
+}
+
+// ColumnMapping is the name of the header of each column in a transaction export. Action, Fees, and Date are optional.
+type ColumnMapping struct {
+	Symbol   string
+	Quantity string
+	Price    string
+	Action   string   // Column describing the transaction (e.g. Buy, YOU SOLD) - when not set, negative quantities are sells
+	Fees     []string // Columns summed into the fixed cost of the lot
+	Date     string   // Column used to order transactions from oldest to newest
+}
+
+// CSVParser parses a CSV transaction export with a header row using a column mapping
+type CSVParser struct {
+	Columns ColumnMapping
+}
+
+//nolint:gochecknoglobals
+var (
+	// columnsGeneric is the default column mapping for a CSV file created by hand or by a spreadsheet
+	columnsGeneric = ColumnMapping{
+		Symbol:   "symbol",
+		Quantity: "quantity",
+		Price:    "price",
+		Action:   "type",
+		Fees:     []string{"fees"},
+		Date:     "date",
+	}
+	// parsers are the layouts of transaction exports from common brokers
+	parsers = map[string]Parser{
+		"schwab": CSVParser{Columns: ColumnMapping{
+			Symbol:   "Symbol",
+			Quantity: "Quantity",
+			Price:    "Price",
+			Action:   "Action",
+			Fees:     []string{"Fees & Comm"},
+			Date:     "Date",
+		}},
+		"fidelity": CSVParser{Columns: ColumnMapping{
+			Symbol:   "Symbol",
+			Quantity: "Quantity",
+			Price:    "Price ($)",
+			Action:   "Action",
+			Fees:     []string{"Commission ($)", "Fees ($)"},
+			Date:     "Run Date",
+		}},
+		"ibkr": CSVParser{Columns: ColumnMapping{
+			Symbol:   "Symbol",
+			Quantity: "Quantity",
+			Price:    "TradePrice",
+			Action:   "Buy/Sell",
+			Fees:     []string{"IBCommission"},
+			Date:     "TradeDate",
+		}},
+	}
+	dateLayouts = []string{"2006-01-02", "01/02/2006", "20060102", "2006-01-02 15:04:05", "2006-01-02T15:04:05Z07:00"}
+)
+
+// GetParser returns the parser for a transaction export format. The generic format's columns can be renamed with a comma
+// separated list of field=header pairs (e.g. symbol=Ticker,quantity=Shares).
+func GetParser(format string, columns string) (Parser, error) {
+
+	if format == "" || format == "generic" {
+		mapping, err := getColumnMapping(columns)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return CSVParser{Columns: mapping}, nil
+	}
+
+	if columns != "" {
+		return nil, errors.New("columns can only be set for the generic format")
+	}
+
+	parser, ok := parsers[format]
+
+	if !ok {
+		return nil, fmt.Errorf("unknown format '%s' (must be one of %s)", format, strings.Join(GetFormats(), ", "))
+	}
+
+	return parser, nil
+}
+
+// GetFormats returns the names of the supported transaction export formats
+func GetFormats() []string {
+	formats := []string{"generic"}
+
+	for format := range parsers {
+		formats = append(formats, format)
+	}
+
+	slices.Sort(formats[1:])
+
+	return formats
+}
+
+func getColumnMapping(columns string) (ColumnMapping, error) {
+
+	mapping := columnsGeneric
+
+	if columns == "" {
+		return mapping, nil
+	}
+
+	for _, pair := range strings.Split(columns, ",") {
+		field, header, ok := strings.Cut(pair, "=")
+
+		if !ok {
+			return mapping, fmt.Errorf("invalid column mapping '%s' (must be in the format field=header)", pair)
+		}
+
+		field = strings.TrimSpace(strings.ToLower(field))
+		header = strings.TrimSpace(header)
+
+		// Only the optional columns can be removed from the mapping with an empty header
+		if header == "" && (field == "symbol" || field == "quantity" || field == "price") {
+			return mapping, fmt.Errorf("invalid column mapping '%s' (header is required for %s)", pair, field)
+		}
+
+		switch field {
+		case "symbol":
+			mapping.Symbol = header
+		case "quantity":
+			mapping.Quantity = header
+		case "price":
+			mapping.Price = header
+		case "type":
+			mapping.Action = header
+		case "fees":
+			mapping.Fees = nil
+			if header != "" {
+				mapping.Fees = strings.Split(header, "+")
+			}
+		case "date":
+			mapping.Date = header
+		default:
+			return mapping, fmt.Errorf("invalid column mapping '%s' (field must be one of symbol, quantity, price, type, fees, or date)", pair)
+		}
+	}
+
+	return mapping, nil
+}
+
+type transaction struct {
+	lot  c.Lot
+	date time.Time
+}
+
+// Parse reads buys and sells from a transaction export in the order they were made. Other transactions such as dividends
+// and transfers are skipped.
+func (p CSVParser) Parse(r io.Reader) ([]c.Lot, error) {
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	records, err := reader.ReadAll()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+
+	headerIndex, columnIndexes, err := p.getColumnIndexes(records)
+
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]transaction, 0)
+
+	for _, record := range records[headerIndex+1:] {
+
+		t, ok, err := p.parseRecord(record, columnIndexes)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			transactions = append(transactions, t)
+		}
+
+	}
+
+	// Exports are often ordered from newest to oldest but sells must come after the buys they are matched to
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].date.Before(transactions[j].date)
+	})
+
+	lots := make([]c.Lot, 0, len(transactions))
+
+	for _, t := range transactions {
+		lots = append(lots, t.lot)
+	}
+
+	return lots, nil
+}
+
+// getColumnIndexes finds the header row which is not always the first row (e.g. when an export starts with account details)
+func (p CSVParser) getColumnIndexes(records [][]string) (int, map[string]int, error) {
+
+	for i, record := range records {
+
+		columnIndexes := make(map[string]int)
+
+		for j, header := range record {
+			columnIndexes[strings.ToLower(strings.TrimSpace(header))] = j
+		}
+
+		_, hasSymbol := columnIndexes[strings.ToLower(p.Columns.Symbol)]
+		_, hasQuantity := columnIndexes[strings.ToLower(p.Columns.Quantity)]
+		_, hasPrice := columnIndexes[strings.ToLower(p.Columns.Price)]
+
+		if hasSymbol && hasQuantity && hasPrice {
+			return i, columnIndexes, nil
+		}
+
+	}
+
+	return 0, nil, fmt.Errorf("header row with columns '%s', '%s', and '%s' not found", p.Columns.Symbol, p.Columns.Quantity, p.Columns.Price)
+}
+
+func (p CSVParser) parseRecord(record []string, columnIndexes map[string]int) (transaction, bool, error) {
+
+	field := func(header string) string {
+		i, ok := columnIndexes[strings.ToLower(header)]
+
+		if header == "" || !ok || i >= len(record) {
+			return ""
+		}
+
+		return strings.TrimSpace(record[i])
+	}
+
+	symbol := strings.ToUpper(field(p.Columns.Symbol))
+	quantityText := field(p.Columns.Quantity)
+
+	if symbol == "" || quantityText == "" {
+		return transaction{}, false, nil
+	}
+
+	quantity, err := parseNumber(quantityText)
+
+	if err != nil {
+		return transaction{}, false, fmt.Errorf("invalid quantity '%s' for symbol '%s'", quantityText, symbol)
+	}
+
+	price, err := parseNumber(field(p.Columns.Price))
+
+	if err != nil {
+		return transaction{}, false, fmt.Errorf("invalid price '%s' for symbol '%s'", field(p.Columns.Price), symbol)
+	}
+
+	var fees float64
+
+	for _, column := range p.Columns.Fees {
+		fee, err := parseNumber(field(column))
+
+		if err != nil {
+			return transaction{}, false, fmt.Errorf("invalid fees '%s' for symbol '%s'", field(column), symbol)
+		}
+
+		fees += math.Abs(fee)
+	}
+
+	isSell := quantity < 0
+
+	// Exports without the action column are treated as having signed quantities
+	if _, hasAction := columnIndexes[strings.ToLower(p.Columns.Action)]; p.Columns.Action != "" && hasAction {
+		var ok bool
+		isSell, ok = parseAction(field(p.Columns.Action))
+
+		if !ok {
+			return transaction{}, false, nil
+		}
+	}
+
+	if quantity == 0 {
+		return transaction{}, false, nil
+	}
+
+	lot := c.Lot{
+		Symbol:    symbol,
+		UnitCost:  math.Abs(price),
+		Quantity:  math.Abs(quantity),
+		FixedCost: fees,
+	}
+
+	if isSell {
+		lot.Type = "sell"
+	}
+
+	return transaction{lot: lot, date: parseDate(field(p.Columns.Date))}, true, nil
+}
+
+// parseAction returns whether a transaction description is a sell and false for ok if it is neither a buy nor a sell
+func parseAction(action string) (isSell bool, ok bool) {
+
+	action = strings.ToLower(action)
+
+	if strings.Contains(action, "sell") || strings.Contains(action, "sold") {
+		return true, true
+	}
+
+	if strings.Contains(action, "buy") || strings.Contains(action, "bought") || strings.Contains(action, "reinvest") {
+		return false, true
+	}
+
+	return false, false
+}
+
+// parseNumber parses numbers formatted for display such as $1,234.50 and (12.00) for negative values
+func parseNumber(text string) (float64, error) {
+
+	text = strings.NewReplacer("$", "", ",", "", " ", "").Replace(text)
+
+	if text == "" || text == "--" {
+		return 0, nil
+	}
+
+	isNegative := strings.HasPrefix(text, "(") && strings.HasSuffix(text, ")")
+	text = strings.Trim(text, "()")
+
+	value, err := strconv.ParseFloat(text, 64)
+
+	if isNegative {
+		value = -value
+	}
+
+	return value, err
+}
+
+func parseDate(text string) time.Time {
+
+	// Some brokers append details to the date (e.g. "03/15/2024 as of 03/14/2024")
+	if fields := strings.Fields(text); len(fields) > 0 && !strings.Contains(text, ":") {
+		text = fields[0]
+	}
+
+	for _, layout := range dateLayouts {
+		if date, err := time.Parse(layout, text); err == nil {
+			return date
+		}
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,77 @@
+// Package metrics counts operational events across monitors with Prometheus counters. Counters are shared by the whole
+// process so that monitors do not need to be configured with somewhere to record them and are exposed by registering
+// them on the registry of whatever serves them.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Results of a request or lookup used as label values
+const (
+	ResultSuccess = "success"
+	ResultError   = "error"
+	ResultHit     = "hit"
+	ResultMiss    = "miss"
+)
+
+//nolint:gochecknoglobals
+var (
+	// SourceRequests counts HTTP requests made to quote sources by source and result
+	SourceRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ticker_source_requests_total",
+		Help: "HTTP requests made to quote sources.",
+	}, []string{"source", "result"})
+	// MonitorErrors counts errors reported by monitors
+	MonitorErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "ticker_monitor_errors_total",
+		Help: "Errors reported by monitors.",
+	})
+	// StreamerReconnects counts websocket connections which were replaced by a new connection by source
+	StreamerReconnects = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ticker_streamer_reconnects_total",
+		Help: "Websocket reconnections made by streamers.",
+	}, []string{"source"})
+	// CacheLookups counts lookups in the on-disk cache by result
+	CacheLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ticker_cache_lookups_total",
+		Help: "Lookups in the on-disk cache.",
+	}, []string{"result"})
+)
+
+type transport struct {
+	source string
+	base   http.RoundTripper
+}
+
+// MustRegister registers all counters on a registry and panics if any of them are already registered on it
+func MustRegister(registerer prometheus.Registerer) {
+	registerer.MustRegister(SourceRequests, MonitorErrors, StreamerReconnects, CacheLookups)
+}
+
+// NewTransport returns a round tripper which counts requests made to a quote source. Requests which fail or receive an
+// error status are counted as errors.
+func NewTransport(source string, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &transport{
+		source: source,
+		base:   base,
+	}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+
+	if err != nil || resp.StatusCode >= http.StatusBadRequest {
+		SourceRequests.WithLabelValues(t.source, ResultError).Inc()
+	} else {
+		SourceRequests.WithLabelValues(t.source, ResultSuccess).Inc()
+	}
+
+	return resp, err
+}
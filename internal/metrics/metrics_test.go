@@ -0,0 +1,70 @@
+package metrics_test
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/achannarasappa/ticker/v5/internal/metrics"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Metrics", func() {
+
+	Describe("MustRegister", func() {
+		It("should register each counter on the registry", func() {
+			registry := prometheus.NewRegistry()
+
+			metrics.MustRegister(registry)
+			metrics.SourceRequests.WithLabelValues("test-source", metrics.ResultSuccess).Inc()
+			metrics.StreamerReconnects.WithLabelValues("test-source").Inc()
+			metrics.CacheLookups.WithLabelValues(metrics.ResultHit).Inc()
+
+			families, err := registry.Gather()
+			Expect(err).NotTo(HaveOccurred())
+
+			names := make([]string, 0, len(families))
+			for _, family := range families {
+				names = append(names, family.GetName())
+			}
+
+			Expect(names).To(ConsistOf(
+				"ticker_source_requests_total",
+				"ticker_monitor_errors_total",
+				"ticker_streamer_reconnects_total",
+				"ticker_cache_lookups_total",
+			))
+		})
+	})
+
+	Describe("NewTransport", func() {
+		It("should count successful and failed requests for the source", func() {
+			server := ghttp.NewServer()
+			defer server.Close()
+
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusOK, ""),
+				ghttp.RespondWith(http.StatusTooManyRequests, ""),
+			)
+
+			client := &http.Client{Transport: metrics.NewTransport("test-transport", nil)}
+
+			for range 2 {
+				resp, err := client.Get(server.URL()) //nolint:noctx
+				Expect(err).NotTo(HaveOccurred())
+				resp.Body.Close()
+			}
+
+			_, err := client.Get("http://127.0.0.1:0") //nolint:noctx,bodyclose
+			Expect(err).To(HaveOccurred())
+
+			Expect(testutil.ToFloat64(metrics.SourceRequests.WithLabelValues("test-transport", metrics.ResultSuccess))).To(Equal(1.0))
+			Expect(testutil.ToFloat64(metrics.SourceRequests.WithLabelValues("test-transport", metrics.ResultError))).To(Equal(2.0))
+		})
+	})
+
+})
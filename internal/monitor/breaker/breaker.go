@@ -0,0 +1,179 @@
+// Package breaker limits requests to a quote source which is failing by retrying with exponential backoff and pausing
+// requests with a circuit breaker after repeated failures
+package breaker
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+const (
+	defaultBackoffInitial   = 5 * time.Second
+	defaultBackoffMax       = 5 * time.Minute
+	defaultFailureThreshold = 5
+	defaultProbeInterval    = time.Minute
+)
+
+// Breaker tracks failed requests to a quote source and decides when the next request should be made
+type Breaker struct {
+	name             string
+	backoffInitial   time.Duration
+	backoffMax       time.Duration
+	failureThreshold int
+	probeInterval    time.Duration
+	state            c.BreakerState
+	failures         int
+	failingSince     time.Time
+	retryAt          time.Time
+	lastSuccess      time.Time
+	lastError        error
+	mu               sync.RWMutex
+}
+
+// Config represents the configuration for the breaker
+type Config struct {
+	Name             string        // Name of the source shown in the UI and logs
+	BackoffInitial   time.Duration // Delay before the first retry which doubles after each failure, defaults to 5s
+	BackoffMax       time.Duration // Longest delay between retries, defaults to 5m
+	FailureThreshold int           // Consecutive failures after which requests are paused, defaults to 5
+	ProbeInterval    time.Duration // Delay between probe requests while requests are paused, defaults to 1m
+}
+
+// New creates a new breaker
+func New(config Config) *Breaker {
+
+	b := &Breaker{
+		name:             config.Name,
+		backoffInitial:   config.BackoffInitial,
+		backoffMax:       config.BackoffMax,
+		failureThreshold: config.FailureThreshold,
+		probeInterval:    config.ProbeInterval,
+		state:            c.BreakerStateClosed,
+	}
+
+	if b.backoffInitial <= 0 {
+		b.backoffInitial = defaultBackoffInitial
+	}
+
+	if b.backoffMax <= 0 {
+		b.backoffMax = defaultBackoffMax
+	}
+
+	if b.failureThreshold <= 0 {
+		b.failureThreshold = defaultFailureThreshold
+	}
+
+	if b.probeInterval <= 0 {
+		b.probeInterval = defaultProbeInterval
+	}
+
+	return b
+}
+
+// Allow returns whether a request should be made at a time and allows a single probe request once requests have been
+// paused long enough
+func (b *Breaker) Allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case c.BreakerStateHalfOpen:
+		// Only one probe request is made at a time
+		return false
+	case c.BreakerStateOpen:
+		if now.Before(b.retryAt) {
+			return false
+		}
+
+		b.state = c.BreakerStateHalfOpen
+
+		return true
+	default:
+		return !now.Before(b.retryAt)
+	}
+}
+
+// Success records a successful request made at a time which closes the breaker and resets the backoff
+func (b *Breaker) Success(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = c.BreakerStateClosed
+	b.failures = 0
+	b.failingSince = time.Time{}
+	b.retryAt = time.Time{}
+	b.lastSuccess = now
+	b.lastError = nil
+}
+
+// Failure records a failed request made at a time and delays the next request. The delay is never shorter than the
+// one requested by the source in a Retry-After header. The error is returned as is to be reported unless requests are
+// paused in which case it is wrapped with the time until the next probe.
+func (b *Breaker) Failure(err error, now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures == 0 {
+		b.failingSince = now
+	}
+
+	b.failures++
+	b.lastError = err
+
+	delay := b.backoff()
+
+	// Pause requests when there are too many failures in a row or the source has not recovered by the probe
+	if b.state == c.BreakerStateHalfOpen || b.failures >= b.failureThreshold {
+		b.state = c.BreakerStateOpen
+		delay = b.probeInterval
+	}
+
+	if retryAfter, ok := GetRetryAfter(err); ok && retryAfter > delay {
+		delay = retryAfter
+	}
+
+	b.retryAt = now.Add(delay)
+
+	if b.state == c.BreakerStateOpen {
+		return fmt.Errorf("%s requests paused after %d failures, retrying in %s: %w", b.name, b.failures, delay.Round(time.Second), err)
+	}
+
+	return err
+}
+
+// Status returns the state of requests to the source
+func (b *Breaker) Status() c.SourceStatus {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return c.SourceStatus{
+		Name:         b.name,
+		BreakerState: b.state,
+		Failures:     b.failures,
+		FailingSince: b.failingSince,
+		RetryAt:      b.retryAt,
+		LastUpdate:   b.lastSuccess,
+		LastError:    b.lastError,
+	}
+}
+
+// backoff returns the delay before the next retry which doubles with each failure up to the maximum. Half of the delay
+// is random so that requests from multiple instances are spread out rather than retried at the same time.
+func (b *Breaker) backoff() time.Duration {
+
+	delay := b.backoffMax
+
+	if b.failures <= 32 {
+		if d := b.backoffInitial << (b.failures - 1); d > 0 && d < b.backoffMax {
+			delay = d
+		}
+	}
+
+	half := delay / 2
+
+	return half + rand.N(half+1) //nolint:gosec // Jitter does not need to be cryptographically secure
+}
@@ -0,0 +1,170 @@
+package breaker_test
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/monitor/breaker"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func newResponse(statusCode int, retryAfter string) *http.Response {
+
+	resp := &http.Response{StatusCode: statusCode, Header: http.Header{}}
+
+	if retryAfter != "" {
+		resp.Header.Set("Retry-After", retryAfter)
+	}
+
+	return resp
+}
+
+var _ = Describe("Breaker", func() {
+
+	var (
+		b      *breaker.Breaker
+		now    time.Time
+		errAPI error
+	)
+
+	BeforeEach(func() {
+		b = breaker.New(breaker.Config{
+			Name:             "Yahoo",
+			BackoffInitial:   10 * time.Second,
+			BackoffMax:       40 * time.Second,
+			FailureThreshold: 4,
+			ProbeInterval:    2 * time.Minute,
+		})
+		now = time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+		errAPI = errors.New("request failed with status 500")
+	})
+
+	Describe("Allow", func() {
+
+		It("should allow requests when there have been no failures", func() {
+			Expect(b.Allow(now)).To(BeTrue())
+			Expect(b.Allow(now)).To(BeTrue())
+		})
+
+		It("should not allow requests until the backoff after a failure has passed", func() {
+			b.Failure(errAPI, now)
+
+			Expect(b.Allow(now.Add(4 * time.Second))).To(BeFalse())
+			Expect(b.Allow(now.Add(10 * time.Second))).To(BeTrue())
+		})
+	})
+
+	Describe("Failure", func() {
+
+		DescribeTable("should double the backoff after each failure up to the maximum with jitter",
+			func(failures int, minDelay time.Duration, maxDelay time.Duration) {
+				for range failures {
+					b.Failure(errAPI, now)
+				}
+
+				Expect(b.Status().RetryAt).To(BeTemporally(">=", now.Add(minDelay)))
+				Expect(b.Status().RetryAt).To(BeTemporally("<=", now.Add(maxDelay)))
+			},
+			Entry("first failure", 1, 5*time.Second, 10*time.Second),
+			Entry("second failure", 2, 10*time.Second, 20*time.Second),
+			Entry("third failure", 3, 20*time.Second, 40*time.Second),
+		)
+
+		It("should return the error as is while requests are retried", func() {
+			Expect(b.Failure(errAPI, now)).To(Equal(errAPI))
+		})
+
+		When("the response has a Retry-After header", func() {
+			It("should wait for the number of seconds in the header", func() {
+				b.Failure(breaker.NewStatusError(newResponse(http.StatusTooManyRequests, "120"), errAPI), now)
+
+				Expect(b.Status().RetryAt).To(Equal(now.Add(120 * time.Second)))
+			})
+
+			It("should wait until the date in the header", func() {
+				retryAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+				b.Failure(breaker.NewStatusError(newResponse(http.StatusTooManyRequests, retryAt.Format(http.TimeFormat)), errAPI), now)
+
+				Expect(b.Status().RetryAt).To(BeTemporally("~", now.Add(time.Hour), 2*time.Second))
+			})
+
+			It("should not wait less than the backoff", func() {
+				b.Failure(breaker.NewStatusError(newResponse(http.StatusTooManyRequests, "1"), errAPI), now)
+
+				Expect(b.Status().RetryAt).To(BeTemporally(">=", now.Add(5*time.Second)))
+			})
+		})
+
+		When("there are too many failures in a row", func() {
+
+			BeforeEach(func() {
+				for range 3 {
+					b.Failure(errAPI, now)
+				}
+			})
+
+			It("should pause requests until the next probe", func() {
+				err := b.Failure(errAPI, now)
+
+				Expect(err).To(MatchError("Yahoo requests paused after 4 failures, retrying in 2m0s: request failed with status 500"))
+				Expect(errors.Is(err, errAPI)).To(BeTrue())
+				Expect(b.Status().BreakerState).To(Equal(c.BreakerStateOpen))
+				Expect(b.Allow(now.Add(time.Minute))).To(BeFalse())
+			})
+
+			It("should allow a single probe request once the probe interval has passed", func() {
+				b.Failure(errAPI, now)
+
+				Expect(b.Allow(now.Add(2 * time.Minute))).To(BeTrue())
+				Expect(b.Allow(now.Add(2 * time.Minute))).To(BeFalse())
+				Expect(b.Status().BreakerState).To(Equal(c.BreakerStateHalfOpen))
+			})
+
+			It("should pause requests again when the probe request fails", func() {
+				b.Failure(errAPI, now)
+				b.Allow(now.Add(2 * time.Minute))
+				b.Failure(errAPI, now.Add(2*time.Minute))
+
+				Expect(b.Status().BreakerState).To(Equal(c.BreakerStateOpen))
+				Expect(b.Status().RetryAt).To(Equal(now.Add(4 * time.Minute)))
+			})
+		})
+	})
+
+	Describe("Success", func() {
+		It("should reset the state of the breaker", func() {
+			for range 4 {
+				b.Failure(errAPI, now)
+			}
+			b.Allow(now.Add(2 * time.Minute))
+			b.Success(now.Add(2 * time.Minute))
+
+			Expect(b.Status()).To(Equal(c.SourceStatus{
+				Name:         "Yahoo",
+				BreakerState: c.BreakerStateClosed,
+				LastUpdate:   now.Add(2 * time.Minute),
+			}))
+			Expect(b.Allow(now.Add(2 * time.Minute))).To(BeTrue())
+		})
+	})
+
+	Describe("Status", func() {
+		It("should return the number of failures, when they started, and the last error", func() {
+			b.Failure(errAPI, now)
+			b.Failure(errAPI, now.Add(10*time.Second))
+
+			status := b.Status()
+
+			Expect(status.Name).To(Equal("Yahoo"))
+			Expect(status.BreakerState).To(Equal(c.BreakerStateClosed))
+			Expect(status.Failures).To(Equal(2))
+			Expect(status.FailingSince).To(Equal(now))
+			Expect(status.LastError).To(MatchError(errAPI))
+		})
+	})
+})
@@ -0,0 +1,63 @@
+package breaker
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// StatusError is an unexpected response from a quote source along with how long the source asked for requests to be
+// delayed in the Retry-After header
+type StatusError struct {
+	StatusCode int
+	RetryAfter time.Duration // Zero if the response did not include a Retry-After header
+	err        error
+}
+
+// NewStatusError creates an error for an unexpected response which has the same message as the error it wraps
+func NewStatusError(resp *http.Response, err error) error {
+	return &StatusError{
+		StatusCode: resp.StatusCode,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		err:        err,
+	}
+}
+
+func (e *StatusError) Error() string {
+	return e.err.Error()
+}
+
+func (e *StatusError) Unwrap() error {
+	return e.err
+}
+
+// GetRetryAfter returns the delay requested by a source for a failed request or false if there was none
+func GetRetryAfter(err error) (time.Duration, bool) {
+
+	var statusError *StatusError
+
+	if !errors.As(err, &statusError) || statusError.RetryAfter <= 0 {
+		return 0, false
+	}
+
+	return statusError.RetryAfter, true
+}
+
+// parseRetryAfter returns the delay from a Retry-After header which is either a number of seconds or a date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		return max(t.Sub(now), 0)
+	}
+
+	return 0
+}
@@ -39,12 +39,14 @@ type MonitorPriceCoinbase struct {
 	chanStreamUpdateQuoteExtended    chan c.MessageUpdate[c.QuoteExtended]
 	chanStreamUpdateExchange         chan c.MessageUpdate[c.Exchange]
 	chanPollUpdateAssetQuote         chan c.MessageUpdate[c.AssetQuote]
+	chanStreamReconnect              chan struct{}
 	chanError                        chan error
 	mu                               sync.RWMutex
 	muCurrencyRates                  sync.RWMutex
 	ctx                              context.Context
 	cancel                           context.CancelFunc
 	isStarted                        bool
+	versionVector                    int
 	chanUpdateAssetQuote             chan c.MessageUpdate[c.AssetQuote]
 	chanRequestCurrencyRates         chan []string // Channel for currency rate requests
 	cache                            c.Cache
@@ -81,6 +83,7 @@ func NewMonitorPriceCoinbase(config Config, opts ...Option) *MonitorPriceCoinbas
 		chanStreamUpdateQuoteExtended:    make(chan c.MessageUpdate[c.QuoteExtended]),
 		chanStreamUpdateExchange:         make(chan c.MessageUpdate[c.Exchange]),
 		chanPollUpdateAssetQuote:         make(chan c.MessageUpdate[c.AssetQuote]),
+		chanStreamReconnect:              make(chan struct{}, 1),
 		chanError:                        config.ChanError,
 		unaryAPI:                         unaryAPI,
 		ctx:                              ctx,
@@ -100,6 +103,7 @@ func NewMonitorPriceCoinbase(config Config, opts ...Option) *MonitorPriceCoinbas
 	streamerConfig := streamer.StreamerConfig{
 		ChanStreamUpdateQuotePrice:    monitor.chanStreamUpdateQuotePrice,
 		ChanStreamUpdateQuoteExtended: monitor.chanStreamUpdateQuoteExtended,
+		ChanReconnect:                 monitor.chanStreamReconnect,
 	}
 
 	monitor.streamer = streamer.NewStreamer(ctx, streamerConfig)
@@ -185,6 +189,7 @@ func (m *MonitorPriceCoinbase) SetSymbols(productIds []string, versionVector int
 	m.mu.Lock()
 
 	m.productIdsStreaming, m.productIdsPolling = partitionProductIds(m.productIds)
+	m.versionVector = versionVector
 
 	m.mu.Unlock()
 
@@ -249,6 +254,11 @@ func (m *MonitorPriceCoinbase) Stop() error {
 	return nil
 }
 
+// GetStatus returns the state of polling requests for quotes which are paused after repeated failures
+func (m *MonitorPriceCoinbase) GetStatus() c.SourceStatus {
+	return m.poller.GetStatus()
+}
+
 func isStreamingProductId(productId string) bool {
 	return !hasUnderlyingProductId(productId)
 }
@@ -290,6 +300,11 @@ func (m *MonitorPriceCoinbase) handleUpdates() {
 			// TODO: handle extended quote
 			continue
 
+		case <-m.chanStreamReconnect:
+			m.refreshAfterReconnect()
+
+			continue
+
 		case updateMessage := <-m.chanPollUpdateAssetQuote:
 
 			// Check if cache exists and values have changed before acquiring write lock
@@ -397,6 +412,40 @@ func (m *MonitorPriceCoinbase) handleUpdates() {
 	}
 }
 
+// refreshAfterReconnect gets quotes from the unary API to fill in any price changes missed while the streaming
+// connection was down and sends updates for the quotes which changed
+func (m *MonitorPriceCoinbase) refreshAfterReconnect() {
+
+	m.mu.RLock()
+	pricesPrevious := make(map[string]float64, len(m.assetQuotesCacheLookup))
+	for productId, assetQuote := range m.assetQuotesCacheLookup {
+		pricesPrevious[productId] = assetQuote.QuotePrice.Price
+	}
+	versionVector := m.versionVector
+	m.mu.RUnlock()
+
+	assetQuotes, err := m.getAssetQuotesAndReplaceCache()
+	if err != nil {
+		if m.chanError != nil {
+			m.chanError <- err
+		}
+
+		return
+	}
+
+	for _, assetQuote := range assetQuotes {
+		if price, exists := pricesPrevious[assetQuote.Meta.SymbolInSourceAPI]; exists && price == assetQuote.QuotePrice.Price {
+			continue
+		}
+
+		m.chanUpdateAssetQuote <- c.MessageUpdate[c.AssetQuote]{
+			ID:            assetQuote.Symbol,
+			Data:          *assetQuote,
+			VersionVector: versionVector,
+		}
+	}
+}
+
 func (m *MonitorPriceCoinbase) SetCurrencyRates(currencyRates c.CurrencyRates) error {
 	m.muCurrencyRates.Lock()
 	m.currencyRatesCache = currencyRates
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -723,6 +724,59 @@ var _ = Describe("Monitor Coinbase", func() {
 					monitor.Stop()
 				})
 			})
+
+			When("the streaming connection is dropped", func() {
+				It("should get quotes from the unary API after reconnecting and send the changed price quote to the channel", func() {
+					var price atomic.Value
+					price.Store("1285.22")
+
+					server.RouteToHandler("GET", "/api/v3/brokerage/market/products", func(w http.ResponseWriter, r *http.Request) {
+						ghttp.RespondWithJSONEncoded(http.StatusOK, unary.Response{
+							Products: []unary.ResponseQuote{
+								{
+									Symbol:         "ETH",
+									ProductID:      "ETH-USD",
+									ShortName:      "Ethereum",
+									Price:          price.Load().(string),
+									PriceChange24H: "2.5",
+									Volume24H:      "245532.79",
+									DisplayName:    "Ethereum",
+									MarketState:    "online",
+									Currency:       "USD",
+									ExchangeName:   "CBE",
+									ProductType:    "SPOT",
+								},
+							},
+						})(w, r)
+					})
+
+					inputServer := testWs.NewServer([]string{})
+					defer inputServer.Close()
+
+					updateChan := make(chan c.MessageUpdate[c.AssetQuote], 10)
+
+					monitor := monitorPriceCoinbase.NewMonitorPriceCoinbase(monitorPriceCoinbase.Config{
+						UnaryURL:                 server.URL(),
+						ChanUpdateAssetQuote:     updateChan,
+						Ctx:                      context.Background(),
+						ChanRequestCurrencyRates: make(chan []string, 1),
+					}, monitorPriceCoinbase.WithRefreshInterval(10*time.Second),
+						monitorPriceCoinbase.WithStreamingURL("ws://"+inputServer.URL[7:]))
+
+					monitor.SetSymbols([]string{"ETH-USD"}, 0)
+					monitor.Start()
+
+					price.Store("1310.00")
+					inputServer.DropConnections()
+
+					Eventually(updateChan, 5*time.Second).Should(Receive(
+						HaveField("Data.QuotePrice.Price", Equal(1310.00)),
+					))
+					Expect(inputServer.GetConnectionCount()).To(Equal(2))
+
+					monitor.Stop()
+				})
+			})
 		})
 
 		When("there is a polling asset update", func() {
@@ -3,9 +3,11 @@ package poller
 import (
 	"context"
 	"errors"
+	"sync"
 	"time"
 
 	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/monitor/breaker"
 	"github.com/achannarasappa/ticker/v5/internal/monitor/coinbase/unary"
 )
 
@@ -19,6 +21,8 @@ type Poller struct {
 	chanUpdateAssetQuote chan c.MessageUpdate[c.AssetQuote]
 	chanError            chan error
 	versionVector        int
+	mu                   sync.Mutex // Guards symbols and versionVector which are set while polling
+	breaker              *breaker.Breaker
 }
 
 type PollerConfig struct {
@@ -39,10 +43,14 @@ func NewPoller(ctx context.Context, config PollerConfig) *Poller {
 		chanUpdateAssetQuote: config.ChanUpdateAssetQuote,
 		chanError:            config.ChanError,
 		versionVector:        0,
+		breaker:              breaker.New(breaker.Config{Name: "Coinbase"}),
 	}
 }
 
 func (p *Poller) SetSymbols(symbols []string, versionVector int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	p.symbols = symbols
 	p.versionVector = versionVector
 }
@@ -79,18 +87,32 @@ func (p *Poller) Start() error {
 			case <-p.ctx.Done():
 				return
 			case <-ticker.C:
-				if len(p.symbols) == 0 {
+				p.mu.Lock()
+				symbols := p.symbols
+				versionVector := p.versionVector
+				p.mu.Unlock()
+
+				if len(symbols) == 0 {
 
 					continue
 				}
-				versionVector := p.versionVector
-				assetQuotes, _, err := p.unaryAPI.GetAssetQuotes(p.symbols)
+
+				// Wait for the backoff after a failed request or for the next probe if requests are paused
+				now := time.Now()
+				if !p.breaker.Allow(now) {
+
+					continue
+				}
+
+				assetQuotes, _, err := p.unaryAPI.GetAssetQuotes(symbols)
 				if err != nil {
-					p.chanError <- err
+					p.chanError <- p.breaker.Failure(err, now)
 
 					continue
 				}
 
+				p.breaker.Success(now)
+
 				for _, assetQuote := range assetQuotes {
 					p.chanUpdateAssetQuote <- c.MessageUpdate[c.AssetQuote]{
 						ID:            assetQuote.Meta.SymbolInSourceAPI,
@@ -104,3 +126,8 @@ func (p *Poller) Start() error {
 
 	return nil
 }
+
+// GetStatus returns the state of requests to Coinbase
+func (p *Poller) GetStatus() c.SourceStatus {
+	return p.breaker.Status()
+}
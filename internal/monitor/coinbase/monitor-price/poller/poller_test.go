@@ -213,6 +213,42 @@ var _ = Describe("Poller", func() {
 			})
 		})
 
+		When("the unary API is rate limited", func() {
+			It("should not make another request until the time in the Retry-After header", func() {
+
+				server.RouteToHandler("GET", "/api/v3/brokerage/market/products",
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v3/brokerage/market/products", "product_ids=BTC-USD"),
+						ghttp.RespondWith(http.StatusTooManyRequests, "", http.Header{"Retry-After": []string{"60"}}),
+					),
+				)
+
+				outputChanError := make(chan error, 5)
+
+				p := poller.NewPoller(context.Background(), poller.PollerConfig{
+					UnaryAPI:             unary.NewUnaryAPI(server.URL()),
+					ChanUpdateAssetQuote: make(chan c.MessageUpdate[c.AssetQuote], 5),
+					ChanError:            outputChanError,
+				})
+				p.SetSymbols([]string{"BTC-USD"}, 0)
+				p.SetRefreshInterval(time.Millisecond * 20)
+
+				err := p.Start()
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(outputChanError).Should(Receive(
+					MatchError("request failed with status 429"),
+				))
+				Consistently(server.ReceivedRequests).Should(HaveLen(1))
+				Expect(p.GetStatus()).To(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Name":     Equal("Coinbase"),
+					"Failures": Equal(1),
+					"RetryAt":  BeTemporally("~", time.Now().Add(time.Minute), 2*time.Second),
+				}))
+
+			})
+		})
+
 		When("the context is cancelled", func() {
 			It("should stop the polling process", func() {
 				ctx, cancel := context.WithCancel(context.Background())
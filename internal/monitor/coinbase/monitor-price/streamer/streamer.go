@@ -6,11 +6,20 @@ import (
 	"fmt"
 	"strconv"
 	"sync"
+	"time"
 
 	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/metrics"
+	"github.com/achannarasappa/ticker/v5/internal/monitor/breaker"
 	"github.com/gorilla/websocket"
 )
 
+const (
+	defaultHeartbeatInterval       = 30 * time.Second
+	defaultReconnectBackoffInitial = time.Second
+	defaultReconnectBackoffMax     = time.Minute
+)
+
 type messageSubscription struct {
 	Type       string   `json:"type"`
 	ProductIDs []string `json:"product_ids"`
@@ -37,27 +46,39 @@ type messagePriceTick struct {
 	LastSize    string `json:"last_size"`
 }
 
+// Streamer streams prices from the Coinbase websocket API and replaces the connection with backoff whenever it is
+// dropped or stops responding to pings
 type Streamer struct {
 	symbols                       []string
 	conn                          *websocket.Conn
 	isStarted                     bool
 	url                           string
-	subscriptionChan              chan messageSubscription
+	heartbeatInterval             time.Duration
+	backoff                       *breaker.Breaker
+	mu                            sync.Mutex
+	muWrite                       sync.Mutex // Serializes writes since a connection supports one concurrent writer
 	wg                            sync.WaitGroup
 	ctx                           context.Context
 	cancel                        context.CancelFunc
 	chanStreamUpdateQuotePrice    chan c.MessageUpdate[c.QuotePrice]
 	chanStreamUpdateQuoteExtended chan c.MessageUpdate[c.QuoteExtended]
 	chanError                     chan error
+	chanReconnect                 chan struct{}
 	versionVector                 int
 }
 
+// StreamerConfig represents the configuration for the streamer
 type StreamerConfig struct {
 	ChanStreamUpdateQuotePrice    chan c.MessageUpdate[c.QuotePrice]
 	ChanStreamUpdateQuoteExtended chan c.MessageUpdate[c.QuoteExtended]
 	ChanError                     chan error
+	ChanReconnect                 chan struct{} // Signaled after a dropped connection is replaced so quotes missed in between can be refreshed
+	HeartbeatInterval             time.Duration // Interval between pings with the connection replaced if nothing is received for twice as long, defaults to 30s
+	ReconnectBackoffInitial       time.Duration // Delay before the first reconnect attempt which doubles after each failure, defaults to 1s
+	ReconnectBackoffMax           time.Duration // Longest delay between reconnect attempts, defaults to 1m
 }
 
+// NewStreamer creates a new streamer
 func NewStreamer(ctx context.Context, config StreamerConfig) *Streamer {
 	ctx, cancel := context.WithCancel(ctx) //nolint:gosec // cancel stored in struct and called via Stop()
 
@@ -65,17 +86,41 @@ func NewStreamer(ctx context.Context, config StreamerConfig) *Streamer {
 		chanStreamUpdateQuotePrice:    config.ChanStreamUpdateQuotePrice,
 		chanStreamUpdateQuoteExtended: config.ChanStreamUpdateQuoteExtended,
 		chanError:                     config.ChanError,
+		chanReconnect:                 config.ChanReconnect,
+		heartbeatInterval:             config.HeartbeatInterval,
 		ctx:                           ctx,
 		cancel:                        cancel,
 		wg:                            sync.WaitGroup{},
-		subscriptionChan:              make(chan messageSubscription),
 		versionVector:                 0,
 	}
 
+	if s.heartbeatInterval <= 0 {
+		s.heartbeatInterval = defaultHeartbeatInterval
+	}
+
+	if config.ReconnectBackoffInitial <= 0 {
+		config.ReconnectBackoffInitial = defaultReconnectBackoffInitial
+	}
+
+	if config.ReconnectBackoffMax <= 0 {
+		config.ReconnectBackoffMax = defaultReconnectBackoffMax
+	}
+
+	s.backoff = breaker.New(breaker.Config{
+		Name:           "Coinbase streaming",
+		BackoffInitial: config.ReconnectBackoffInitial,
+		BackoffMax:     config.ReconnectBackoffMax,
+		ProbeInterval:  config.ReconnectBackoffMax,
+	})
+
 	return s
 }
 
+// Start connects to the websocket API and keeps the connection open until the context is cancelled
 func (s *Streamer) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	if s.isStarted {
 		return errors.New("streamer already started")
 	}
@@ -85,80 +130,66 @@ func (s *Streamer) Start() error {
 		return nil
 	}
 
-	// Create connection channel for result
-	connChan := make(chan *websocket.Conn, 1)
-	errChan := make(chan error, 1)
-
-	// Connect the websocket address in a goroutine
-	go func() {
-		url := s.url
-		conn, _, err := websocket.DefaultDialer.DialContext(s.ctx, url, nil)
-		if err != nil {
-			errChan <- err
-
-			return
-		}
-		connChan <- conn
-	}()
-
-	// Wait for either connection, error, or context cancellation
-	select {
-	case conn := <-connChan:
-		s.conn = conn
-	case err := <-errChan:
-
+	conn, err := s.connect()
+	if err != nil {
 		return err
-	case <-s.ctx.Done():
-
-		return fmt.Errorf("connection aborted: %w", s.ctx.Err())
 	}
 
+	s.conn = conn
+
 	// Disconnect on stop signal
 	go func() {
 		<-s.ctx.Done()
-		s.wg.Wait()
+		s.mu.Lock()
 		s.conn.Close()
 		s.isStarted = false
 		s.symbols = []string{}
+		s.mu.Unlock()
+		s.wg.Wait()
 	}()
 
 	s.isStarted = true
 
-	s.wg.Add(2)
-	go s.readStreamQuote()
-	go s.writeStreamSubscription()
+	s.subscribe(conn, s.symbols)
+
+	s.wg.Add(1)
+	go s.run(conn)
 
 	return nil
 }
 
+// SetSymbolsAndUpdateSubscriptions sets the product ids to stream and subscribes to them if the streamer is started
 func (s *Streamer) SetSymbolsAndUpdateSubscriptions(symbols []string, versionVector int) error {
+	s.mu.Lock()
 
-	var err error
+	s.symbols = symbols
+	s.versionVector = versionVector
 
 	if !s.isStarted {
+		s.mu.Unlock()
 
 		return nil
 	}
 
-	s.symbols = symbols
-	s.versionVector = versionVector
+	conn := s.conn
+
+	s.mu.Unlock()
 
 	// TODO: fix symbol change
-	// err = s.unsubscribe()
+	// err = s.unsubscribe(conn)
 	// if err != nil {
 	// 	return err
 	// }
 
-	err = s.subscribe(s.symbols)
-	if err != nil {
-
-		return err
-	}
+	s.subscribe(conn, symbols)
 
 	return nil
 }
 
+// SetURL sets the websocket URL
 func (s *Streamer) SetURL(url string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
 	if s.isStarted {
 
@@ -170,56 +201,200 @@ func (s *Streamer) SetURL(url string) error {
 	return nil
 }
 
-func (s *Streamer) readStreamQuote() {
+// connect opens a connection and starts sending pings on it
+func (s *Streamer) connect() (*websocket.Conn, error) {
+
+	// Create connection channel for result
+	connChan := make(chan *websocket.Conn, 1)
+	errChan := make(chan error, 1)
+
+	// Connect the websocket address in a goroutine
+	go func() {
+		url := s.url
+		conn, _, err := websocket.DefaultDialer.DialContext(s.ctx, url, nil)
+		if err != nil {
+			errChan <- err
+
+			return
+		}
+		connChan <- conn
+	}()
+
+	var conn *websocket.Conn
+
+	// Wait for either connection, error, or context cancellation
+	select {
+	case conn = <-connChan:
+	case err := <-errChan:
+
+		return nil, err
+	case <-s.ctx.Done():
+
+		return nil, fmt.Errorf("connection aborted: %w", s.ctx.Err())
+	}
+
+	// A connection which has not received a message or pong for two heartbeats is assumed to be half-open
+	conn.SetReadDeadline(time.Now().Add(2 * s.heartbeatInterval)) //nolint:errcheck
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * s.heartbeatInterval))
+	})
+
+	s.wg.Add(1)
+	go s.sendHeartbeats(conn)
+
+	return conn, nil
+}
+
+// run reads messages from the connection and replaces it whenever it is dropped until the streamer is stopped
+func (s *Streamer) run(conn *websocket.Conn) {
 	defer s.wg.Done()
 
 	for {
-		select {
-		case <-s.ctx.Done():
+		err := s.readStreamQuote(conn)
+		conn.Close()
+
+		if s.ctx.Err() != nil {
 			return
+		}
+
+		conn = s.reconnect(err)
+		if conn == nil {
+			return
+		}
+
+		metrics.StreamerReconnects.WithLabelValues("coinbase").Inc()
+
+		// Signal without blocking since a single refresh covers any number of reconnects
+		select {
+		case s.chanReconnect <- struct{}{}:
 		default:
-			var message messagePriceTick
-			err := s.conn.ReadJSON(&message)
-			if err != nil {
-				s.chanError <- err
+		}
+	}
+}
 
-				return
-			}
+// reconnect opens a new connection with backoff between attempts and resubscribes to the current product ids. nil
+// is returned if the streamer is stopped before a connection is opened.
+func (s *Streamer) reconnect(err error) *websocket.Conn {
 
-			// Only handle ticker messages; first message is a subscription confirmation
-			if message.Type != "ticker" {
+	for {
+		s.sendError(s.backoff.Failure(fmt.Errorf("streaming connection lost: %w", err), time.Now()))
 
-				continue
-			}
+		select {
+		case <-s.ctx.Done():
+			return nil
+		case <-time.After(time.Until(s.backoff.Status().RetryAt)):
+		}
+
+		s.backoff.Allow(time.Now())
+
+		var conn *websocket.Conn
+
+		conn, err = s.connect()
+		if err != nil {
+			continue
+		}
 
-			qp, qe := transformPriceTick(message, s.versionVector)
-			s.chanStreamUpdateQuotePrice <- qp
-			s.chanStreamUpdateQuoteExtended <- qe
+		s.backoff.Success(time.Now())
+
+		s.mu.Lock()
+
+		// Stop may have closed the previous connection while the new one was being opened
+		if s.ctx.Err() != nil {
+			s.mu.Unlock()
+			conn.Close()
+
+			return nil
 		}
+
+		s.conn = conn
+		symbols := s.symbols
+
+		s.mu.Unlock()
+
+		s.subscribe(conn, symbols)
+
+		return conn
 	}
 }
 
-func (s *Streamer) writeStreamSubscription() {
+// sendHeartbeats pings the server until the connection is closed so that a half-open connection is detected by the
+// missing pongs
+func (s *Streamer) sendHeartbeats(conn *websocket.Conn) {
 	defer s.wg.Done()
 
+	ticker := time.NewTicker(s.heartbeatInterval)
+	defer ticker.Stop()
+
 	for {
 		select {
 		case <-s.ctx.Done():
-
 			return
-		case message := <-s.subscriptionChan:
-
-			err := s.conn.WriteJSON(message)
-			if err != nil {
-				s.chanError <- err
-
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(s.heartbeatInterval)); err != nil {
 				return
 			}
 		}
 	}
 }
 
-func (s *Streamer) subscribe(productIDs []string) error {
+func (s *Streamer) readStreamQuote(conn *websocket.Conn) error {
+
+	for {
+		var message messagePriceTick
+		err := conn.ReadJSON(&message)
+		if err != nil {
+			return err
+		}
+
+		// Any message shows the connection is still open
+		conn.SetReadDeadline(time.Now().Add(2 * s.heartbeatInterval)) //nolint:errcheck
+
+		// Only handle ticker messages; first message is a subscription confirmation
+		if message.Type != "ticker" {
+
+			continue
+		}
+
+		s.mu.Lock()
+		versionVector := s.versionVector
+		s.mu.Unlock()
+
+		qp, qe := transformPriceTick(message, versionVector)
+
+		select {
+		case s.chanStreamUpdateQuotePrice <- qp:
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		}
+
+		select {
+		case s.chanStreamUpdateQuoteExtended <- qe:
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		}
+	}
+}
+
+// sendError reports an error without blocking once the streamer is stopped
+func (s *Streamer) sendError(err error) {
+
+	if s.chanError == nil {
+		return
+	}
+
+	select {
+	case s.chanError <- err:
+	case <-s.ctx.Done():
+	}
+}
+
+// subscribe sends a subscription for product ids. A failed write closes the connection so that it is replaced and
+// the subscription is sent again on the new connection.
+func (s *Streamer) subscribe(conn *websocket.Conn, productIDs []string) {
+
+	if len(productIDs) == 0 {
+		return
+	}
 
 	message := messageSubscription{
 		Type:       "subscribe",
@@ -227,21 +402,25 @@ func (s *Streamer) subscribe(productIDs []string) error {
 		Channels:   []string{"ticker"},
 	}
 
-	s.subscriptionChan <- message
+	s.muWrite.Lock()
+	defer s.muWrite.Unlock()
 
-	return nil
+	if err := conn.WriteJSON(message); err != nil {
+		conn.Close()
+	}
 }
 
-func (s *Streamer) unsubscribe() error { //nolint:unused
+func (s *Streamer) unsubscribe(conn *websocket.Conn) error { //nolint:unused
 
 	message := messageSubscription{
 		Type:     "unsubscribe",
 		Channels: []string{"ticker"},
 	}
 
-	s.subscriptionChan <- message
+	s.muWrite.Lock()
+	defer s.muWrite.Unlock()
 
-	return nil
+	return conn.WriteJSON(message)
 }
 
 func transformPriceTick(message messagePriceTick, versionVector int) (qp c.MessageUpdate[c.QuotePrice], qe c.MessageUpdate[c.QuoteExtended]) {
@@ -173,6 +173,86 @@ var _ = Describe("Streamer", func() {
 		})
 	})
 
+	Describe("reconnect", func() {
+		var (
+			server        *testWs.Server
+			ctx           context.Context
+			cancel        context.CancelFunc
+			chanError     chan error
+			chanReconnect chan struct{}
+		)
+
+		BeforeEach(func() {
+			server = testWs.NewServer([]string{})
+			ctx, cancel = context.WithCancel(context.Background())
+			chanError = make(chan error, 5)
+			chanReconnect = make(chan struct{}, 1)
+			s = streamer.NewStreamer(ctx, streamer.StreamerConfig{
+				ChanStreamUpdateQuotePrice:    make(chan c.MessageUpdate[c.QuotePrice], 5),
+				ChanStreamUpdateQuoteExtended: make(chan c.MessageUpdate[c.QuoteExtended], 5),
+				ChanError:                     chanError,
+				ChanReconnect:                 chanReconnect,
+				HeartbeatInterval:             50 * time.Millisecond,
+				ReconnectBackoffInitial:       10 * time.Millisecond,
+			})
+			s.SetURL("ws://" + server.URL[7:])
+			s.SetSymbolsAndUpdateSubscriptions([]string{"BTC-USD"}, 0)
+
+			err := s.Start()
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(server.GetReceivedMessages).Should(HaveLen(1))
+		})
+
+		AfterEach(func() {
+			cancel()
+			server.Close()
+		})
+
+		When("the connection is dropped", func() {
+			It("should open a new connection, resubscribe to the product ids, and signal the reconnect", func() {
+				server.DropConnections()
+
+				Eventually(chanReconnect).Should(Receive())
+				Expect(server.GetConnectionCount()).To(Equal(2))
+				Eventually(server.GetReceivedMessages).Should(HaveExactElements(
+					ContainSubstring(`"product_ids":["BTC-USD"]`),
+					ContainSubstring(`"product_ids":["BTC-USD"]`),
+				))
+				Expect(chanError).To(Receive(MatchError(ContainSubstring("streaming connection lost"))))
+			})
+
+			It("should retry with backoff until the server is available", func() {
+				server.Close()
+
+				Eventually(chanError).Should(Receive())
+				Eventually(chanError).Should(Receive(MatchError(ContainSubstring("connection refused"))))
+				Consistently(chanReconnect, 100*time.Millisecond).ShouldNot(Receive())
+			})
+		})
+
+		When("the server stops responding to pings", func() {
+			It("should replace the half-open connection", func() {
+				server.SetUnresponsive(true)
+
+				Eventually(server.GetConnectionCount).Should(BeNumerically(">=", 2))
+				Eventually(chanReconnect).Should(Receive())
+			})
+
+			It("should keep the connection open while the server responds to pings", func() {
+				Consistently(server.GetConnectionCount, 300*time.Millisecond).Should(Equal(1))
+			})
+		})
+
+		When("the streamer is stopped", func() {
+			It("should not reconnect", func() {
+				cancel()
+
+				Consistently(server.GetConnectionCount, 200*time.Millisecond).Should(Equal(1))
+				Expect(chanReconnect).NotTo(Receive())
+			})
+		})
+	})
+
 	Describe("SetSymbolsAndUpdateSubscriptions", func() {
 		BeforeEach(func() {
 			inputServer = testWs.NewTestServer([]string{})
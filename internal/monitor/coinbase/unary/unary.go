@@ -10,12 +10,36 @@ import (
 	"time"
 
 	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/metrics"
+	"github.com/achannarasappa/ticker/v5/internal/monitor/breaker"
 )
 
 const (
 	productTypeFuture = "FUTURE"
+	// searchResultsLimit is the maximum number of products returned by a search
+	searchResultsLimit = 10
+	// candlesLimit is the maximum number of candles requested at once which is below the limit of the API
+	candlesLimit = 300
 )
 
+// candleParams are the interval of each candle and the length of time covered for a price history range. Since crypto
+// markets do not have sessions, the 1D range covers the last 24 hours.
+type candleParams struct {
+	Granularity string
+	Interval    time.Duration
+	Period      time.Duration
+}
+
+//nolint:gochecknoglobals
+var candleParamsByRange = map[c.PriceHistoryRange]candleParams{
+	c.PriceHistoryRange1D: {Granularity: "FIVE_MINUTE", Interval: 5 * time.Minute, Period: 24 * time.Hour},
+	c.PriceHistoryRange5D: {Granularity: "THIRTY_MINUTE", Interval: 30 * time.Minute, Period: 5 * 24 * time.Hour},
+	c.PriceHistoryRange1M: {Granularity: "TWO_HOUR", Interval: 2 * time.Hour, Period: 30 * 24 * time.Hour},
+	c.PriceHistoryRange6M: {Granularity: "ONE_DAY", Interval: 24 * time.Hour, Period: 182 * 24 * time.Hour},
+	c.PriceHistoryRange1Y: {Granularity: "ONE_DAY", Interval: 24 * time.Hour, Period: 365 * 24 * time.Hour},
+	c.PriceHistoryRange5Y: {Granularity: "ONE_DAY", Interval: 24 * time.Hour, Period: 5 * 365 * 24 * time.Hour},
+}
+
 // Response represents the container object from the API response
 type Response struct {
 	Products []ResponseQuote `json:"products"`
@@ -54,6 +78,21 @@ type ResponseQuote struct {
 	ProductType              string                                `json:"product_type"`
 }
 
+// ResponseCandles represents the container object from the product candles API response
+type ResponseCandles struct {
+	Candles []ResponseCandle `json:"candles"`
+}
+
+// ResponseCandle represents the prices of a product over a single interval
+type ResponseCandle struct {
+	Start  string `json:"start"`
+	Low    string `json:"low"`
+	High   string `json:"high"`
+	Open   string `json:"open"`
+	Close  string `json:"close"`
+	Volume string `json:"volume"`
+}
+
 type AssetQuotesIndexed struct {
 	AssetQuotes            []c.AssetQuote
 	AssetQuotesByProductId map[string]*c.AssetQuote
@@ -66,7 +105,7 @@ type UnaryAPI struct {
 
 func NewUnaryAPI(baseURL string) *UnaryAPI {
 	return &UnaryAPI{
-		client:  &http.Client{},
+		client:  &http.Client{Transport: metrics.NewTransport("coinbase", nil)},
 		baseURL: baseURL,
 	}
 }
@@ -186,7 +225,7 @@ func (u *UnaryAPI) GetAssetQuotes(symbols []string) ([]c.AssetQuote, map[string]
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, nil, fmt.Errorf("request failed with status %d", resp.StatusCode)
+		return nil, nil, breaker.NewStatusError(resp, fmt.Errorf("request failed with status %d", resp.StatusCode))
 	}
 
 	// Decode response
@@ -199,3 +238,165 @@ func (u *UnaryAPI) GetAssetQuotes(symbols []string) ([]c.AssetQuote, map[string]
 
 	return quotes, quotesByProductId, nil
 }
+
+// SearchSymbols retrieves the list of products and returns those with a symbol, name, or product id matching the query.
+// Only spot products quoted in USD and futures are included since they are the products which can be set as .CB symbols.
+func (u *UnaryAPI) SearchSymbols(query string) ([]c.SymbolSearchResult, error) {
+	query = strings.ToUpper(strings.TrimSpace(query))
+
+	if query == "" {
+		return []c.SymbolSearchResult{}, nil
+	}
+
+	resp, err := u.client.Get(u.baseURL + "/api/v3/brokerage/market/products")
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request failed with status %d", resp.StatusCode)
+	}
+
+	var result Response
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	// Exact symbol matches are listed before partial matches of the symbol or name
+	exactMatches := make([]c.SymbolSearchResult, 0)
+	partialMatches := make([]c.SymbolSearchResult, 0)
+
+	for _, product := range result.Products {
+		searchResult, ok := transformResponseSearch(product)
+
+		if !ok {
+			continue
+		}
+
+		if strings.ToUpper(product.Symbol) == query || strings.ToUpper(product.ProductID) == query {
+			exactMatches = append(exactMatches, searchResult)
+
+			continue
+		}
+
+		if strings.Contains(strings.ToUpper(product.Symbol), query) || strings.Contains(strings.ToUpper(searchResult.Name), query) {
+			partialMatches = append(partialMatches, searchResult)
+		}
+	}
+
+	results := append(exactMatches, partialMatches...)
+
+	if len(results) > searchResultsLimit {
+		results = results[:searchResultsLimit]
+	}
+
+	return results, nil
+}
+
+func transformResponseSearch(product ResponseQuote) (c.SymbolSearchResult, bool) {
+
+	if product.ProductType == productTypeFuture {
+		return c.SymbolSearchResult{
+			Symbol:            product.ProductID + ".CB",
+			SymbolInSourceAPI: product.ProductID,
+			Name:              product.FutureProductDetails.GroupDescription,
+			Exchange:          product.ExchangeName,
+			Type:              "Futures",
+			Source:            c.QuoteSourceCoinbase,
+		}, strings.HasSuffix(product.ProductID, "-CDE")
+	}
+
+	return c.SymbolSearchResult{
+		Symbol:            product.Symbol + ".CB",
+		SymbolInSourceAPI: product.ProductID,
+		Name:              product.ShortName,
+		Exchange:          product.ExchangeName,
+		Type:              "Cryptocurrency",
+		Source:            c.QuoteSourceCoinbase,
+	}, strings.ToUpper(product.Currency) == "USD" && product.ProductID == product.Symbol+"-USD"
+}
+
+// GetPriceHistory retrieves the closing price and volume of each candle over a range for a product. Ranges with more
+// candles than can be returned at once are retrieved with multiple requests.
+func (u *UnaryAPI) GetPriceHistory(productID string, historyRange c.PriceHistoryRange) ([]c.PricePoint, error) {
+	params, ok := candleParamsByRange[historyRange]
+	if !ok {
+		return nil, fmt.Errorf("unsupported price history range: %d", historyRange)
+	}
+
+	end := time.Now()
+	points := make([]c.PricePoint, 0)
+
+	for start := end.Add(-params.Period); start.Before(end); start = start.Add(params.Interval * candlesLimit) {
+		requestEnd := start.Add(params.Interval * candlesLimit)
+		if requestEnd.After(end) {
+			requestEnd = end
+		}
+
+		candles, err := u.getCandles(productID, params.Granularity, start, requestEnd)
+		if err != nil {
+			return nil, err
+		}
+
+		// Candles at the boundary between requests may be returned by both requests
+		for _, point := range transformResponseCandles(candles) {
+			if len(points) > 0 && point.Time <= points[len(points)-1].Time {
+				continue
+			}
+
+			points = append(points, point)
+		}
+	}
+
+	return points, nil
+}
+
+func (u *UnaryAPI) getCandles(productID string, granularity string, start time.Time, end time.Time) ([]ResponseCandle, error) {
+	reqURL, _ := url.Parse(u.baseURL + "/api/v3/brokerage/market/products/" + url.PathEscape(productID) + "/candles")
+	q := reqURL.Query()
+	q.Set("start", strconv.FormatInt(start.Unix(), 10))
+	q.Set("end", strconv.FormatInt(end.Unix(), 10))
+	q.Set("granularity", granularity)
+	reqURL.RawQuery = q.Encode()
+
+	resp, err := u.client.Get(reqURL.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request failed with status %d", resp.StatusCode)
+	}
+
+	var result ResponseCandles
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result.Candles, nil
+}
+
+// transformResponseCandles converts candles, which are returned with the most recent first, into prices in chronological order
+func transformResponseCandles(candles []ResponseCandle) []c.PricePoint {
+	points := make([]c.PricePoint, 0, len(candles))
+
+	for i := len(candles) - 1; i >= 0; i-- {
+		startTime, errStart := strconv.ParseInt(candles[i].Start, 10, 64)
+		price, errPrice := strconv.ParseFloat(candles[i].Close, 64)
+		volume, _ := strconv.ParseFloat(candles[i].Volume, 64)
+
+		if errStart != nil || errPrice != nil {
+			continue
+		}
+
+		points = append(points, c.PricePoint{
+			Time:   startTime,
+			Price:  price,
+			Volume: volume,
+		})
+	}
+
+	return points
+}
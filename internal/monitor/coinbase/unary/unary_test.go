@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"time"
 
+	c "github.com/achannarasappa/ticker/v5/internal/common"
 	"github.com/achannarasappa/ticker/v5/internal/monitor/coinbase/unary"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -301,4 +302,128 @@ var _ = Describe("Unary", func() {
 			})
 		})
 	})
+
+	Describe("SearchSymbols", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v3/brokerage/market/products"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, unary.Response{
+						Products: []unary.ResponseQuote{
+							{Symbol: "WBTC", ProductID: "WBTC-USD", ShortName: "Wrapped Bitcoin", Currency: "USD", ExchangeName: "CBE"},
+							{Symbol: "BTC", ProductID: "BTC-EUR", ShortName: "Bitcoin", Currency: "EUR", ExchangeName: "CBE"},
+							{Symbol: "BTC", ProductID: "BTC-USD", ShortName: "Bitcoin", Currency: "USD", ExchangeName: "CBE"},
+							{
+								ProductID:    "BIT-31JAN25-CDE",
+								ProductType:  "FUTURE",
+								ExchangeName: "FCM",
+								FutureProductDetails: unary.ResponseQuoteFutureProductDetails{
+									GroupDescription: "Nano Bitcoin Futures",
+								},
+							},
+							{Symbol: "ETH", ProductID: "ETH-USD", ShortName: "Ethereum", Currency: "USD", ExchangeName: "CBE"},
+						},
+					}),
+				),
+			)
+		})
+
+		It("should return USD spot products and futures matching the query with exact symbol matches first", func() {
+			api := unary.NewUnaryAPI(server.URL())
+			results, err := api.SearchSymbols("btc")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(HaveLen(2))
+			Expect(results[0].Symbol).To(Equal("BTC.CB"))
+			Expect(results[0].SymbolInSourceAPI).To(Equal("BTC-USD"))
+			Expect(results[0].Name).To(Equal("Bitcoin"))
+			Expect(results[1].Symbol).To(Equal("WBTC.CB"))
+		})
+
+		It("should match futures by name", func() {
+			api := unary.NewUnaryAPI(server.URL())
+			results, err := api.SearchSymbols("nano")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Symbol).To(Equal("BIT-31JAN25-CDE.CB"))
+			Expect(results[0].SymbolInSourceAPI).To(Equal("BIT-31JAN25-CDE"))
+			Expect(results[0].Type).To(Equal("Futures"))
+		})
+	})
+
+	Describe("GetPriceHistory", func() {
+		It("should return the closing price and volume of each candle in chronological order", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v3/brokerage/market/products/BTC-USD/candles"),
+					ghttp.VerifyFormKV("granularity", "FIVE_MINUTE"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, unary.ResponseCandles{
+						Candles: []unary.ResponseCandle{
+							{Start: "1700000600", Close: "50200.50", Volume: "12.5"},
+							{Start: "1700000300", Close: "50100.00", Volume: "8"},
+							{Start: "1700000000", Close: "50000.00", Volume: "10"},
+						},
+					}),
+				),
+			)
+
+			api := unary.NewUnaryAPI(server.URL())
+			points, err := api.GetPriceHistory("BTC-USD", c.PriceHistoryRange1D)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(points).To(Equal([]c.PricePoint{
+				{Time: 1700000000, Price: 50000.00, Volume: 10},
+				{Time: 1700000300, Price: 50100.00, Volume: 8},
+				{Time: 1700000600, Price: 50200.50, Volume: 12.5},
+			}))
+		})
+
+		When("the range has more candles than can be returned by one request", func() {
+			It("should combine the candles from each request without duplicates", func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyFormKV("granularity", "ONE_DAY"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, unary.ResponseCandles{
+							Candles: []unary.ResponseCandle{
+								{Start: "1700086400", Close: "51000"},
+								{Start: "1700000000", Close: "50000"},
+							},
+						}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyFormKV("granularity", "ONE_DAY"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, unary.ResponseCandles{
+							Candles: []unary.ResponseCandle{
+								{Start: "1700172800", Close: "52000"},
+								{Start: "1700086400", Close: "51000"},
+							},
+						}),
+					),
+				)
+
+				api := unary.NewUnaryAPI(server.URL())
+				points, err := api.GetPriceHistory("BTC-USD", c.PriceHistoryRange1Y)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(server.ReceivedRequests()).To(HaveLen(2))
+				Expect(points).To(Equal([]c.PricePoint{
+					{Time: 1700000000, Price: 50000},
+					{Time: 1700086400, Price: 51000},
+					{Time: 1700172800, Price: 52000},
+				}))
+			})
+		})
+
+		When("the request fails", func() {
+			It("should return an error", func() {
+				server.AppendHandlers(ghttp.RespondWith(http.StatusNotFound, ""))
+
+				api := unary.NewUnaryAPI(server.URL())
+				_, err := api.GetPriceHistory("BTC-USD", c.PriceHistoryRange1D)
+
+				Expect(err).To(MatchError("request failed with status 404"))
+			})
+		})
+	})
 })
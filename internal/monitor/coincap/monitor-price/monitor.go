@@ -0,0 +1,405 @@
+package monitorPriceCoinCap
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	poller "github.com/achannarasappa/ticker/v5/internal/monitor/coincap/monitor-price/poller"
+	streamer "github.com/achannarasappa/ticker/v5/internal/monitor/coincap/monitor-price/streamer"
+	unary "github.com/achannarasappa/ticker/v5/internal/monitor/coincap/unary"
+)
+
+const (
+	fromCurrencyCode = "USD"
+)
+
+// MonitorPriceCoinCap represents a CoinCap monitor
+type MonitorPriceCoinCap struct {
+	unaryAPI                   *unary.UnaryAPI
+	streamer                   *streamer.Streamer
+	poller                     *poller.Poller
+	input                      input
+	ids                        []string                 // CoinCap refers to assets by an id (e.g. bitcoin) which ticker accepts with a .CC suffix
+	assetQuotesCache           []*c.AssetQuote          // Asset quotes for all assets retrieved at start or on symbol change
+	assetQuotesCacheLookup     map[string]*c.AssetQuote // Asset quotes for all assets retrieved at least once (symbol change does not remove symbols)
+	currencyRatesCache         c.CurrencyRates          // Cache of currency rates
+	currencyHasRequestedRates  bool                     // Whether the currency rates have been requested; all quotes are in a single currency (USD)
+	isStreaming                bool                     // Whether a streaming URL is set and prices are updated in real-time
+	chanStreamUpdateQuotePrice chan c.MessageUpdate[c.QuotePrice]
+	chanPollUpdateAssetQuote   chan c.MessageUpdate[c.AssetQuote]
+	chanError                  chan error
+	mu                         sync.RWMutex
+	muCurrencyRates            sync.RWMutex
+	ctx                        context.Context
+	cancel                     context.CancelFunc
+	isStarted                  bool
+	chanUpdateAssetQuote       chan c.MessageUpdate[c.AssetQuote]
+	chanRequestCurrencyRates   chan []string
+}
+
+// input represents user input for the CoinCap monitor with any transformation
+type input struct {
+	ids       []string
+	idsLookup map[string]bool
+}
+
+// Config contains the required configuration for the CoinCap monitor
+type Config struct {
+	Ctx                      context.Context
+	UnaryURL                 string
+	APIKey                   string
+	ChanError                chan error
+	ChanUpdateAssetQuote     chan c.MessageUpdate[c.AssetQuote]
+	ChanRequestCurrencyRates chan []string
+}
+
+// Option defines an option for configuring the monitor
+type Option func(*MonitorPriceCoinCap)
+
+// NewMonitorPriceCoinCap creates a new CoinCap monitor
+func NewMonitorPriceCoinCap(config Config, opts ...Option) *MonitorPriceCoinCap {
+	ctx, cancel := context.WithCancel(config.Ctx)
+
+	unaryAPI := unary.NewUnaryAPI(config.UnaryURL, config.APIKey)
+
+	monitor := &MonitorPriceCoinCap{
+		assetQuotesCacheLookup:     make(map[string]*c.AssetQuote),
+		assetQuotesCache:           make([]*c.AssetQuote, 0),
+		chanStreamUpdateQuotePrice: make(chan c.MessageUpdate[c.QuotePrice]),
+		chanPollUpdateAssetQuote:   make(chan c.MessageUpdate[c.AssetQuote]),
+		chanError:                  config.ChanError,
+		unaryAPI:                   unaryAPI,
+		ctx:                        ctx,
+		cancel:                     cancel,
+		chanUpdateAssetQuote:       config.ChanUpdateAssetQuote,
+		chanRequestCurrencyRates:   config.ChanRequestCurrencyRates,
+	}
+
+	pollerConfig := poller.PollerConfig{
+		ChanUpdateAssetQuote: monitor.chanPollUpdateAssetQuote,
+		ChanError:            monitor.chanError,
+		UnaryAPI:             unaryAPI,
+	}
+	monitor.poller = poller.NewPoller(ctx, pollerConfig)
+
+	streamerConfig := streamer.StreamerConfig{
+		ChanStreamUpdateQuotePrice: monitor.chanStreamUpdateQuotePrice,
+		ChanError:                  monitor.chanError,
+		APIKey:                     config.APIKey,
+	}
+	monitor.streamer = streamer.NewStreamer(ctx, streamerConfig)
+
+	for _, opt := range opts {
+		opt(monitor)
+	}
+
+	return monitor
+}
+
+// WithStreamingURL sets the streaming URL for the monitor
+func WithStreamingURL(url string) Option {
+	return func(m *MonitorPriceCoinCap) {
+		if err := m.streamer.SetURL(url); err != nil && m.chanError != nil {
+			m.chanError <- err
+		}
+
+		m.isStreaming = url != ""
+	}
+}
+
+// WithRefreshInterval sets the refresh interval for the monitor
+func WithRefreshInterval(interval time.Duration) Option {
+	return func(m *MonitorPriceCoinCap) {
+		if err := m.poller.SetRefreshInterval(interval); err != nil && m.chanError != nil {
+			m.chanError <- err
+		}
+	}
+}
+
+// GetAssetQuotes returns the asset quotes either from the cache or from the unary API if ignoreCache is set
+func (m *MonitorPriceCoinCap) GetAssetQuotes(ignoreCache ...bool) ([]c.AssetQuote, error) {
+
+	if len(ignoreCache) > 0 && ignoreCache[0] {
+		assetQuotes, err := m.getAssetQuotesAndReplaceCache()
+		if err != nil {
+			return []c.AssetQuote{}, err
+		}
+
+		result := make([]c.AssetQuote, len(assetQuotes))
+		for i, quote := range assetQuotes {
+			result[i] = *quote
+		}
+
+		return result, nil
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]c.AssetQuote, len(m.assetQuotesCache))
+	for i, quote := range m.assetQuotesCache {
+		result[i] = *quote
+	}
+
+	return result, nil
+}
+
+// SetSymbols sets the CoinCap asset ids to monitor
+func (m *MonitorPriceCoinCap) SetSymbols(ids []string, versionVector int) error {
+
+	var err error
+
+	m.mu.Lock()
+
+	// CoinCap ids are always lowercase while ticker symbols are case insensitive
+	idsNormalized := make([]string, 0, len(ids))
+	for _, id := range ids {
+		idsNormalized = append(idsNormalized, strings.ToLower(id))
+	}
+
+	// Deduplicate ids since input may have duplicates
+	slices.Sort(idsNormalized)
+	m.ids = slices.Compact(idsNormalized)
+	m.input.ids = idsNormalized
+	m.input.idsLookup = make(map[string]bool)
+	for _, id := range idsNormalized {
+		m.input.idsLookup[id] = true
+	}
+
+	// All quotes are denominated in USD so the currency rate only needs to be requested once
+	if !m.currencyHasRequestedRates {
+		m.chanRequestCurrencyRates <- []string{fromCurrencyCode}
+		m.currencyHasRequestedRates = true
+	}
+
+	m.mu.Unlock()
+
+	// Since the symbols have changed, make a synchronous call to get price quotes for the new symbols
+	_, err = m.getAssetQuotesAndReplaceCache()
+	if err != nil {
+		return err
+	}
+
+	err = m.streamer.SetSymbolsAndUpdateSubscriptions(m.ids, versionVector)
+	if err != nil {
+		return err
+	}
+
+	// The poller refreshes fields not available from the streaming API such as volume and market cap
+	m.poller.SetSymbols(m.ids, versionVector)
+
+	return nil
+}
+
+// Start the monitor
+func (m *MonitorPriceCoinCap) Start() error {
+	var err error
+
+	if m.isStarted {
+		return errors.New("monitor already started")
+	}
+
+	// On start, get initial quotes from unary API
+	_, err = m.getAssetQuotesAndReplaceCache()
+	if err != nil {
+		return err
+	}
+
+	err = m.streamer.Start()
+	if err != nil {
+		return err
+	}
+
+	err = m.poller.Start()
+	if err != nil {
+		return err
+	}
+
+	go m.handleUpdates()
+
+	m.isStarted = true
+
+	return nil
+}
+
+// Stop the monitor
+func (m *MonitorPriceCoinCap) Stop() error {
+
+	if !m.isStarted {
+		return errors.New("monitor not started")
+	}
+
+	m.cancel()
+
+	return nil
+}
+
+// SetCurrencyRates sets the currency rates and updates the currency on each asset quote
+func (m *MonitorPriceCoinCap) SetCurrencyRates(currencyRates c.CurrencyRates) error {
+	m.muCurrencyRates.Lock()
+	m.currencyRatesCache = currencyRates
+	m.muCurrencyRates.Unlock()
+
+	// Map over each asset quote and update the currency rate
+	// TODO: make this more efficient by selectively updating based on changes in rates
+	_, err := m.getAssetQuotesAndReplaceCache()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// handleUpdates listens for asset quote change messages from the poller and streamer and updates the cache
+func (m *MonitorPriceCoinCap) handleUpdates() {
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+
+		case updateMessage := <-m.chanPollUpdateAssetQuote:
+
+			// Check if cache exists and values have changed before acquiring write lock
+			m.mu.RLock()
+
+			assetQuote, exists := m.assetQuotesCacheLookup[updateMessage.ID]
+
+			if !exists {
+				// If asset id does not exist in cache, skip update
+				m.mu.RUnlock()
+
+				continue
+			}
+
+			// Skip update if nothing has changed
+			if assetQuote.QuotePrice.Price == updateMessage.Data.QuotePrice.Price &&
+				assetQuote.QuoteExtended.Volume == updateMessage.Data.QuoteExtended.Volume {
+
+				m.mu.RUnlock()
+
+				continue
+			}
+			m.mu.RUnlock()
+
+			// Price is different so update cache
+			m.mu.Lock()
+
+			assetQuote.QuotePrice.Price = updateMessage.Data.QuotePrice.Price
+			assetQuote.QuotePrice.Change = updateMessage.Data.QuotePrice.Change
+			assetQuote.QuotePrice.ChangePercent = updateMessage.Data.QuotePrice.ChangePercent
+			assetQuote.QuotePrice.PricePrevClose = updateMessage.Data.QuotePrice.PricePrevClose
+			assetQuote.QuoteExtended.MarketCap = updateMessage.Data.QuoteExtended.MarketCap
+			assetQuote.QuoteExtended.Volume = updateMessage.Data.QuoteExtended.Volume
+
+			m.mu.Unlock()
+
+			// Send a message with an updated quote
+			m.chanUpdateAssetQuote <- c.MessageUpdate[c.AssetQuote]{
+				ID:            assetQuote.Symbol,
+				Data:          *assetQuote,
+				VersionVector: updateMessage.VersionVector,
+			}
+
+			continue
+
+		case updateMessage := <-m.chanStreamUpdateQuotePrice:
+
+			// Check if cache exists and values have changed before acquiring write lock
+			m.mu.RLock()
+
+			assetQuote, exists := m.assetQuotesCacheLookup[updateMessage.ID]
+
+			if !exists {
+				// If asset id does not exist in cache, skip update
+				m.mu.RUnlock()
+
+				continue
+			}
+
+			// Skip update if price has not changed
+			if assetQuote.QuotePrice.Price == updateMessage.Data.Price {
+				m.mu.RUnlock()
+
+				continue
+			}
+			m.mu.RUnlock()
+
+			// Price is different so update cache; the streaming API only sends the price so change is derived from the last reference price
+			m.mu.Lock()
+
+			quotePrice := unary.GetQuotePrice(updateMessage.Data.Price, assetQuote.QuotePrice.PricePrevClose)
+			assetQuote.QuotePrice.Price = quotePrice.Price
+			assetQuote.QuotePrice.Change = quotePrice.Change
+			assetQuote.QuotePrice.ChangePercent = quotePrice.ChangePercent
+
+			m.mu.Unlock()
+
+			// Send a message with an updated quote
+			m.chanUpdateAssetQuote <- c.MessageUpdate[c.AssetQuote]{
+				ID:            assetQuote.Symbol,
+				Data:          *assetQuote,
+				VersionVector: updateMessage.VersionVector,
+			}
+
+			continue
+		}
+	}
+}
+
+// getAssetQuotesAndReplaceCache gets asset quotes from the unary API, adds currency rates, filters out assets not explicitly requested,
+// and replaces the asset quotes cache
+func (m *MonitorPriceCoinCap) getAssetQuotesAndReplaceCache() ([]*c.AssetQuote, error) {
+
+	lookup := make(map[string]*c.AssetQuote)
+
+	m.mu.RLock()
+	ids := m.ids
+	m.mu.RUnlock()
+
+	assetQuotes, _, err := m.unaryAPI.GetAssetQuotes(ids)
+	if err != nil {
+		return []*c.AssetQuote{}, err
+	}
+
+	assetQuotesEnriched := make([]*c.AssetQuote, 0, len(assetQuotes))
+
+	m.muCurrencyRates.RLock()
+
+	for _, quote := range assetQuotes {
+
+		// Set the currency rate if available
+		if currencyRate, exists := m.currencyRatesCache[fromCurrencyCode]; exists {
+			quote.Currency.Rate = currencyRate.Rate
+			quote.Currency.FromCurrencyCode = fromCurrencyCode
+			quote.Currency.ToCurrencyCode = currencyRate.ToCurrency
+		}
+
+		// Check if this quote is explicitly requested and if not, skip
+		if !m.input.idsLookup[quote.Meta.SymbolInSourceAPI] {
+			continue
+		}
+
+		if m.isStreaming {
+			quote.Exchange.DelayText = "Real-time"
+		}
+
+		lookup[quote.Meta.SymbolInSourceAPI] = &quote
+		assetQuotesEnriched = append(assetQuotesEnriched, &quote)
+	}
+
+	m.muCurrencyRates.RUnlock()
+
+	// Lock updates to asset quotes while symbols are changed to ensure data from unary call supercedes potentially outdated streaming data
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.assetQuotesCache = assetQuotesEnriched
+	m.assetQuotesCacheLookup = lookup
+
+	return m.assetQuotesCache, nil
+}
@@ -0,0 +1,13 @@
+package monitorPriceCoinCap_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCoinCap(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "CoinCap Suite")
+}
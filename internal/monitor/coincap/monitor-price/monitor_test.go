@@ -0,0 +1,273 @@
+package monitorPriceCoinCap_test
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	g "github.com/onsi/gomega/gstruct"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	monitorPriceCoinCap "github.com/achannarasappa/ticker/v5/internal/monitor/coincap/monitor-price"
+	unary "github.com/achannarasappa/ticker/v5/internal/monitor/coincap/unary"
+	testWs "github.com/achannarasappa/ticker/v5/test/websocket"
+)
+
+var _ = Describe("Monitor CoinCap", func() {
+	var (
+		server          *ghttp.Server
+		responseBitcoin unary.ResponseQuote
+		responseEther   unary.ResponseQuote
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		responseBitcoin = unary.ResponseQuote{
+			ID:                "bitcoin",
+			Symbol:            "BTC",
+			Name:              "Bitcoin",
+			PriceUsd:          "50000",
+			ChangePercent24Hr: "0",
+			VolumeUsd24Hr:     "1000",
+		}
+		responseEther = unary.ResponseQuote{
+			ID:                "ethereum",
+			Symbol:            "ETH",
+			Name:              "Ethereum",
+			PriceUsd:          "2000",
+			ChangePercent24Hr: "0",
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("NewMonitorPriceCoinCap", func() {
+		It("should return a new MonitorPriceCoinCap", func() {
+			monitor := monitorPriceCoinCap.NewMonitorPriceCoinCap(monitorPriceCoinCap.Config{
+				UnaryURL:                 server.URL(),
+				APIKey:                   "key",
+				Ctx:                      context.Background(),
+				ChanRequestCurrencyRates: make(chan []string, 1),
+			}, monitorPriceCoinCap.WithRefreshInterval(10*time.Second), monitorPriceCoinCap.WithStreamingURL("wss://ws.coincap.io/prices"))
+
+			Expect(monitor).NotTo(BeNil())
+		})
+	})
+
+	Describe("SetSymbols", func() {
+		It("should get quotes for the normalized and deduplicated ids", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/assets", "ids=bitcoin,ethereum&limit=2000"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, unary.Response{
+						Data: []unary.ResponseQuote{responseBitcoin, responseEther},
+					}),
+				),
+			)
+
+			inputChanRequestCurrencyRates := make(chan []string, 1)
+
+			monitor := monitorPriceCoinCap.NewMonitorPriceCoinCap(monitorPriceCoinCap.Config{
+				UnaryURL:                 server.URL(),
+				APIKey:                   "key",
+				Ctx:                      context.Background(),
+				ChanRequestCurrencyRates: inputChanRequestCurrencyRates,
+			})
+
+			err := monitor.SetSymbols([]string{"Ethereum", "bitcoin", "ethereum"}, 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			assetQuotes, err := monitor.GetAssetQuotes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(assetQuotes).To(HaveLen(2))
+			Expect(assetQuotes[0].Symbol).To(Equal("BITCOIN.CC"))
+			Expect(assetQuotes[1].Symbol).To(Equal("ETHEREUM.CC"))
+			Expect(inputChanRequestCurrencyRates).To(Receive(Equal([]string{"USD"})))
+		})
+
+		When("the request fails", func() {
+			It("should return an error", func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusInternalServerError, ""),
+				)
+
+				monitor := monitorPriceCoinCap.NewMonitorPriceCoinCap(monitorPriceCoinCap.Config{
+					UnaryURL:                 server.URL(),
+					APIKey:                   "key",
+					Ctx:                      context.Background(),
+					ChanRequestCurrencyRates: make(chan []string, 1),
+				})
+
+				err := monitor.SetSymbols([]string{"bitcoin"}, 0)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("SetCurrencyRates", func() {
+		It("should set the currency rate on each asset quote", func() {
+			server.RouteToHandler("GET", "/v3/assets",
+				ghttp.RespondWithJSONEncoded(http.StatusOK, unary.Response{
+					Data: []unary.ResponseQuote{responseBitcoin},
+				}),
+			)
+
+			monitor := monitorPriceCoinCap.NewMonitorPriceCoinCap(monitorPriceCoinCap.Config{
+				UnaryURL:                 server.URL(),
+				APIKey:                   "key",
+				Ctx:                      context.Background(),
+				ChanRequestCurrencyRates: make(chan []string, 1),
+			})
+
+			monitor.SetSymbols([]string{"bitcoin"}, 0)
+
+			err := monitor.SetCurrencyRates(c.CurrencyRates{
+				"USD": c.CurrencyRate{FromCurrency: "USD", ToCurrency: "EUR", Rate: 0.9},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			assetQuotes, _ := monitor.GetAssetQuotes()
+			Expect(assetQuotes).To(HaveLen(1))
+			Expect(assetQuotes[0].Currency).To(Equal(c.Currency{
+				FromCurrencyCode: "USD",
+				ToCurrencyCode:   "EUR",
+				Rate:             0.9,
+			}))
+		})
+	})
+
+	Describe("Start", func() {
+		When("a price is streamed", func() {
+			It("should send the updated quote with the change from the reference price to the update channel", func() {
+				server.RouteToHandler("GET", "/v3/assets",
+					ghttp.RespondWithJSONEncoded(http.StatusOK, unary.Response{
+						Data: []unary.ResponseQuote{responseBitcoin},
+					}),
+				)
+
+				inputServer := testWs.NewTestServer([]string{`{"bitcoin":"55000"}`})
+				defer inputServer.Close()
+
+				inputChanUpdateAssetQuote := make(chan c.MessageUpdate[c.AssetQuote], 5)
+
+				monitor := monitorPriceCoinCap.NewMonitorPriceCoinCap(monitorPriceCoinCap.Config{
+					UnaryURL:                 server.URL(),
+					APIKey:                   "key",
+					Ctx:                      context.Background(),
+					ChanRequestCurrencyRates: make(chan []string, 1),
+					ChanUpdateAssetQuote:     inputChanUpdateAssetQuote,
+					ChanError:                make(chan error, 5),
+				}, monitorPriceCoinCap.WithRefreshInterval(10*time.Second), monitorPriceCoinCap.WithStreamingURL("ws://"+inputServer.URL[7:]))
+
+				err := monitor.Start()
+				Expect(err).NotTo(HaveOccurred())
+				defer monitor.Stop() //nolint:errcheck
+
+				err = monitor.SetSymbols([]string{"bitcoin"}, 1)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(inputChanUpdateAssetQuote).Should(Receive(
+					g.MatchFields(g.IgnoreExtras, g.Fields{
+						"ID":            Equal("BITCOIN.CC"),
+						"VersionVector": Equal(1),
+						"Data": g.MatchFields(g.IgnoreExtras, g.Fields{
+							"QuotePrice": g.MatchFields(g.IgnoreExtras, g.Fields{
+								"Price":         Equal(55000.0),
+								"Change":        Equal(5000.0),
+								"ChangePercent": Equal(10.0),
+							}),
+							"Exchange": g.MatchFields(g.IgnoreExtras, g.Fields{
+								"DelayText": Equal("Real-time"),
+							}),
+						}),
+					}),
+				))
+			})
+		})
+
+		When("a quote is polled", func() {
+			It("should send the updated quote to the update channel", func() {
+				responseBitcoinUpdated := responseBitcoin
+				responseBitcoinUpdated.VolumeUsd24Hr = "2000"
+
+				// The first requests are made synchronously by SetSymbols and Start and later requests by the poller
+				requestCount := 0
+				server.RouteToHandler("GET", "/v3/assets", func(w http.ResponseWriter, r *http.Request) {
+					requestCount++
+					if requestCount <= 2 {
+						ghttp.RespondWithJSONEncoded(http.StatusOK, unary.Response{Data: []unary.ResponseQuote{responseBitcoin}})(w, r)
+
+						return
+					}
+					ghttp.RespondWithJSONEncoded(http.StatusOK, unary.Response{Data: []unary.ResponseQuote{responseBitcoinUpdated}})(w, r)
+				})
+
+				inputChanUpdateAssetQuote := make(chan c.MessageUpdate[c.AssetQuote], 5)
+
+				monitor := monitorPriceCoinCap.NewMonitorPriceCoinCap(monitorPriceCoinCap.Config{
+					UnaryURL:                 server.URL(),
+					APIKey:                   "key",
+					Ctx:                      context.Background(),
+					ChanRequestCurrencyRates: make(chan []string, 1),
+					ChanUpdateAssetQuote:     inputChanUpdateAssetQuote,
+					ChanError:                make(chan error, 5),
+				}, monitorPriceCoinCap.WithRefreshInterval(100*time.Millisecond))
+
+				monitor.SetSymbols([]string{"bitcoin"}, 2)
+
+				err := monitor.Start()
+				Expect(err).NotTo(HaveOccurred())
+				defer monitor.Stop() //nolint:errcheck
+
+				Eventually(inputChanUpdateAssetQuote).Should(Receive(
+					g.MatchFields(g.IgnoreExtras, g.Fields{
+						"ID":            Equal("BITCOIN.CC"),
+						"VersionVector": Equal(2),
+						"Data": g.MatchFields(g.IgnoreExtras, g.Fields{
+							"QuoteExtended": g.MatchFields(g.IgnoreExtras, g.Fields{
+								"Volume": Equal(2000.0),
+							}),
+						}),
+					}),
+				))
+			})
+		})
+
+		When("the monitor is already started", func() {
+			It("should return an error", func() {
+				monitor := monitorPriceCoinCap.NewMonitorPriceCoinCap(monitorPriceCoinCap.Config{
+					UnaryURL:                 server.URL(),
+					APIKey:                   "key",
+					Ctx:                      context.Background(),
+					ChanRequestCurrencyRates: make(chan []string, 1),
+				}, monitorPriceCoinCap.WithRefreshInterval(10*time.Second))
+
+				err := monitor.Start()
+				Expect(err).NotTo(HaveOccurred())
+				err = monitor.Start()
+				Expect(err).To(MatchError("monitor already started"))
+			})
+		})
+	})
+
+	Describe("Stop", func() {
+		When("the monitor is not started", func() {
+			It("should return an error", func() {
+				monitor := monitorPriceCoinCap.NewMonitorPriceCoinCap(monitorPriceCoinCap.Config{
+					UnaryURL:                 server.URL(),
+					APIKey:                   "key",
+					Ctx:                      context.Background(),
+					ChanRequestCurrencyRates: make(chan []string, 1),
+				})
+
+				err := monitor.Stop()
+				Expect(err).To(MatchError("monitor not started"))
+			})
+		})
+	})
+})
@@ -0,0 +1,169 @@
+package poller_test
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	g "github.com/onsi/gomega/gstruct"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	poller "github.com/achannarasappa/ticker/v5/internal/monitor/coincap/monitor-price/poller"
+	unary "github.com/achannarasappa/ticker/v5/internal/monitor/coincap/unary"
+)
+
+var _ = Describe("Poller", func() {
+	var (
+		server *ghttp.Server
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+
+		server.RouteToHandler("GET", "/v3/assets",
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/v3/assets", "ids=bitcoin&limit=2000"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, unary.Response{
+					Data: []unary.ResponseQuote{
+						{
+							ID:       "bitcoin",
+							Symbol:   "BTC",
+							Name:     "Bitcoin",
+							PriceUsd: "50000.00",
+						},
+					},
+				}),
+			),
+		)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("NewPoller", func() {
+		It("should create a new poller instance", func() {
+			p := poller.NewPoller(context.Background(), poller.PollerConfig{
+				UnaryAPI:             unary.NewUnaryAPI(server.URL(), "key"),
+				ChanUpdateAssetQuote: make(chan c.MessageUpdate[c.AssetQuote], 5),
+			})
+			Expect(p).NotTo(BeNil())
+		})
+	})
+
+	Describe("Start", func() {
+		It("should start polling for price updates", func() {
+
+			inputChanUpdateAssetQuote := make(chan c.MessageUpdate[c.AssetQuote], 5)
+
+			p := poller.NewPoller(context.Background(), poller.PollerConfig{
+				UnaryAPI:             unary.NewUnaryAPI(server.URL(), "key"),
+				ChanUpdateAssetQuote: inputChanUpdateAssetQuote,
+			})
+			p.SetSymbols([]string{"bitcoin"}, 0)
+			p.SetRefreshInterval(time.Millisecond * 250)
+
+			err := p.Start()
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(inputChanUpdateAssetQuote).Should(Receive(
+				g.MatchFields(g.IgnoreExtras, g.Fields{
+					"ID": Equal("bitcoin"),
+					"Data": g.MatchFields(g.IgnoreExtras, g.Fields{
+						"QuotePrice": g.MatchFields(g.IgnoreExtras, g.Fields{
+							"Price": Equal(50000.00),
+						}),
+					}),
+				}),
+			))
+		})
+
+		When("the poller is already started", func() {
+			When("and the poller is started again", func() {
+				It("should return an error", func() {
+					p := poller.NewPoller(context.Background(), poller.PollerConfig{
+						UnaryAPI:             unary.NewUnaryAPI(server.URL(), "key"),
+						ChanUpdateAssetQuote: make(chan c.MessageUpdate[c.AssetQuote], 5),
+					})
+					p.SetSymbols([]string{"bitcoin"}, 0)
+					p.SetRefreshInterval(time.Second * 1)
+
+					err := p.Start()
+					Expect(err).NotTo(HaveOccurred())
+					err = p.Start()
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(Equal("poller already started"))
+				})
+			})
+
+			When("and the refresh interval is set again", func() {
+				It("should return an error", func() {
+					p := poller.NewPoller(context.Background(), poller.PollerConfig{
+						UnaryAPI:             unary.NewUnaryAPI(server.URL(), "key"),
+						ChanUpdateAssetQuote: make(chan c.MessageUpdate[c.AssetQuote], 5),
+					})
+					p.SetSymbols([]string{"bitcoin"}, 0)
+					p.SetRefreshInterval(time.Second * 1)
+
+					err := p.Start()
+					Expect(err).NotTo(HaveOccurred())
+					err = p.SetRefreshInterval(time.Second * 1)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(Equal("cannot set refresh interval while poller is started"))
+				})
+			})
+		})
+
+		When("the refresh interval is not set", func() {
+			It("should return an error", func() {
+				p := poller.NewPoller(context.Background(), poller.PollerConfig{
+					UnaryAPI:             unary.NewUnaryAPI(server.URL(), "key"),
+					ChanUpdateAssetQuote: make(chan c.MessageUpdate[c.AssetQuote], 5),
+				})
+				p.SetSymbols([]string{"bitcoin"}, 0)
+
+				err := p.Start()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("refresh interval is not set"))
+			})
+		})
+
+		When("the request fails", func() {
+			It("should send an error to the error channel", func() {
+				server.RouteToHandler("GET", "/v3/assets",
+					ghttp.RespondWith(http.StatusTooManyRequests, ""),
+				)
+
+				inputChanError := make(chan error, 5)
+
+				p := poller.NewPoller(context.Background(), poller.PollerConfig{
+					UnaryAPI:             unary.NewUnaryAPI(server.URL(), "key"),
+					ChanUpdateAssetQuote: make(chan c.MessageUpdate[c.AssetQuote], 5),
+					ChanError:            inputChanError,
+				})
+				p.SetSymbols([]string{"bitcoin"}, 0)
+				p.SetRefreshInterval(time.Millisecond * 250)
+
+				err := p.Start()
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(inputChanError).Should(Receive(MatchError("request failed with status 429")))
+			})
+		})
+	})
+
+	Describe("Stop", func() {
+		It("should stop the poller", func() {
+			p := poller.NewPoller(context.Background(), poller.PollerConfig{
+				UnaryAPI:             unary.NewUnaryAPI(server.URL(), "key"),
+				ChanUpdateAssetQuote: make(chan c.MessageUpdate[c.AssetQuote], 5),
+			})
+
+			err := p.Stop()
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
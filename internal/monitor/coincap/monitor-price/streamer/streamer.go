@@ -0,0 +1,244 @@
+package streamer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/metrics"
+	"github.com/gorilla/websocket"
+)
+
+// messagePrices is a map of CoinCap asset ids to the latest price in USD encoded as a string
+type messagePrices map[string]string
+
+// Streamer streams prices from the CoinCap websocket API. CoinCap sets the assets to stream when the connection
+// is opened rather than with subscription messages so the connection is replaced whenever the symbols change.
+type Streamer struct {
+	symbols                    []string
+	conn                       *websocket.Conn
+	isStarted                  bool
+	url                        string
+	mu                         sync.Mutex
+	wg                         sync.WaitGroup
+	ctx                        context.Context
+	cancel                     context.CancelFunc
+	chanStreamUpdateQuotePrice chan c.MessageUpdate[c.QuotePrice]
+	chanError                  chan error
+	versionVector              int
+	apiKey                     string
+}
+
+// StreamerConfig represents the configuration for the streamer
+type StreamerConfig struct {
+	ChanStreamUpdateQuotePrice chan c.MessageUpdate[c.QuotePrice]
+	ChanError                  chan error
+	APIKey                     string
+}
+
+// NewStreamer creates a new streamer
+func NewStreamer(ctx context.Context, config StreamerConfig) *Streamer {
+	ctx, cancel := context.WithCancel(ctx) //nolint:gosec // cancel stored in struct and called via Stop()
+
+	s := &Streamer{
+		chanStreamUpdateQuotePrice: config.ChanStreamUpdateQuotePrice,
+		chanError:                  config.ChanError,
+		ctx:                        ctx,
+		cancel:                     cancel,
+		wg:                         sync.WaitGroup{},
+		versionVector:              0,
+		apiKey:                     config.APIKey,
+	}
+
+	return s
+}
+
+// Start starts the streamer and connects to the websocket API if any symbols are set
+func (s *Streamer) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.isStarted {
+		return errors.New("streamer already started")
+	}
+
+	// Streaming is optional so prices are only polled when no URL is set
+	if s.url == "" {
+		return nil
+	}
+
+	err := s.connect()
+	if err != nil {
+		return err
+	}
+
+	// Disconnect on stop signal
+	go func() {
+		<-s.ctx.Done()
+		s.mu.Lock()
+		s.disconnect()
+		s.isStarted = false
+		s.symbols = []string{}
+		s.mu.Unlock()
+		s.wg.Wait()
+	}()
+
+	s.isStarted = true
+
+	return nil
+}
+
+// SetSymbolsAndUpdateSubscriptions sets the asset ids to stream and reconnects if the streamer is started
+func (s *Streamer) SetSymbolsAndUpdateSubscriptions(symbols []string, versionVector int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.symbols = symbols
+	s.versionVector = versionVector
+
+	if !s.isStarted {
+
+		return nil
+	}
+
+	isReconnect := s.conn != nil
+
+	s.disconnect()
+
+	if err := s.connect(); err != nil {
+		return err
+	}
+
+	if isReconnect && s.conn != nil {
+		metrics.StreamerReconnects.WithLabelValues("coincap").Inc()
+	}
+
+	return nil
+}
+
+// SetURL sets the websocket URL
+func (s *Streamer) SetURL(url string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.isStarted {
+
+		return errors.New("cannot set URL while streamer is connected")
+	}
+
+	s.url = url
+
+	return nil
+}
+
+// connect opens a connection for the current symbols; the caller must hold the lock
+func (s *Streamer) connect() error {
+
+	if len(s.symbols) == 0 {
+		return nil
+	}
+
+	streamURL, err := url.Parse(s.url)
+	if err != nil {
+		return fmt.Errorf("failed to parse streaming URL: %w", err)
+	}
+
+	q := streamURL.Query()
+	q.Set("assets", strings.Join(s.symbols, ","))
+	if s.apiKey != "" {
+		q.Set("apiKey", s.apiKey)
+	}
+	streamURL.RawQuery = q.Encode()
+
+	// Create connection channel for result
+	connChan := make(chan *websocket.Conn, 1)
+	errChan := make(chan error, 1)
+
+	// Connect the websocket address in a goroutine
+	go func() {
+		conn, _, err := websocket.DefaultDialer.DialContext(s.ctx, streamURL.String(), nil)
+		if err != nil {
+			errChan <- err
+
+			return
+		}
+		connChan <- conn
+	}()
+
+	// Wait for either connection, error, or context cancellation
+	select {
+	case conn := <-connChan:
+		s.conn = conn
+	case err := <-errChan:
+
+		return err
+	case <-s.ctx.Done():
+
+		return fmt.Errorf("connection aborted: %w", s.ctx.Err())
+	}
+
+	s.wg.Add(1)
+	go s.readStreamQuote(s.conn, s.versionVector)
+
+	return nil
+}
+
+// disconnect closes the current connection if there is one; the caller must hold the lock
+func (s *Streamer) disconnect() {
+
+	if s.conn == nil {
+		return
+	}
+
+	s.conn.Close()
+	s.conn = nil
+}
+
+func (s *Streamer) readStreamQuote(conn *websocket.Conn, versionVector int) {
+	defer s.wg.Done()
+
+	for {
+		var message messagePrices
+		err := conn.ReadJSON(&message)
+		if err != nil {
+			s.mu.Lock()
+			isCurrentConn := s.conn == conn
+			s.mu.Unlock()
+
+			// Errors from connections closed intentionally on symbol change or stop are expected
+			if isCurrentConn && s.chanError != nil {
+				select {
+				case s.chanError <- err:
+				case <-s.ctx.Done():
+				}
+			}
+
+			return
+		}
+
+		for id, priceText := range message {
+			price, err := strconv.ParseFloat(priceText, 64)
+			if err != nil {
+				continue
+			}
+
+			select {
+			case s.chanStreamUpdateQuotePrice <- c.MessageUpdate[c.QuotePrice]{
+				ID:            id,
+				VersionVector: versionVector,
+				Data: c.QuotePrice{
+					Price: price,
+				},
+			}:
+			case <-s.ctx.Done():
+
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package streamer_test
+
+import (
+	"context"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	g "github.com/onsi/gomega/gstruct"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	streamer "github.com/achannarasappa/ticker/v5/internal/monitor/coincap/monitor-price/streamer"
+	testWs "github.com/achannarasappa/ticker/v5/test/websocket"
+)
+
+var _ = Describe("Streamer", func() {
+	var (
+		inputServer                     *httptest.Server
+		inputChanStreamUpdateQuotePrice chan c.MessageUpdate[c.QuotePrice]
+		inputChanError                  chan error
+		s                               *streamer.Streamer
+	)
+
+	BeforeEach(func() {
+		inputChanStreamUpdateQuotePrice = make(chan c.MessageUpdate[c.QuotePrice], 5)
+		inputChanError = make(chan error, 5)
+	})
+
+	Describe("NewStreamer", func() {
+		It("should return a new Streamer", func() {
+			s := streamer.NewStreamer(context.Background(), streamer.StreamerConfig{})
+			Expect(s).NotTo(BeNil())
+		})
+	})
+
+	Describe("Start", func() {
+		BeforeEach(func() {
+			inputServer = testWs.NewTestServer([]string{})
+			s = streamer.NewStreamer(context.Background(), streamer.StreamerConfig{
+				ChanStreamUpdateQuotePrice: inputChanStreamUpdateQuotePrice,
+				ChanError:                  inputChanError,
+			})
+			s.SetURL("ws://" + inputServer.URL[7:])
+		})
+
+		AfterEach(func() {
+			inputServer.Close()
+		})
+
+		It("should start the streamer without an error", func() {
+			err := s.Start()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("the streamer is already started", func() {
+			It("should return the error 'streamer already started'", func() {
+				err := s.Start()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = s.Start()
+				Expect(err).To(MatchError("streamer already started"))
+			})
+		})
+
+		When("the url is not set", func() {
+			It("should not start the streamer and not return an error", func() {
+				s = streamer.NewStreamer(context.Background(), streamer.StreamerConfig{})
+				err := s.Start()
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("symbols are set before the streamer is started", func() {
+			When("the websocket connection is not successful", func() {
+				It("should return an error", func() {
+					s.SetURL("http://" + inputServer.URL[7:])
+					s.SetSymbolsAndUpdateSubscriptions([]string{"bitcoin"}, 0)
+					err := s.Start()
+
+					Expect(err).To(MatchError(ContainSubstring("malformed ws or wss URL")))
+				})
+			})
+
+			When("the context is cancelled while trying to connect to the websocket", func() {
+				It("should return an error containing the text 'connection aborted'", func() {
+					ctx, cancel := context.WithCancel(context.Background())
+					s = streamer.NewStreamer(ctx, streamer.StreamerConfig{})
+					s.SetURL("ws://" + inputServer.URL[7:])
+					s.SetSymbolsAndUpdateSubscriptions([]string{"bitcoin"}, 0)
+					cancel()
+
+					err := s.Start()
+					Expect(err).To(HaveOccurred())
+				})
+			})
+		})
+	})
+
+	Describe("SetURL", func() {
+		When("the streamer is started", func() {
+			It("should return an error", func() {
+				inputServer = testWs.NewTestServer([]string{})
+				defer inputServer.Close()
+
+				s = streamer.NewStreamer(context.Background(), streamer.StreamerConfig{})
+				s.SetURL("ws://" + inputServer.URL[7:])
+				s.Start()
+
+				err := s.SetURL("ws://" + inputServer.URL[7:])
+				Expect(err).To(MatchError("cannot set URL while streamer is connected"))
+			})
+		})
+	})
+
+	Describe("SetSymbolsAndUpdateSubscriptions", func() {
+		It("should connect with the asset ids and API key", func() {
+			server := testWs.NewServer([]string{})
+			defer server.Close()
+
+			s = streamer.NewStreamer(context.Background(), streamer.StreamerConfig{
+				ChanStreamUpdateQuotePrice: inputChanStreamUpdateQuotePrice,
+				ChanError:                  inputChanError,
+				APIKey:                     "key",
+			})
+			s.SetURL("ws://" + server.URL[7:] + "/prices")
+			s.Start()
+
+			err := s.SetSymbolsAndUpdateSubscriptions([]string{"bitcoin", "ethereum"}, 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(server.GetRequestURIs).Should(Equal([]string{"/prices?apiKey=key&assets=bitcoin%2Cethereum"}))
+		})
+	})
+
+	Describe("readStreamQuote", func() {
+		When("a price message is received", func() {
+			It("should send a price update for each asset to the channel", func() {
+				inputServer = testWs.NewTestServer([]string{
+					`{"bitcoin":"50000.10","ethereum":"2000.50"}`,
+				})
+				defer inputServer.Close()
+
+				s = streamer.NewStreamer(context.Background(), streamer.StreamerConfig{
+					ChanStreamUpdateQuotePrice: inputChanStreamUpdateQuotePrice,
+					ChanError:                  inputChanError,
+				})
+				s.SetURL("ws://" + inputServer.URL[7:])
+				s.Start()
+
+				err := s.SetSymbolsAndUpdateSubscriptions([]string{"bitcoin", "ethereum"}, 3)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(inputChanStreamUpdateQuotePrice).Should(Receive(
+					g.MatchFields(g.IgnoreExtras, g.Fields{
+						"VersionVector": Equal(3),
+						"Data": g.MatchFields(g.IgnoreExtras, g.Fields{
+							"Price": BeNumerically(">", 0),
+						}),
+					}),
+				))
+				Eventually(inputChanStreamUpdateQuotePrice).Should(Receive())
+			})
+		})
+
+		When("a price cannot be parsed", func() {
+			It("should skip that asset", func() {
+				inputServer = testWs.NewTestServer([]string{
+					`{"bitcoin":"invalid"}`,
+				})
+				defer inputServer.Close()
+
+				s = streamer.NewStreamer(context.Background(), streamer.StreamerConfig{
+					ChanStreamUpdateQuotePrice: inputChanStreamUpdateQuotePrice,
+					ChanError:                  inputChanError,
+				})
+				s.SetURL("ws://" + inputServer.URL[7:])
+				s.SetSymbolsAndUpdateSubscriptions([]string{"bitcoin"}, 0)
+				s.Start()
+
+				Consistently(inputChanStreamUpdateQuotePrice).ShouldNot(Receive())
+			})
+		})
+
+		When("the connection is closed by the server", func() {
+			It("should send the error to the error channel", func() {
+				inputServer = testWs.NewTestServer([]string{})
+				defer inputServer.Close()
+
+				s = streamer.NewStreamer(context.Background(), streamer.StreamerConfig{
+					ChanStreamUpdateQuotePrice: inputChanStreamUpdateQuotePrice,
+					ChanError:                  inputChanError,
+				})
+				s.SetURL("ws://" + inputServer.URL[7:])
+				s.SetSymbolsAndUpdateSubscriptions([]string{"bitcoin"}, 0)
+				s.Start()
+
+				Eventually(inputChanError).Should(Receive())
+			})
+		})
+	})
+})
@@ -0,0 +1,197 @@
+package unary
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/metrics"
+)
+
+const (
+	// maxIdsPerRequest is the largest number of assets returned by the assets endpoint in a single page
+	maxIdsPerRequest = 2000
+	symbolSuffix     = ".CC"
+)
+
+// Response represents the container object from the CoinCap assets endpoint
+type Response struct {
+	Data      []ResponseQuote `json:"data"`
+	Timestamp int64           `json:"timestamp"`
+}
+
+// ResponseQuote represents a quote of a single asset from the CoinCap API; numeric values are encoded as strings
+type ResponseQuote struct {
+	ID                string `json:"id"`
+	Rank              string `json:"rank"`
+	Symbol            string `json:"symbol"`
+	Name              string `json:"name"`
+	Supply            string `json:"supply"`
+	MaxSupply         string `json:"maxSupply"`
+	MarketCapUsd      string `json:"marketCapUsd"`
+	VolumeUsd24Hr     string `json:"volumeUsd24Hr"`
+	PriceUsd          string `json:"priceUsd"`
+	ChangePercent24Hr string `json:"changePercent24Hr"`
+	Vwap24Hr          string `json:"vwap24Hr"`
+}
+
+// UnaryAPI is a client for the CoinCap API
+type UnaryAPI struct {
+	client  *http.Client
+	baseURL string
+	apiKey  string
+}
+
+// NewUnaryAPI creates a new client which authenticates with a CoinCap API key
+func NewUnaryAPI(baseURL string, apiKey string) *UnaryAPI {
+	return &UnaryAPI{
+		client:  &http.Client{Transport: metrics.NewTransport("coincap", nil)},
+		baseURL: baseURL,
+		apiKey:  apiKey,
+	}
+}
+
+// SymbolFromID returns the ticker symbol for a CoinCap asset id (e.g. bitcoin -> BITCOIN.CC)
+func SymbolFromID(id string) string {
+	return strings.ToUpper(id) + symbolSuffix
+}
+
+// GetQuotePrice returns price fields for a new price given the 24 hour reference price
+func GetQuotePrice(price float64, pricePrevClose float64) c.QuotePrice {
+
+	var changePercent float64
+
+	change := price - pricePrevClose
+
+	if pricePrevClose != 0 {
+		changePercent = change / pricePrevClose * 100
+	}
+
+	return c.QuotePrice{
+		Price:          price,
+		PricePrevClose: pricePrevClose,
+		Change:         change,
+		ChangePercent:  changePercent,
+	}
+}
+
+func transformResponseQuote(responseQuote ResponseQuote) c.AssetQuote {
+
+	price, _ := strconv.ParseFloat(responseQuote.PriceUsd, 64)
+	changePercent, _ := strconv.ParseFloat(responseQuote.ChangePercent24Hr, 64)
+	marketCap, _ := strconv.ParseFloat(responseQuote.MarketCapUsd, 64)
+	volume, _ := strconv.ParseFloat(responseQuote.VolumeUsd24Hr, 64)
+
+	// CoinCap only provides the percent change over the last 24 hours so the reference price is derived from it
+	pricePrevClose := price / (1 + changePercent/100)
+
+	return c.AssetQuote{
+		Name:   responseQuote.Name,
+		Symbol: SymbolFromID(responseQuote.ID),
+		Class:  c.AssetClassCryptocurrency,
+		Currency: c.Currency{
+			FromCurrencyCode: "USD",
+		},
+		QuotePrice: c.QuotePrice{
+			Price:          price,
+			PricePrevClose: pricePrevClose,
+			Change:         price - pricePrevClose,
+			ChangePercent:  changePercent,
+		},
+		QuoteExtended: c.QuoteExtended{
+			MarketCap: marketCap,
+			Volume:    volume,
+		},
+		QuoteSource: c.QuoteSourceCoinCap,
+		Exchange: c.Exchange{
+			Name:                    "CoinCap",
+			State:                   c.ExchangeStateOpen,
+			IsActive:                true,
+			IsRegularTradingSession: true, // Crypto markets are always in regular session
+		},
+		Meta: c.Meta{
+			IsVariablePrecision: true,
+			SymbolInSourceAPI:   responseQuote.ID,
+		},
+	}
+}
+
+func transformResponseQuotes(responseQuotes []ResponseQuote) ([]c.AssetQuote, map[string]*c.AssetQuote) {
+	quotes := make([]c.AssetQuote, 0, len(responseQuotes))
+	quotesByID := make(map[string]*c.AssetQuote, len(responseQuotes))
+
+	for _, responseQuote := range responseQuotes {
+		quote := transformResponseQuote(responseQuote)
+		quotes = append(quotes, quote)
+		quotesByID[quote.Meta.SymbolInSourceAPI] = &quote
+	}
+
+	return quotes, quotesByID
+}
+
+// GetAssetQuotes retrieves quotes for the given CoinCap asset ids
+func (u *UnaryAPI) GetAssetQuotes(ids []string) ([]c.AssetQuote, map[string]*c.AssetQuote, error) {
+	if len(ids) == 0 {
+		return []c.AssetQuote{}, make(map[string]*c.AssetQuote), nil
+	}
+
+	responseQuotes := make([]ResponseQuote, 0, len(ids))
+
+	for start := 0; start < len(ids); start += maxIdsPerRequest {
+		end := min(start+maxIdsPerRequest, len(ids))
+
+		batch, err := u.getAssets(ids[start:end])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		responseQuotes = append(responseQuotes, batch...)
+	}
+
+	quotes, quotesByID := transformResponseQuotes(responseQuotes)
+
+	return quotes, quotesByID, nil
+}
+
+func (u *UnaryAPI) getAssets(ids []string) ([]ResponseQuote, error) {
+
+	if u.apiKey == "" {
+		return nil, errors.New("CoinCap API key is not set (set coincap-api-key in the config)")
+	}
+
+	reqURL, err := url.Parse(u.baseURL + "/v3/assets")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	q := reqURL.Query()
+	q.Set("ids", strings.Join(ids, ","))
+	q.Set("limit", strconv.Itoa(maxIdsPerRequest))
+	reqURL.RawQuery = q.Encode()
+
+	req, _ := http.NewRequest(http.MethodGet, reqURL.String(), nil)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+u.apiKey)
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request failed with status %d", resp.StatusCode)
+	}
+
+	var result Response
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result.Data, nil
+}
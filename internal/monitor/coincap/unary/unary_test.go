@@ -0,0 +1,147 @@
+package unary_test
+
+import (
+	"net/http"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/monitor/coincap/unary"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Unary", func() {
+	var (
+		server *ghttp.Server
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("NewUnaryAPI", func() {
+		It("should return a new UnaryAPI", func() {
+			api := unary.NewUnaryAPI(server.URL(), "key")
+			Expect(api).NotTo(BeNil())
+		})
+	})
+
+	Describe("SymbolFromID", func() {
+		It("should return the uppercase id with the CoinCap suffix", func() {
+			Expect(unary.SymbolFromID("bitcoin")).To(Equal("BITCOIN.CC"))
+		})
+	})
+
+	Describe("GetQuotePrice", func() {
+		It("should derive the change from the reference price", func() {
+			Expect(unary.GetQuotePrice(110, 100)).To(Equal(c.QuotePrice{
+				Price:          110,
+				PricePrevClose: 100,
+				Change:         10,
+				ChangePercent:  10,
+			}))
+		})
+
+		When("the reference price is zero", func() {
+			It("should not set a change percent", func() {
+				Expect(unary.GetQuotePrice(110, 0).ChangePercent).To(BeZero())
+			})
+		})
+	})
+
+	Describe("GetAssetQuotes", func() {
+		It("should return a list of asset quotes", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/assets", "ids=bitcoin&limit=2000"),
+					ghttp.VerifyHeaderKV("Authorization", "Bearer key"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, unary.Response{
+						Data: []unary.ResponseQuote{
+							{
+								ID:                "bitcoin",
+								Symbol:            "BTC",
+								Name:              "Bitcoin",
+								PriceUsd:          "55000.00",
+								ChangePercent24Hr: "10",
+								MarketCapUsd:      "1000000000",
+								VolumeUsd24Hr:     "2000000",
+							},
+						},
+					}),
+				),
+			)
+
+			api := unary.NewUnaryAPI(server.URL(), "key")
+			quotes, quotesByID, err := api.GetAssetQuotes([]string{"bitcoin"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(quotes).To(HaveLen(1))
+			Expect(quotes[0].Symbol).To(Equal("BITCOIN.CC"))
+			Expect(quotes[0].Name).To(Equal("Bitcoin"))
+			Expect(quotes[0].Class).To(Equal(c.AssetClassCryptocurrency))
+			Expect(quotes[0].QuoteSource).To(Equal(c.QuoteSourceCoinCap))
+			Expect(quotes[0].Currency.FromCurrencyCode).To(Equal("USD"))
+			Expect(quotes[0].QuotePrice.Price).To(Equal(55000.0))
+			Expect(quotes[0].QuotePrice.PricePrevClose).To(BeNumerically("~", 50000.0, 0.001))
+			Expect(quotes[0].QuotePrice.Change).To(BeNumerically("~", 5000.0, 0.001))
+			Expect(quotes[0].QuotePrice.ChangePercent).To(Equal(10.0))
+			Expect(quotes[0].QuoteExtended.MarketCap).To(Equal(1000000000.0))
+			Expect(quotes[0].QuoteExtended.Volume).To(Equal(2000000.0))
+			Expect(quotes[0].Meta.SymbolInSourceAPI).To(Equal("bitcoin"))
+			Expect(quotesByID).To(HaveKey("bitcoin"))
+		})
+
+		When("there are no ids", func() {
+			It("should return an empty list without making a request", func() {
+				api := unary.NewUnaryAPI(server.URL(), "key")
+				quotes, _, err := api.GetAssetQuotes([]string{})
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(quotes).To(BeEmpty())
+				Expect(server.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+
+		When("the API key is not set", func() {
+			It("should return an error without making a request", func() {
+				api := unary.NewUnaryAPI(server.URL(), "")
+				_, _, err := api.GetAssetQuotes([]string{"bitcoin"})
+
+				Expect(err).To(MatchError("CoinCap API key is not set (set coincap-api-key in the config)"))
+				Expect(server.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+
+		When("the request fails", func() {
+			It("should return an error", func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusInternalServerError, ""),
+				)
+
+				api := unary.NewUnaryAPI(server.URL(), "key")
+				_, _, err := api.GetAssetQuotes([]string{"bitcoin"})
+
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("request failed with status 500"))
+			})
+		})
+
+		When("the response is not valid JSON", func() {
+			It("should return an error", func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusOK, "invalid"),
+				)
+
+				api := unary.NewUnaryAPI(server.URL(), "key")
+				_, _, err := api.GetAssetQuotes([]string{"bitcoin"})
+
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed to decode response"))
+			})
+		})
+	})
+})
@@ -0,0 +1,336 @@
+package monitorPriceCoingecko
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	poller "github.com/achannarasappa/ticker/v5/internal/monitor/coingecko/monitor-price/poller"
+	unary "github.com/achannarasappa/ticker/v5/internal/monitor/coingecko/unary"
+)
+
+const (
+	fromCurrencyCode = "USD"
+)
+
+// MonitorPriceCoingecko represents a CoinGecko monitor
+type MonitorPriceCoingecko struct {
+	unaryAPI                  *unary.UnaryAPI
+	poller                    *poller.Poller
+	input                     input
+	ids                       []string                 // CoinGecko refers to coins by an id (e.g. bitcoin) which ticker accepts with a .CG suffix
+	assetQuotesCache          []*c.AssetQuote          // Asset quotes for all assets retrieved at start or on symbol change
+	assetQuotesCacheLookup    map[string]*c.AssetQuote // Asset quotes for all assets retrieved at least once (symbol change does not remove symbols)
+	currencyRatesCache        c.CurrencyRates          // Cache of currency rates
+	currencyHasRequestedRates bool                     // Whether the currency rates have been requested; all quotes are requested in a single currency (USD)
+	chanPollUpdateAssetQuote  chan c.MessageUpdate[c.AssetQuote]
+	chanError                 chan error
+	mu                        sync.RWMutex
+	muCurrencyRates           sync.RWMutex
+	ctx                       context.Context
+	cancel                    context.CancelFunc
+	isStarted                 bool
+	chanUpdateAssetQuote      chan c.MessageUpdate[c.AssetQuote]
+	chanRequestCurrencyRates  chan []string
+}
+
+// input represents user input for the CoinGecko monitor with any transformation
+type input struct {
+	ids       []string
+	idsLookup map[string]bool
+}
+
+// Config contains the required configuration for the CoinGecko monitor
+type Config struct {
+	Ctx                      context.Context
+	UnaryURL                 string
+	ChanError                chan error
+	ChanUpdateAssetQuote     chan c.MessageUpdate[c.AssetQuote]
+	ChanRequestCurrencyRates chan []string
+	Cache                    c.Cache
+}
+
+// Option defines an option for configuring the monitor
+type Option func(*MonitorPriceCoingecko)
+
+// NewMonitorPriceCoingecko creates a new CoinGecko monitor
+func NewMonitorPriceCoingecko(config Config, opts ...Option) *MonitorPriceCoingecko {
+	ctx, cancel := context.WithCancel(config.Ctx)
+
+	unaryAPI := unary.NewUnaryAPI(config.UnaryURL)
+
+	monitor := &MonitorPriceCoingecko{
+		assetQuotesCacheLookup:   make(map[string]*c.AssetQuote),
+		assetQuotesCache:         make([]*c.AssetQuote, 0),
+		chanPollUpdateAssetQuote: make(chan c.MessageUpdate[c.AssetQuote]),
+		chanError:                config.ChanError,
+		unaryAPI:                 unaryAPI,
+		ctx:                      ctx,
+		cancel:                   cancel,
+		chanUpdateAssetQuote:     config.ChanUpdateAssetQuote,
+		chanRequestCurrencyRates: config.ChanRequestCurrencyRates,
+	}
+
+	pollerConfig := poller.PollerConfig{
+		ChanUpdateAssetQuote: monitor.chanPollUpdateAssetQuote,
+		ChanError:            monitor.chanError,
+		UnaryAPI:             unaryAPI,
+		Cache:                config.Cache,
+	}
+	monitor.poller = poller.NewPoller(ctx, pollerConfig)
+
+	for _, opt := range opts {
+		opt(monitor)
+	}
+
+	return monitor
+}
+
+// WithRefreshInterval sets the refresh interval for the monitor
+func WithRefreshInterval(interval time.Duration) Option {
+	return func(m *MonitorPriceCoingecko) {
+		if err := m.poller.SetRefreshInterval(interval); err != nil && m.chanError != nil {
+			m.chanError <- err
+		}
+	}
+}
+
+// GetAssetQuotes returns the asset quotes either from the cache or from the unary API if ignoreCache is set
+func (m *MonitorPriceCoingecko) GetAssetQuotes(ignoreCache ...bool) ([]c.AssetQuote, error) {
+
+	if len(ignoreCache) > 0 && ignoreCache[0] {
+		assetQuotes, err := m.getAssetQuotesAndReplaceCache(false)
+		if err != nil {
+			return []c.AssetQuote{}, err
+		}
+
+		result := make([]c.AssetQuote, len(assetQuotes))
+		for i, quote := range assetQuotes {
+			result[i] = *quote
+		}
+
+		return result, nil
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]c.AssetQuote, len(m.assetQuotesCache))
+	for i, quote := range m.assetQuotesCache {
+		result[i] = *quote
+	}
+
+	return result, nil
+}
+
+// SetSymbols sets the CoinGecko coin ids to monitor
+func (m *MonitorPriceCoingecko) SetSymbols(ids []string, versionVector int) error {
+
+	var err error
+
+	m.mu.Lock()
+
+	// CoinGecko ids are always lowercase while ticker symbols are case insensitive
+	idsNormalized := make([]string, 0, len(ids))
+	for _, id := range ids {
+		idsNormalized = append(idsNormalized, strings.ToLower(id))
+	}
+
+	// Deduplicate ids since input may have duplicates
+	slices.Sort(idsNormalized)
+	m.ids = slices.Compact(idsNormalized)
+	m.input.ids = idsNormalized
+	m.input.idsLookup = make(map[string]bool)
+	for _, id := range idsNormalized {
+		m.input.idsLookup[id] = true
+	}
+
+	// All quotes are denominated in USD so the currency rate only needs to be requested once
+	if !m.currencyHasRequestedRates {
+		m.chanRequestCurrencyRates <- []string{fromCurrencyCode}
+		m.currencyHasRequestedRates = true
+	}
+
+	m.mu.Unlock()
+
+	// Since the symbols have changed, make a synchronous call to get price quotes for the new symbols
+	_, err = m.getAssetQuotesAndReplaceCache(true)
+	if err != nil {
+		return err
+	}
+
+	// Set the symbols to monitor on the poller
+	m.poller.SetSymbols(m.ids, versionVector)
+
+	return nil
+}
+
+// Start the monitor
+func (m *MonitorPriceCoingecko) Start() error {
+	var err error
+
+	if m.isStarted {
+		return errors.New("monitor already started")
+	}
+
+	// On start, get initial quotes from unary API
+	_, err = m.getAssetQuotesAndReplaceCache(true)
+	if err != nil {
+		return err
+	}
+
+	err = m.poller.Start()
+	if err != nil {
+		return err
+	}
+
+	go m.handleUpdates()
+
+	m.isStarted = true
+
+	return nil
+}
+
+// Stop the monitor
+func (m *MonitorPriceCoingecko) Stop() error {
+
+	if !m.isStarted {
+		return errors.New("monitor not started")
+	}
+
+	m.cancel()
+
+	return nil
+}
+
+// SetCurrencyRates sets the currency rates and updates the currency on each asset quote
+func (m *MonitorPriceCoingecko) SetCurrencyRates(currencyRates c.CurrencyRates) error {
+	m.muCurrencyRates.Lock()
+	m.currencyRatesCache = currencyRates
+	m.muCurrencyRates.Unlock()
+
+	// Map over each asset quote and update the currency rate
+	// TODO: make this more efficient by selectively updating based on changes in rates
+	_, err := m.getAssetQuotesAndReplaceCache(true)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// handleUpdates listens for asset quote change messages and updates the cache
+func (m *MonitorPriceCoingecko) handleUpdates() {
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+
+		case updateMessage := <-m.chanPollUpdateAssetQuote:
+
+			// Check if cache exists and values have changed before acquiring write lock
+			m.mu.RLock()
+
+			assetQuote, exists := m.assetQuotesCacheLookup[updateMessage.ID]
+
+			if !exists {
+				// If coin id does not exist in cache, skip update
+				m.mu.RUnlock()
+
+				continue
+			}
+
+			// Skip update if nothing has changed
+			if assetQuote.QuotePrice.Price == updateMessage.Data.QuotePrice.Price &&
+				assetQuote.QuotePrice.PriceDayHigh == updateMessage.Data.QuotePrice.PriceDayHigh {
+
+				m.mu.RUnlock()
+
+				continue
+			}
+			m.mu.RUnlock()
+
+			// Price is different so update cache
+			m.mu.Lock()
+
+			assetQuote.QuotePrice.Price = updateMessage.Data.QuotePrice.Price
+			assetQuote.QuotePrice.Change = updateMessage.Data.QuotePrice.Change
+			assetQuote.QuotePrice.ChangePercent = updateMessage.Data.QuotePrice.ChangePercent
+			assetQuote.QuotePrice.PriceDayHigh = updateMessage.Data.QuotePrice.PriceDayHigh
+			assetQuote.QuotePrice.PriceDayLow = updateMessage.Data.QuotePrice.PriceDayLow
+			assetQuote.QuotePrice.PricePrevClose = updateMessage.Data.QuotePrice.PricePrevClose
+			assetQuote.QuoteExtended.MarketCap = updateMessage.Data.QuoteExtended.MarketCap
+			assetQuote.QuoteExtended.Volume = updateMessage.Data.QuoteExtended.Volume
+
+			m.mu.Unlock()
+
+			// Send a message with an updated quote
+			m.chanUpdateAssetQuote <- c.MessageUpdate[c.AssetQuote]{
+				ID:            assetQuote.Symbol,
+				Data:          *assetQuote,
+				VersionVector: updateMessage.VersionVector,
+			}
+
+			continue
+		}
+	}
+}
+
+// getAssetQuotesAndReplaceCache gets asset quotes from the unary API, adds currency rates, filters out assets not explicitly requested,
+// and replaces the asset quotes cache. When useSharedCache is set, quotes recently fetched by any ticker instance are reused.
+func (m *MonitorPriceCoingecko) getAssetQuotesAndReplaceCache(useSharedCache bool) ([]*c.AssetQuote, error) {
+
+	lookup := make(map[string]*c.AssetQuote)
+
+	assetQuotes, err := m.getAssetQuotes(useSharedCache)
+	if err != nil {
+		return []*c.AssetQuote{}, err
+	}
+
+	assetQuotesEnriched := make([]*c.AssetQuote, 0, len(assetQuotes))
+
+	m.muCurrencyRates.RLock()
+
+	for _, quote := range assetQuotes {
+
+		// Set the currency rate if available
+		if currencyRate, exists := m.currencyRatesCache[fromCurrencyCode]; exists {
+			quote.Currency.Rate = currencyRate.Rate
+			quote.Currency.FromCurrencyCode = fromCurrencyCode
+			quote.Currency.ToCurrencyCode = currencyRate.ToCurrency
+		}
+
+		// Check if this quote is explicitly requested and if not, skip
+		if !m.input.idsLookup[quote.Meta.SymbolInSourceAPI] {
+			continue
+		}
+
+		lookup[quote.Meta.SymbolInSourceAPI] = &quote
+		assetQuotesEnriched = append(assetQuotesEnriched, &quote)
+	}
+
+	m.muCurrencyRates.RUnlock()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.assetQuotesCache = assetQuotesEnriched
+	m.assetQuotesCacheLookup = lookup
+
+	return m.assetQuotesCache, nil
+}
+
+// getAssetQuotes gets quotes for the current ids through the poller so that they are shared with other ticker instances
+// in the same way as polled quotes
+func (m *MonitorPriceCoingecko) getAssetQuotes(useSharedCache bool) ([]c.AssetQuote, error) {
+
+	m.mu.RLock()
+	ids := m.ids
+	m.mu.RUnlock()
+
+	return m.poller.GetAssetQuotes(ids, useSharedCache)
+}
@@ -0,0 +1,274 @@
+package monitorPriceCoingecko_test
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	g "github.com/onsi/gomega/gstruct"
+	"github.com/spf13/afero"
+
+	"github.com/achannarasappa/ticker/v5/internal/cache"
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	monitorPriceCoingecko "github.com/achannarasappa/ticker/v5/internal/monitor/coingecko/monitor-price"
+	unary "github.com/achannarasappa/ticker/v5/internal/monitor/coingecko/unary"
+)
+
+var _ = Describe("Monitor CoinGecko", func() {
+	var (
+		server          *ghttp.Server
+		responseBitcoin unary.ResponseQuote
+		responseEther   unary.ResponseQuote
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		responseBitcoin = unary.ResponseQuote{
+			ID:             "bitcoin",
+			Symbol:         "btc",
+			Name:           "Bitcoin",
+			CurrentPrice:   50000,
+			PriceChange24H: 1000,
+		}
+		responseEther = unary.ResponseQuote{
+			ID:             "ethereum",
+			Symbol:         "eth",
+			Name:           "Ethereum",
+			CurrentPrice:   2000,
+			PriceChange24H: -50,
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("NewMonitorPriceCoingecko", func() {
+		It("should return a new MonitorPriceCoingecko", func() {
+			monitor := monitorPriceCoingecko.NewMonitorPriceCoingecko(monitorPriceCoingecko.Config{
+				UnaryURL:                 server.URL(),
+				Ctx:                      context.Background(),
+				ChanRequestCurrencyRates: make(chan []string, 1),
+			}, monitorPriceCoingecko.WithRefreshInterval(10*time.Second))
+
+			Expect(monitor).NotTo(BeNil())
+		})
+	})
+
+	Describe("SetSymbols", func() {
+		It("should get quotes for the normalized and deduplicated ids", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v3/coins/markets", "ids=bitcoin,ethereum&page=1&per_page=250&vs_currency=usd"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, []unary.ResponseQuote{responseBitcoin, responseEther}),
+				),
+			)
+
+			inputChanRequestCurrencyRates := make(chan []string, 1)
+
+			monitor := monitorPriceCoingecko.NewMonitorPriceCoingecko(monitorPriceCoingecko.Config{
+				UnaryURL:                 server.URL(),
+				Ctx:                      context.Background(),
+				ChanRequestCurrencyRates: inputChanRequestCurrencyRates,
+			})
+
+			err := monitor.SetSymbols([]string{"Ethereum", "bitcoin", "ethereum"}, 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			assetQuotes, err := monitor.GetAssetQuotes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(assetQuotes).To(HaveLen(2))
+			Expect(assetQuotes[0].Symbol).To(Equal("BITCOIN.CG"))
+			Expect(assetQuotes[1].Symbol).To(Equal("ETHEREUM.CG"))
+			Expect(inputChanRequestCurrencyRates).To(Receive(Equal([]string{"USD"})))
+		})
+
+		When("the request fails", func() {
+			It("should return an error", func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusInternalServerError, ""),
+				)
+
+				monitor := monitorPriceCoingecko.NewMonitorPriceCoingecko(monitorPriceCoingecko.Config{
+					UnaryURL:                 server.URL(),
+					Ctx:                      context.Background(),
+					ChanRequestCurrencyRates: make(chan []string, 1),
+				})
+
+				err := monitor.SetSymbols([]string{"bitcoin"}, 0)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		When("a cache is set and another instance recently fetched the same ids", func() {
+			It("should use the cached quotes instead of making a request", func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v3/coins/markets", "ids=bitcoin&page=1&per_page=250&vs_currency=usd"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, []unary.ResponseQuote{responseBitcoin}),
+					),
+				)
+
+				sharedCache := cache.New(afero.NewMemMapFs(), "/cache.json", true)
+
+				for range 2 {
+					monitor := monitorPriceCoingecko.NewMonitorPriceCoingecko(monitorPriceCoingecko.Config{
+						UnaryURL:                 server.URL(),
+						Ctx:                      context.Background(),
+						ChanRequestCurrencyRates: make(chan []string, 1),
+						Cache:                    sharedCache,
+					})
+
+					err := monitor.SetSymbols([]string{"bitcoin"}, 0)
+					Expect(err).NotTo(HaveOccurred())
+
+					assetQuotes, _ := monitor.GetAssetQuotes()
+					Expect(assetQuotes).To(HaveLen(1))
+					Expect(assetQuotes[0].QuotePrice.Price).To(Equal(50000.0))
+				}
+
+				Expect(server.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+	})
+
+	Describe("GetAssetQuotes", func() {
+		When("the ignoreCache flag is set to true", func() {
+			It("should get new quotes from the API", func() {
+				responseBitcoinUpdated := responseBitcoin
+				responseBitcoinUpdated.CurrentPrice = 51000
+
+				server.AppendHandlers(
+					ghttp.RespondWithJSONEncoded(http.StatusOK, []unary.ResponseQuote{responseBitcoin}),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, []unary.ResponseQuote{responseBitcoinUpdated}),
+				)
+
+				monitor := monitorPriceCoingecko.NewMonitorPriceCoingecko(monitorPriceCoingecko.Config{
+					UnaryURL:                 server.URL(),
+					Ctx:                      context.Background(),
+					ChanRequestCurrencyRates: make(chan []string, 1),
+				})
+
+				monitor.SetSymbols([]string{"bitcoin"}, 0)
+
+				assetQuotes, err := monitor.GetAssetQuotes(true)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(assetQuotes).To(HaveLen(1))
+				Expect(assetQuotes[0].QuotePrice.Price).To(Equal(51000.0))
+			})
+		})
+	})
+
+	Describe("SetCurrencyRates", func() {
+		It("should set the currency rate on each asset quote", func() {
+			server.RouteToHandler("GET", "/api/v3/coins/markets",
+				ghttp.RespondWithJSONEncoded(http.StatusOK, []unary.ResponseQuote{responseBitcoin}),
+			)
+
+			monitor := monitorPriceCoingecko.NewMonitorPriceCoingecko(monitorPriceCoingecko.Config{
+				UnaryURL:                 server.URL(),
+				Ctx:                      context.Background(),
+				ChanRequestCurrencyRates: make(chan []string, 1),
+			})
+
+			monitor.SetSymbols([]string{"bitcoin"}, 0)
+
+			err := monitor.SetCurrencyRates(c.CurrencyRates{
+				"USD": c.CurrencyRate{FromCurrency: "USD", ToCurrency: "EUR", Rate: 0.9},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			assetQuotes, _ := monitor.GetAssetQuotes()
+			Expect(assetQuotes).To(HaveLen(1))
+			Expect(assetQuotes[0].Currency).To(Equal(c.Currency{
+				FromCurrencyCode: "USD",
+				ToCurrencyCode:   "EUR",
+				Rate:             0.9,
+			}))
+		})
+	})
+
+	Describe("Start", func() {
+		It("should send updated quotes from the poller to the update channel", func() {
+			responseBitcoinUpdated := responseBitcoin
+			responseBitcoinUpdated.CurrentPrice = 52000
+
+			// The first requests are made synchronously by SetSymbols and Start and later requests by the poller
+			requestCount := 0
+			server.RouteToHandler("GET", "/api/v3/coins/markets", func(w http.ResponseWriter, r *http.Request) {
+				requestCount++
+				if requestCount <= 2 {
+					ghttp.RespondWithJSONEncoded(http.StatusOK, []unary.ResponseQuote{responseBitcoin})(w, r)
+
+					return
+				}
+				ghttp.RespondWithJSONEncoded(http.StatusOK, []unary.ResponseQuote{responseBitcoinUpdated})(w, r)
+			})
+
+			inputChanUpdateAssetQuote := make(chan c.MessageUpdate[c.AssetQuote], 5)
+
+			monitor := monitorPriceCoingecko.NewMonitorPriceCoingecko(monitorPriceCoingecko.Config{
+				UnaryURL:                 server.URL(),
+				Ctx:                      context.Background(),
+				ChanRequestCurrencyRates: make(chan []string, 1),
+				ChanUpdateAssetQuote:     inputChanUpdateAssetQuote,
+				ChanError:                make(chan error, 5),
+			}, monitorPriceCoingecko.WithRefreshInterval(100*time.Millisecond))
+
+			monitor.SetSymbols([]string{"bitcoin"}, 2)
+
+			err := monitor.Start()
+			Expect(err).NotTo(HaveOccurred())
+			defer monitor.Stop() //nolint:errcheck
+
+			Eventually(inputChanUpdateAssetQuote).Should(Receive(
+				g.MatchFields(g.IgnoreExtras, g.Fields{
+					"ID":            Equal("BITCOIN.CG"),
+					"VersionVector": Equal(2),
+					"Data": g.MatchFields(g.IgnoreExtras, g.Fields{
+						"QuotePrice": g.MatchFields(g.IgnoreExtras, g.Fields{
+							"Price": Equal(52000.0),
+						}),
+					}),
+				}),
+			))
+		})
+
+		When("the monitor is already started", func() {
+			It("should return an error", func() {
+				server.RouteToHandler("GET", "/api/v3/coins/markets",
+					ghttp.RespondWithJSONEncoded(http.StatusOK, []unary.ResponseQuote{}),
+				)
+
+				monitor := monitorPriceCoingecko.NewMonitorPriceCoingecko(monitorPriceCoingecko.Config{
+					UnaryURL:                 server.URL(),
+					Ctx:                      context.Background(),
+					ChanRequestCurrencyRates: make(chan []string, 1),
+				}, monitorPriceCoingecko.WithRefreshInterval(10*time.Second))
+
+				err := monitor.Start()
+				Expect(err).NotTo(HaveOccurred())
+				err = monitor.Start()
+				Expect(err).To(MatchError("monitor already started"))
+			})
+		})
+	})
+
+	Describe("Stop", func() {
+		When("the monitor is not started", func() {
+			It("should return an error", func() {
+				monitor := monitorPriceCoingecko.NewMonitorPriceCoingecko(monitorPriceCoingecko.Config{
+					UnaryURL:                 server.URL(),
+					Ctx:                      context.Background(),
+					ChanRequestCurrencyRates: make(chan []string, 1),
+				})
+
+				err := monitor.Stop()
+				Expect(err).To(MatchError("monitor not started"))
+			})
+		})
+	})
+})
@@ -0,0 +1,173 @@
+package poller
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/monitor/coingecko/unary"
+)
+
+const (
+	// cacheKeyQuotes namespaces cached quotes by the set of coin ids requested.
+	cacheKeyQuotes = "coingecko:quotes:"
+	// ttlQuotes bounds reuse of quotes for the initial fetch. The shared cache file is
+	// only read when ticker starts so a newly started instance can reuse quotes another
+	// instance fetched within the last minute rather than immediately requesting them
+	// again from CoinGecko, which rate limits public clients aggressively.
+	ttlQuotes = time.Minute
+)
+
+// Poller represents a poller for CoinGecko
+type Poller struct {
+	refreshInterval      time.Duration
+	symbols              []string
+	isStarted            bool
+	ctx                  context.Context
+	cancel               context.CancelFunc
+	unaryAPI             *unary.UnaryAPI
+	cache                c.Cache
+	chanUpdateAssetQuote chan c.MessageUpdate[c.AssetQuote]
+	chanError            chan error
+	versionVector        int
+	mu                   sync.Mutex // Guards symbols and versionVector which are set while polling
+}
+
+// PollerConfig represents the configuration for the poller
+type PollerConfig struct {
+	UnaryAPI             *unary.UnaryAPI
+	Cache                c.Cache // Optional cache used to share quotes with other ticker instances
+	ChanUpdateAssetQuote chan c.MessageUpdate[c.AssetQuote]
+	ChanError            chan error
+}
+
+// NewPoller creates a new poller
+func NewPoller(ctx context.Context, config PollerConfig) *Poller {
+	ctx, cancel := context.WithCancel(ctx) //nolint:gosec // cancel stored in struct and called via Stop()
+
+	return &Poller{
+		refreshInterval:      0,
+		isStarted:            false,
+		ctx:                  ctx,
+		cancel:               cancel,
+		unaryAPI:             config.UnaryAPI,
+		cache:                config.Cache,
+		chanUpdateAssetQuote: config.ChanUpdateAssetQuote,
+		chanError:            config.ChanError,
+		versionVector:        0,
+	}
+}
+
+// SetSymbols sets the CoinGecko coin ids to poll
+func (p *Poller) SetSymbols(symbols []string, versionVector int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.symbols = symbols
+	p.versionVector = versionVector
+}
+
+// SetRefreshInterval sets the refresh interval for the poller
+func (p *Poller) SetRefreshInterval(interval time.Duration) error {
+
+	if p.isStarted {
+		return errors.New("cannot set refresh interval while poller is started")
+	}
+
+	p.refreshInterval = interval
+
+	return nil
+}
+
+// Start starts the poller
+func (p *Poller) Start() error {
+	if p.isStarted {
+		return errors.New("poller already started")
+	}
+
+	if p.refreshInterval <= 0 {
+		return errors.New("refresh interval is not set")
+	}
+
+	p.isStarted = true
+
+	// Start polling goroutine
+	go func() {
+		ticker := time.NewTicker(p.refreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-p.ctx.Done():
+
+				return
+			case <-ticker.C:
+				p.mu.Lock()
+				symbols := p.symbols
+				versionVector := p.versionVector
+				p.mu.Unlock()
+
+				// Skip making a HTTP request if no symbols are set
+				if len(symbols) == 0 {
+
+					continue
+				}
+
+				// Get the asset quotes for all coin ids in batches from the API since cached quotes may be older than the refresh interval
+				assetQuotes, err := p.GetAssetQuotes(symbols, false)
+
+				if err != nil {
+					p.chanError <- err
+
+					continue
+				}
+
+				// Send the asset quotes to the update channel
+				for _, assetQuote := range assetQuotes {
+					p.chanUpdateAssetQuote <- c.MessageUpdate[c.AssetQuote]{
+						ID:            assetQuote.Meta.SymbolInSourceAPI,
+						Data:          assetQuote,
+						VersionVector: versionVector,
+					}
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// GetAssetQuotes gets quotes for coin ids from the shared cache when useSharedCache is set and a recent entry exists for
+// the same set of ids and otherwise fetches them in batches from the unary API and shares them through the cache
+func (p *Poller) GetAssetQuotes(ids []string, useSharedCache bool) ([]c.AssetQuote, error) {
+
+	cacheKey := cacheKeyQuotes + strings.Join(ids, ",")
+
+	if useSharedCache && p.cache != nil {
+		var cached []c.AssetQuote
+		if p.cache.Get(cacheKey, &cached) {
+			return cached, nil
+		}
+	}
+
+	assetQuotes, _, err := p.unaryAPI.GetAssetQuotes(ids)
+	if err != nil {
+		return []c.AssetQuote{}, err
+	}
+
+	if p.cache != nil && len(assetQuotes) > 0 {
+		p.cache.Set(cacheKey, assetQuotes, ttlQuotes)
+	}
+
+	return assetQuotes, nil
+}
+
+// Stop stops the poller
+func (p *Poller) Stop() error {
+	p.cancel()
+
+	return nil
+}
@@ -0,0 +1,298 @@
+package poller_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	g "github.com/onsi/gomega/gstruct"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	poller "github.com/achannarasappa/ticker/v5/internal/monitor/coingecko/monitor-price/poller"
+	unary "github.com/achannarasappa/ticker/v5/internal/monitor/coingecko/unary"
+)
+
+// cacheFake is an in-memory cache which records the time-to-live each key was set with
+type cacheFake struct {
+	mu     sync.Mutex
+	values map[string][]c.AssetQuote
+	ttls   map[string]time.Duration
+}
+
+func newCacheFake() *cacheFake {
+	return &cacheFake{
+		values: make(map[string][]c.AssetQuote),
+		ttls:   make(map[string]time.Duration),
+	}
+}
+
+func (f *cacheFake) Get(key string, out any) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	value, exists := f.values[key]
+	if exists {
+		*out.(*[]c.AssetQuote) = value
+	}
+
+	return exists
+}
+
+func (f *cacheFake) Set(key string, value any, ttl time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.values[key] = value.([]c.AssetQuote)
+	f.ttls[key] = ttl
+}
+
+func (f *cacheFake) getTTLs() map[string]time.Duration {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ttls := make(map[string]time.Duration)
+	for key, ttl := range f.ttls {
+		ttls[key] = ttl
+	}
+
+	return ttls
+}
+
+var _ = Describe("Poller", func() {
+	var (
+		server *ghttp.Server
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+
+		server.RouteToHandler("GET", "/api/v3/coins/markets",
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/api/v3/coins/markets", "ids=bitcoin&page=1&per_page=250&vs_currency=usd"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, []unary.ResponseQuote{
+					{
+						ID:           "bitcoin",
+						Symbol:       "btc",
+						Name:         "Bitcoin",
+						CurrentPrice: 50000,
+					},
+				}),
+			),
+		)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("NewPoller", func() {
+		It("should create a new poller instance", func() {
+			p := poller.NewPoller(context.Background(), poller.PollerConfig{
+				UnaryAPI:             unary.NewUnaryAPI(server.URL()),
+				ChanUpdateAssetQuote: make(chan c.MessageUpdate[c.AssetQuote], 5),
+			})
+			Expect(p).NotTo(BeNil())
+		})
+	})
+
+	Describe("Start", func() {
+		It("should start polling for price updates", func() {
+
+			inputChanUpdateAssetQuote := make(chan c.MessageUpdate[c.AssetQuote], 5)
+
+			p := poller.NewPoller(context.Background(), poller.PollerConfig{
+				UnaryAPI:             unary.NewUnaryAPI(server.URL()),
+				ChanUpdateAssetQuote: inputChanUpdateAssetQuote,
+			})
+			p.SetSymbols([]string{"bitcoin"}, 0)
+			p.SetRefreshInterval(time.Millisecond * 250)
+
+			err := p.Start()
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(inputChanUpdateAssetQuote).Should(Receive(
+				g.MatchFields(g.IgnoreExtras, g.Fields{
+					"ID": Equal("bitcoin"),
+					"Data": g.MatchFields(g.IgnoreExtras, g.Fields{
+						"QuotePrice": g.MatchFields(g.IgnoreExtras, g.Fields{
+							"Price": Equal(50000.00),
+						}),
+					}),
+				}),
+			))
+		})
+
+		When("the poller is already started", func() {
+			When("and the poller is started again", func() {
+				It("should return an error", func() {
+					p := poller.NewPoller(context.Background(), poller.PollerConfig{
+						UnaryAPI:             unary.NewUnaryAPI(server.URL()),
+						ChanUpdateAssetQuote: make(chan c.MessageUpdate[c.AssetQuote], 5),
+					})
+					p.SetSymbols([]string{"bitcoin"}, 0)
+					p.SetRefreshInterval(time.Second * 1)
+
+					err := p.Start()
+					Expect(err).NotTo(HaveOccurred())
+					err = p.Start()
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(Equal("poller already started"))
+				})
+			})
+
+			When("and the refresh interval is set again", func() {
+				It("should return an error", func() {
+					p := poller.NewPoller(context.Background(), poller.PollerConfig{
+						UnaryAPI:             unary.NewUnaryAPI(server.URL()),
+						ChanUpdateAssetQuote: make(chan c.MessageUpdate[c.AssetQuote], 5),
+					})
+					p.SetSymbols([]string{"bitcoin"}, 0)
+					p.SetRefreshInterval(time.Second * 1)
+
+					err := p.Start()
+					Expect(err).NotTo(HaveOccurred())
+					err = p.SetRefreshInterval(time.Second * 1)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(Equal("cannot set refresh interval while poller is started"))
+				})
+			})
+		})
+
+		When("the refresh interval is not set", func() {
+			It("should return an error", func() {
+				p := poller.NewPoller(context.Background(), poller.PollerConfig{
+					UnaryAPI:             unary.NewUnaryAPI(server.URL()),
+					ChanUpdateAssetQuote: make(chan c.MessageUpdate[c.AssetQuote], 5),
+				})
+				p.SetSymbols([]string{"bitcoin"}, 0)
+
+				err := p.Start()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("refresh interval is not set"))
+			})
+		})
+
+		When("a cache is set", func() {
+			It("should share the polled quotes through the cache for a minute", func() {
+				inputCache := newCacheFake()
+				inputChanUpdateAssetQuote := make(chan c.MessageUpdate[c.AssetQuote], 5)
+
+				p := poller.NewPoller(context.Background(), poller.PollerConfig{
+					UnaryAPI:             unary.NewUnaryAPI(server.URL()),
+					Cache:                inputCache,
+					ChanUpdateAssetQuote: inputChanUpdateAssetQuote,
+				})
+				p.SetSymbols([]string{"bitcoin"}, 0)
+				p.SetRefreshInterval(time.Millisecond * 250)
+
+				err := p.Start()
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(inputChanUpdateAssetQuote).Should(Receive())
+				Expect(inputCache.getTTLs()).To(Equal(map[string]time.Duration{"coingecko:quotes:bitcoin": time.Minute}))
+			})
+
+			When("quotes for the same ids are in the cache", func() {
+				It("should request new quotes rather than sending the cached quotes", func() {
+					inputCache := newCacheFake()
+					inputCache.Set("coingecko:quotes:bitcoin", []c.AssetQuote{
+						{
+							Symbol:     "BITCOIN.CG",
+							QuotePrice: c.QuotePrice{Price: 49000},
+							Meta:       c.Meta{SymbolInSourceAPI: "bitcoin"},
+						},
+					}, time.Minute)
+					inputChanUpdateAssetQuote := make(chan c.MessageUpdate[c.AssetQuote], 5)
+
+					p := poller.NewPoller(context.Background(), poller.PollerConfig{
+						UnaryAPI:             unary.NewUnaryAPI(server.URL()),
+						Cache:                inputCache,
+						ChanUpdateAssetQuote: inputChanUpdateAssetQuote,
+					})
+					p.SetSymbols([]string{"bitcoin"}, 0)
+					p.SetRefreshInterval(time.Millisecond * 250)
+
+					err := p.Start()
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(inputChanUpdateAssetQuote).Should(Receive(
+						g.MatchFields(g.IgnoreExtras, g.Fields{
+							"ID": Equal("bitcoin"),
+							"Data": g.MatchFields(g.IgnoreExtras, g.Fields{
+								"QuotePrice": g.MatchFields(g.IgnoreExtras, g.Fields{
+									"Price": Equal(50000.00),
+								}),
+							}),
+						}),
+					))
+					Expect(server.ReceivedRequests()).To(HaveLen(1))
+				})
+			})
+		})
+
+		When("the request fails", func() {
+			It("should send an error to the error channel", func() {
+				server.RouteToHandler("GET", "/api/v3/coins/markets",
+					ghttp.RespondWith(http.StatusTooManyRequests, ""),
+				)
+
+				inputChanError := make(chan error, 5)
+
+				p := poller.NewPoller(context.Background(), poller.PollerConfig{
+					UnaryAPI:             unary.NewUnaryAPI(server.URL()),
+					ChanUpdateAssetQuote: make(chan c.MessageUpdate[c.AssetQuote], 5),
+					ChanError:            inputChanError,
+				})
+				p.SetSymbols([]string{"bitcoin"}, 0)
+				p.SetRefreshInterval(time.Millisecond * 250)
+
+				err := p.Start()
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(inputChanError).Should(Receive(MatchError("request failed with status 429")))
+			})
+		})
+	})
+
+	Describe("GetAssetQuotes", func() {
+		When("the shared cache is used and another instance recently fetched quotes for the same ids", func() {
+			It("should return the cached quotes without making a request", func() {
+				inputCache := newCacheFake()
+				inputCache.Set("coingecko:quotes:bitcoin", []c.AssetQuote{
+					{
+						Symbol:     "BITCOIN.CG",
+						QuotePrice: c.QuotePrice{Price: 49000},
+						Meta:       c.Meta{SymbolInSourceAPI: "bitcoin"},
+					},
+				}, time.Minute)
+
+				p := poller.NewPoller(context.Background(), poller.PollerConfig{
+					UnaryAPI: unary.NewUnaryAPI(server.URL()),
+					Cache:    inputCache,
+				})
+
+				outputAssetQuotes, err := p.GetAssetQuotes([]string{"bitcoin"}, true)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(outputAssetQuotes).To(HaveLen(1))
+				Expect(outputAssetQuotes[0].QuotePrice.Price).To(Equal(49000.00))
+				Expect(server.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("Stop", func() {
+		It("should stop the poller", func() {
+			p := poller.NewPoller(context.Background(), poller.PollerConfig{
+				UnaryAPI:             unary.NewUnaryAPI(server.URL()),
+				ChanUpdateAssetQuote: make(chan c.MessageUpdate[c.AssetQuote], 5),
+			})
+
+			err := p.Stop()
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
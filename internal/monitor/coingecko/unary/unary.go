@@ -0,0 +1,161 @@
+package unary
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/metrics"
+)
+
+const (
+	// maxIdsPerRequest is the largest page size accepted by the markets endpoint
+	maxIdsPerRequest = 250
+	vsCurrency       = "usd"
+	symbolSuffix     = ".CG"
+)
+
+// ResponseQuote represents a quote of a single coin from the CoinGecko markets endpoint
+type ResponseQuote struct {
+	ID                       string  `json:"id"`
+	Symbol                   string  `json:"symbol"`
+	Name                     string  `json:"name"`
+	CurrentPrice             float64 `json:"current_price"`
+	MarketCap                float64 `json:"market_cap"`
+	TotalVolume              float64 `json:"total_volume"`
+	High24H                  float64 `json:"high_24h"`
+	Low24H                   float64 `json:"low_24h"`
+	PriceChange24H           float64 `json:"price_change_24h"`
+	PriceChangePercentage24H float64 `json:"price_change_percentage_24h"`
+	LastUpdated              string  `json:"last_updated"`
+}
+
+// UnaryAPI is a client for the CoinGecko API
+type UnaryAPI struct {
+	client  *http.Client
+	baseURL string
+}
+
+// NewUnaryAPI creates a new client
+func NewUnaryAPI(baseURL string) *UnaryAPI {
+	return &UnaryAPI{
+		client:  &http.Client{Transport: metrics.NewTransport("coingecko", nil)},
+		baseURL: baseURL,
+	}
+}
+
+// SymbolFromID returns the ticker symbol for a CoinGecko coin id (e.g. bitcoin -> BITCOIN.CG)
+func SymbolFromID(id string) string {
+	return strings.ToUpper(id) + symbolSuffix
+}
+
+func transformResponseQuote(responseQuote ResponseQuote) c.AssetQuote {
+
+	return c.AssetQuote{
+		Name:   responseQuote.Name,
+		Symbol: SymbolFromID(responseQuote.ID),
+		Class:  c.AssetClassCryptocurrency,
+		Currency: c.Currency{
+			FromCurrencyCode: strings.ToUpper(vsCurrency),
+		},
+		QuotePrice: c.QuotePrice{
+			Price:          responseQuote.CurrentPrice,
+			PricePrevClose: responseQuote.CurrentPrice - responseQuote.PriceChange24H,
+			PriceDayHigh:   responseQuote.High24H,
+			PriceDayLow:    responseQuote.Low24H,
+			Change:         responseQuote.PriceChange24H,
+			ChangePercent:  responseQuote.PriceChangePercentage24H,
+		},
+		QuoteExtended: c.QuoteExtended{
+			MarketCap: responseQuote.MarketCap,
+			Volume:    responseQuote.TotalVolume,
+		},
+		QuoteSource: c.QuoteSourceCoingecko,
+		Exchange: c.Exchange{
+			Name:                    "CoinGecko",
+			State:                   c.ExchangeStateOpen,
+			IsActive:                true,
+			IsRegularTradingSession: true, // Crypto markets are always in regular session
+		},
+		Meta: c.Meta{
+			IsVariablePrecision: true,
+			SymbolInSourceAPI:   responseQuote.ID,
+		},
+	}
+}
+
+func transformResponseQuotes(responseQuotes []ResponseQuote) ([]c.AssetQuote, map[string]*c.AssetQuote) {
+	quotes := make([]c.AssetQuote, 0, len(responseQuotes))
+	quotesByID := make(map[string]*c.AssetQuote, len(responseQuotes))
+
+	for _, responseQuote := range responseQuotes {
+		quote := transformResponseQuote(responseQuote)
+		quotes = append(quotes, quote)
+		quotesByID[quote.Meta.SymbolInSourceAPI] = &quote
+	}
+
+	return quotes, quotesByID
+}
+
+// GetAssetQuotes retrieves quotes for the given CoinGecko coin ids, splitting the ids into batches no larger than the API page size
+func (u *UnaryAPI) GetAssetQuotes(ids []string) ([]c.AssetQuote, map[string]*c.AssetQuote, error) {
+	if len(ids) == 0 {
+		return []c.AssetQuote{}, make(map[string]*c.AssetQuote), nil
+	}
+
+	responseQuotes := make([]ResponseQuote, 0, len(ids))
+
+	for start := 0; start < len(ids); start += maxIdsPerRequest {
+		end := min(start+maxIdsPerRequest, len(ids))
+
+		batch, err := u.getMarkets(ids[start:end])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		responseQuotes = append(responseQuotes, batch...)
+	}
+
+	quotes, quotesByID := transformResponseQuotes(responseQuotes)
+
+	return quotes, quotesByID, nil
+}
+
+func (u *UnaryAPI) getMarkets(ids []string) ([]ResponseQuote, error) {
+
+	reqURL, err := url.Parse(u.baseURL + "/api/v3/coins/markets")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	q := reqURL.Query()
+	q.Set("vs_currency", vsCurrency)
+	q.Set("ids", strings.Join(ids, ","))
+	q.Set("per_page", strconv.Itoa(maxIdsPerRequest))
+	q.Set("page", "1")
+	reqURL.RawQuery = q.Encode()
+
+	req, _ := http.NewRequest(http.MethodGet, reqURL.String(), nil)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request failed with status %d", resp.StatusCode)
+	}
+
+	var result []ResponseQuote
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,160 @@
+package unary_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/monitor/coingecko/unary"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Unary", func() {
+	var (
+		server *ghttp.Server
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("NewUnaryAPI", func() {
+		It("should return a new UnaryAPI", func() {
+			api := unary.NewUnaryAPI(server.URL())
+			Expect(api).NotTo(BeNil())
+		})
+	})
+
+	Describe("SymbolFromID", func() {
+		It("should return the uppercase id with the CoinGecko suffix", func() {
+			Expect(unary.SymbolFromID("bitcoin")).To(Equal("BITCOIN.CG"))
+		})
+	})
+
+	Describe("GetAssetQuotes", func() {
+		It("should return a list of asset quotes", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v3/coins/markets", "ids=bitcoin&page=1&per_page=250&vs_currency=usd"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, []unary.ResponseQuote{
+						{
+							ID:                       "bitcoin",
+							Symbol:                   "btc",
+							Name:                     "Bitcoin",
+							CurrentPrice:             50000,
+							MarketCap:                1000000000,
+							TotalVolume:              2000000,
+							High24H:                  51000,
+							Low24H:                   48000,
+							PriceChange24H:           1000,
+							PriceChangePercentage24H: 2.04,
+						},
+					}),
+				),
+			)
+
+			api := unary.NewUnaryAPI(server.URL())
+			quotes, quotesByID, err := api.GetAssetQuotes([]string{"bitcoin"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(quotes).To(HaveLen(1))
+			Expect(quotes[0].Symbol).To(Equal("BITCOIN.CG"))
+			Expect(quotes[0].Name).To(Equal("Bitcoin"))
+			Expect(quotes[0].Class).To(Equal(c.AssetClassCryptocurrency))
+			Expect(quotes[0].QuoteSource).To(Equal(c.QuoteSourceCoingecko))
+			Expect(quotes[0].Currency.FromCurrencyCode).To(Equal("USD"))
+			Expect(quotes[0].QuotePrice.Price).To(Equal(50000.0))
+			Expect(quotes[0].QuotePrice.PricePrevClose).To(Equal(49000.0))
+			Expect(quotes[0].QuotePrice.PriceDayHigh).To(Equal(51000.0))
+			Expect(quotes[0].QuotePrice.PriceDayLow).To(Equal(48000.0))
+			Expect(quotes[0].QuotePrice.Change).To(Equal(1000.0))
+			Expect(quotes[0].QuotePrice.ChangePercent).To(Equal(2.04))
+			Expect(quotes[0].QuoteExtended.MarketCap).To(Equal(1000000000.0))
+			Expect(quotes[0].QuoteExtended.Volume).To(Equal(2000000.0))
+			Expect(quotes[0].Meta.SymbolInSourceAPI).To(Equal("bitcoin"))
+			Expect(quotesByID).To(HaveKey("bitcoin"))
+		})
+
+		When("there are no ids", func() {
+			It("should return an empty list without making a request", func() {
+				api := unary.NewUnaryAPI(server.URL())
+				quotes, _, err := api.GetAssetQuotes([]string{})
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(quotes).To(BeEmpty())
+				Expect(server.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+
+		When("there are more ids than fit in a single request", func() {
+			It("should split the ids into multiple requests", func() {
+				ids := make([]string, 0, 300)
+				for i := range 300 {
+					ids = append(ids, fmt.Sprintf("coin-%d", i))
+				}
+
+				respondWithIDs := func(w http.ResponseWriter, r *http.Request) {
+					requestedIDs := strings.Split(r.URL.Query().Get("ids"), ",")
+					response := make([]unary.ResponseQuote, 0, len(requestedIDs))
+					for _, id := range requestedIDs {
+						response = append(response, unary.ResponseQuote{ID: id, CurrentPrice: 1})
+					}
+					ghttp.RespondWithJSONEncoded(http.StatusOK, response)(w, r)
+				}
+
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v3/coins/markets", "ids="+strings.Join(ids[:250], ",")+"&page=1&per_page=250&vs_currency=usd"),
+						respondWithIDs,
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v3/coins/markets", "ids="+strings.Join(ids[250:], ",")+"&page=1&per_page=250&vs_currency=usd"),
+						respondWithIDs,
+					),
+				)
+
+				api := unary.NewUnaryAPI(server.URL())
+				quotes, _, err := api.GetAssetQuotes(ids)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(quotes).To(HaveLen(300))
+				Expect(server.ReceivedRequests()).To(HaveLen(2))
+			})
+		})
+
+		When("the request fails", func() {
+			It("should return an error", func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusTooManyRequests, ""),
+				)
+
+				api := unary.NewUnaryAPI(server.URL())
+				_, _, err := api.GetAssetQuotes([]string{"bitcoin"})
+
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("request failed with status 429"))
+			})
+		})
+
+		When("the response is not valid JSON", func() {
+			It("should return an error", func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusOK, "invalid"),
+				)
+
+				api := unary.NewUnaryAPI(server.URL())
+				_, _, err := api.GetAssetQuotes([]string{"bitcoin"})
+
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed to decode response"))
+			})
+		})
+	})
+})
@@ -0,0 +1,363 @@
+package monitor
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+// failoverCheckInterval is the time between checks of whether symbols with alternative sources should switch sources
+const failoverCheckInterval = time.Second
+
+// defaultFailoverThreshold is how long a quote is stale before switching to an alternative source
+const defaultFailoverThreshold = time.Minute
+
+// failover tracks which source is serving quotes for a symbol with alternative sources
+type failover struct {
+	c.AssetGroupSymbolAlternatives
+	active      int    // Index of the alternative serving quotes or -1 if the source for the symbol is serving them
+	symbolQuote string // Symbol set on quotes from the alternative so they take the place of quotes from the source
+}
+
+// setFailovers resets the source serving quotes for each symbol with alternative sources to its own source
+func (m *Monitor) setFailovers(assetGroup c.AssetGroup) {
+	m.muFailover.Lock()
+	defer m.muFailover.Unlock()
+
+	m.failovers = make([]*failover, 0, len(assetGroup.SymbolAlternatives))
+
+	for _, symbolAlternatives := range assetGroup.SymbolAlternatives {
+		m.failovers = append(m.failovers, &failover{
+			AssetGroupSymbolAlternatives: symbolAlternatives,
+			active:                       -1,
+		})
+	}
+
+	m.isSymbolInAssetGroup = make(map[c.QuoteSource]map[string]bool)
+
+	for _, symbolsBySource := range assetGroup.SymbolsBySource {
+		m.isSymbolInAssetGroup[symbolsBySource.Source] = make(map[string]bool)
+
+		for _, symbol := range symbolsBySource.Symbols {
+			m.isSymbolInAssetGroup[symbolsBySource.Source][strings.ToLower(symbol)] = true
+		}
+	}
+}
+
+// setSymbolsSet records the symbols set on each monitor for an asset group and the monitors which failed to be set
+func (m *Monitor) setSymbolsSet(symbolsSetBySource map[c.QuoteSource][]string, setSymbolsFailedAt map[c.QuoteSource]time.Time, versionVector int) {
+	m.muFailover.Lock()
+	defer m.muFailover.Unlock()
+
+	m.symbolsSetBySource = symbolsSetBySource
+	m.setSymbolsFailedAt = setSymbolsFailedAt
+	m.symbolsSetVersionVector = versionVector
+}
+
+// handleFailovers periodically switches symbols with alternative sources to the first source which is not failing
+func (m *Monitor) handleFailovers() {
+
+	ticker := time.NewTicker(failoverCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+
+			return
+		case <-ticker.C:
+		case <-m.chanCheckFailovers:
+		}
+
+		m.checkFailovers(time.Now())
+	}
+}
+
+// checkFailovers switches the source serving quotes for each symbol with alternative sources if it is failing or has
+// recovered, sets symbols on monitors for alternatives which were switched to, and sends updated quotes if either
+// changed
+func (m *Monitor) checkFailovers(now time.Time) {
+
+	m.mu.RLock()
+	assetGroup := m.assetGroup
+	versionVector := m.assetGroupVersionVector
+	m.mu.RUnlock()
+
+	m.muFailover.RLock()
+	failovers := m.failovers
+	m.muFailover.RUnlock()
+
+	isSwitched := false
+
+	// The source serving each symbol is only switched here so it can be read without holding the lock while monitors
+	// are called
+	for _, f := range failovers {
+		m.muFailover.RLock()
+		activePrevious := f.active
+		m.muFailover.RUnlock()
+
+		active := m.getActiveAlternative(f, activePrevious, now)
+
+		if active == activePrevious {
+			continue
+		}
+
+		symbolQuote := f.Symbol
+		if active >= 0 {
+			symbolQuote = m.getSymbolQuote(f)
+		}
+
+		m.muFailover.Lock()
+		f.active = active
+		f.symbolQuote = symbolQuote
+		symbolSource := f.getSymbolSource()
+		m.muFailover.Unlock()
+
+		isSwitched = true
+
+		if m.logger != nil {
+			m.logger.Printf("quotes for %s are from %s", f.Symbol, sourceNames[symbolSource.Source])
+		}
+	}
+
+	isSymbolsSet := m.setChangedSymbols(m.getSymbolsBySource(assetGroup), versionVector, now)
+
+	if !isSwitched && !isSymbolsSet {
+		return
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	// Skip sending quotes if the asset group changed since the check started
+	if versionVector != m.assetGroupVersionVector {
+		return
+	}
+
+	assetGroupQuote := m.getAssetGroupQuote(assetGroup)
+
+	go m.onUpdateAssetGroupQuote(assetGroupQuote, versionVector)
+}
+
+// getActiveAlternative returns the index of the first alternative which is not failing if the source for a symbol is
+// failing or -1 if it is not. The current source is kept if all alternatives are failing.
+func (m *Monitor) getActiveAlternative(f *failover, active int, now time.Time) int {
+
+	if !m.isFailing(f.Source, now) {
+		return -1
+	}
+
+	for i, alternative := range f.Alternatives {
+		if !m.isFailing(alternative, now) {
+			return i
+		}
+	}
+
+	return active
+}
+
+// isFailing returns whether the quote for a symbol has been stale for longer than the failover threshold or has never
+// been received while requests to the source are failing
+func (m *Monitor) isFailing(symbolSource c.AssetGroupSymbolSource, now time.Time) bool {
+
+	status := m.getSourceStatus(symbolSource.Source)
+
+	m.muHealth.RLock()
+	lastUpdated := m.lastUpdateBySymbol[symbolSource.Source][strings.ToLower(symbolSource.Symbol)]
+	m.muHealth.RUnlock()
+
+	if !IsStale(lastUpdated, status) {
+		return false
+	}
+
+	return lastUpdated.IsZero() || now.Sub(status.FailingSince) >= m.failoverThreshold
+}
+
+// getSymbolQuote returns the symbol of the last quote from the source for a symbol or the symbol as set in the config
+// if no quote has been received from it
+func (m *Monitor) getSymbolQuote(f *failover) string {
+
+	if monitor, exists := m.monitors[f.Source.Source]; exists {
+		assetQuotes, _ := monitor.GetAssetQuotes()
+
+		for _, assetQuote := range assetQuotes {
+			if strings.EqualFold(assetQuote.Meta.SymbolInSourceAPI, f.Source.Symbol) {
+				return assetQuote.Symbol
+			}
+		}
+	}
+
+	return f.Symbol
+}
+
+// getSymbolsBySource returns the symbols in an asset group along with the alternatives serving quotes in place of
+// failing sources
+func (m *Monitor) getSymbolsBySource(assetGroup c.AssetGroup) []c.AssetGroupSymbolsBySource {
+	m.muFailover.RLock()
+	defer m.muFailover.RUnlock()
+
+	symbolsBySource := slices.Clone(assetGroup.SymbolsBySource)
+
+	for _, f := range m.failovers {
+		if f.active < 0 {
+			continue
+		}
+
+		alternative := f.Alternatives[f.active]
+		i := slices.IndexFunc(symbolsBySource, func(s c.AssetGroupSymbolsBySource) bool { return s.Source == alternative.Source })
+
+		if i < 0 {
+			symbolsBySource = append(symbolsBySource, c.AssetGroupSymbolsBySource{
+				Source:  alternative.Source,
+				Symbols: []string{alternative.Symbol},
+			})
+
+			continue
+		}
+
+		if !slices.Contains(symbolsBySource[i].Symbols, alternative.Symbol) {
+			// Clip so that the symbols in the asset group are not modified by the append
+			symbolsBySource[i].Symbols = append(slices.Clip(symbolsBySource[i].Symbols), alternative.Symbol)
+		}
+	}
+
+	return symbolsBySource
+}
+
+// setChangedSymbols sets symbols on each monitor which does not have them set yet and returns whether any were set.
+// Monitors which failed to be set are retried once the failover threshold has passed.
+func (m *Monitor) setChangedSymbols(symbolsBySource []c.AssetGroupSymbolsBySource, versionVector int, now time.Time) bool {
+	m.muSetSymbols.Lock()
+	defer m.muSetSymbols.Unlock()
+
+	isSet := false
+
+	for _, symbolBySource := range symbolsBySource {
+		monitor, exists := m.monitors[symbolBySource.Source]
+		if !exists {
+			continue
+		}
+
+		m.muFailover.RLock()
+		isCurrent := versionVector == m.symbolsSetVersionVector
+		isUnchanged := isSameSymbols(m.symbolsSetBySource[symbolBySource.Source], symbolBySource.Symbols)
+		failedAt, isFailed := m.setSymbolsFailedAt[symbolBySource.Source]
+		m.muFailover.RUnlock()
+
+		// Symbols from a previous asset group would replace those set for the current one
+		if !isCurrent {
+			return isSet
+		}
+
+		if isUnchanged || (isFailed && now.Sub(failedAt) < m.failoverThreshold) {
+			continue
+		}
+
+		// Monitors may reorder the symbols they are set with
+		err := monitor.SetSymbols(slices.Clone(symbolBySource.Symbols), versionVector)
+
+		m.muFailover.Lock()
+		if err != nil {
+			m.setSymbolsFailedAt[symbolBySource.Source] = now
+		} else {
+			m.symbolsSetBySource[symbolBySource.Source] = symbolBySource.Symbols
+			delete(m.setSymbolsFailedAt, symbolBySource.Source)
+		}
+		m.muFailover.Unlock()
+
+		if err != nil {
+			m.recordFailure(symbolBySource.Source, err, now)
+			m.logError(err)
+
+			continue
+		}
+
+		m.recordUpdate(symbolBySource.Source, symbolBySource.Symbols, now)
+		isSet = true
+	}
+
+	return isSet
+}
+
+// replaceFailedOverQuotes replaces quotes from failing sources with quotes from the alternative serving them which are
+// set with the symbol of the quote they replace. Quotes from alternatives which are not otherwise in the asset group
+// are dropped.
+func (m *Monitor) replaceFailedOverQuotes(assetQuotes []c.AssetQuote) []c.AssetQuote {
+	m.muFailover.RLock()
+	defer m.muFailover.RUnlock()
+
+	if len(m.failovers) == 0 {
+		return assetQuotes
+	}
+
+	out := make([]c.AssetQuote, 0, len(assetQuotes))
+
+	for _, assetQuote := range assetQuotes {
+		isReplaced := false
+		isAlternative := false
+
+		for _, f := range m.failovers {
+			if f.active < 0 {
+				continue
+			}
+
+			if isSymbolSource(assetQuote, f.Source) {
+				isReplaced = true
+			}
+
+			if isSymbolSource(assetQuote, f.Alternatives[f.active]) {
+				isAlternative = true
+
+				assetQuoteAlternative := assetQuote
+				assetQuoteAlternative.Symbol = f.symbolQuote
+				assetQuoteAlternative.Meta.AlternativeSourceName = sourceNames[assetQuote.QuoteSource]
+				out = append(out, assetQuoteAlternative)
+			}
+		}
+
+		if isReplaced || (isAlternative && !m.isSymbolInAssetGroup[assetQuote.QuoteSource][strings.ToLower(assetQuote.Meta.SymbolInSourceAPI)]) {
+			continue
+		}
+
+		out = append(out, assetQuote)
+	}
+
+	return out
+}
+
+// getSymbolSource returns the source serving quotes for the symbol
+func (f *failover) getSymbolSource() c.AssetGroupSymbolSource {
+
+	if f.active < 0 {
+		return f.Source
+	}
+
+	return f.Alternatives[f.active]
+}
+
+// isSameSymbols returns whether two lists of symbols have the same symbols in any order
+func isSameSymbols(a []string, b []string) bool {
+	return slices.Equal(slices.Sorted(slices.Values(a)), slices.Sorted(slices.Values(b)))
+}
+
+func isSymbolSource(assetQuote c.AssetQuote, symbolSource c.AssetGroupSymbolSource) bool {
+	return assetQuote.QuoteSource == symbolSource.Source && strings.EqualFold(assetQuote.Meta.SymbolInSourceAPI, symbolSource.Symbol)
+}
+
+// isFailoverAvailable returns whether every symbol from a source has alternative sources to switch to
+func isFailoverAvailable(assetGroup c.AssetGroup, source c.QuoteSource, symbols []string) bool {
+
+	for _, symbol := range symbols {
+		hasAlternatives := slices.ContainsFunc(assetGroup.SymbolAlternatives, func(symbolAlternatives c.AssetGroupSymbolAlternatives) bool {
+			return symbolAlternatives.Source.Source == source && strings.EqualFold(symbolAlternatives.Source.Symbol, symbol)
+		})
+
+		if !hasAlternatives {
+			return false
+		}
+	}
+
+	return true
+}
@@ -5,40 +5,116 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/achannarasappa/ticker/v5/internal/alert"
 	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/metrics"
 	monitorPriceCoinbase "github.com/achannarasappa/ticker/v5/internal/monitor/coinbase/monitor-price"
+	unaryClientCoinbase "github.com/achannarasappa/ticker/v5/internal/monitor/coinbase/unary"
+	monitorPriceCoinCap "github.com/achannarasappa/ticker/v5/internal/monitor/coincap/monitor-price"
+	monitorPriceCoingecko "github.com/achannarasappa/ticker/v5/internal/monitor/coingecko/monitor-price"
+	monitorPriceUserDefined "github.com/achannarasappa/ticker/v5/internal/monitor/user-defined/monitor-price"
 	monitorCurrencyRate "github.com/achannarasappa/ticker/v5/internal/monitor/yahoo/monitor-currency-rates"
 	monitorPriceYahoo "github.com/achannarasappa/ticker/v5/internal/monitor/yahoo/monitor-price"
 	unaryClientYahoo "github.com/achannarasappa/ticker/v5/internal/monitor/yahoo/unary"
+	"github.com/achannarasappa/ticker/v5/internal/notifier"
 )
 
 // Monitor represents an overall monitor which manages API specific monitors
 type Monitor struct {
 	monitors                map[c.QuoteSource]c.Monitor
 	monitorCurrencyRate     c.MonitorCurrencyRate
+	symbolSearchers         []symbolSearcher
+	priceHistoryGetters     map[c.QuoteSource]priceHistoryGetter
+	alertEvaluator          *alert.Evaluator
+	alertDispatcher         *notifier.Dispatcher
 	chanError               chan error
+	chanErrorBySource       map[c.QuoteSource]chan error
 	chanUpdateAssetQuote    chan c.MessageUpdate[c.AssetQuote]
 	chanUpdateCurrencyRates chan c.CurrencyRates
 	onUpdateAssetQuote      func(symbol string, assetQuote c.AssetQuote, versionVector int)
 	onUpdateAssetGroupQuote func(assetGroupQuote c.AssetGroupQuote, versionVector int)
+	onAlert                 func(alert c.Alert)
 	assetGroupVersionVector int
 	assetGroup              c.AssetGroup
+	currencyRates           c.CurrencyRates
+	healthBySource          map[c.QuoteSource]*sourceHealth
+	lastUpdateBySymbol      map[c.QuoteSource]map[string]time.Time
+	failovers               []*failover
+	failoverThreshold       time.Duration
+	isSymbolInAssetGroup    map[c.QuoteSource]map[string]bool
+	symbolsSetBySource      map[c.QuoteSource][]string  // Symbols last set on each monitor for the current asset group
+	setSymbolsFailedAt      map[c.QuoteSource]time.Time // Time setting symbols last failed for monitors which have not been set since
+	symbolsSetVersionVector int
+	chanCheckFailovers      chan struct{}
 	mu                      sync.RWMutex
+	muHealth                sync.RWMutex
+	muFailover              sync.RWMutex
+	muSetSymbols            sync.Mutex // Serializes setting symbols on monitors so those for an earlier asset group do not replace later ones
 	logger                  *log.Logger
 	ctx                     context.Context
 	cancel                  context.CancelFunc
 }
 
+// symbolSearcher finds symbols matching a query on a source
+type symbolSearcher interface {
+	SearchSymbols(query string) ([]c.SymbolSearchResult, error)
+}
+
+// priceHistoryGetter retrieves prices over a range for a symbol on a source
+type priceHistoryGetter interface {
+	GetPriceHistory(symbolInSourceAPI string, historyRange c.PriceHistoryRange) ([]c.PricePoint, error)
+}
+
+// sourceHealth tracks updates and errors from a source
+type sourceHealth struct {
+	lastUpdate   time.Time
+	lastFailure  time.Time
+	failingSince time.Time
+	failures     int
+	lastError    error
+}
+
+//nolint:gochecknoglobals
+var sourceNames = map[c.QuoteSource]string{
+	c.QuoteSourceYahoo:       "Yahoo",
+	c.QuoteSourceUserDefined: "User Defined",
+	c.QuoteSourceCoingecko:   "CoinGecko",
+	c.QuoteSourceCoinCap:     "CoinCap",
+	c.QuoteSourceCoinbase:    "Coinbase",
+}
+
+// statusGetter reports the state of polling requests to a source which limits requests after failures
+type statusGetter interface {
+	GetStatus() c.SourceStatus
+}
+
+// intradayPricesConcurrency is the maximum number of concurrent requests for intraday prices
+const intradayPricesConcurrency = 5
+
+// refreshIntervalClosed is the minimum time between requests for quotes from Yahoo Finance when the markets for all
+// symbols are closed
+const refreshIntervalClosed = 5 * time.Minute
+
 // ConfigMonitor represents the configuration for the main monitor
 type ConfigMonitor struct {
 	RefreshInterval int
 	TargetCurrency  string
 	Logger          *log.Logger
 	Cache           c.Cache
+	Alerts          []c.ConfigAlert
+	Notifiers       []c.ConfigNotifier
+	LotMatching     string
+	// FailoverThreshold is how long a quote is stale before switching to an alternative source for the symbol,
+	// defaults to 1m. Symbols without a quote from their source are switched as soon as the source is failing.
+	FailoverThreshold time.Duration
 	ConfigMonitorPriceCoinbase
+	ConfigMonitorPriceCoingecko
+	ConfigMonitorPriceCoinCap
+	ConfigMonitorPriceUserDefined
 	ConfigMonitorsYahoo
 }
 
@@ -48,24 +124,50 @@ type ConfigMonitorPriceCoinbase struct {
 	StreamingURL string
 }
 
+// ConfigMonitorPriceCoingecko represents the configuration for the CoinGecko monitor
+type ConfigMonitorPriceCoingecko struct {
+	BaseURL string
+}
+
+// ConfigMonitorPriceCoinCap represents the configuration for the CoinCap monitor
+type ConfigMonitorPriceCoinCap struct {
+	BaseURL      string
+	StreamingURL string
+	APIKey       string
+}
+
+// ConfigMonitorPriceUserDefined represents the configuration for the user defined price monitor
+type ConfigMonitorPriceUserDefined struct {
+	Prices []c.ConfigUserDefinedPrice
+}
+
 // ConfigMonitorsYahoo represents the configuration for the Yahoo monitors (price and currency rate)
 type ConfigMonitorsYahoo struct {
 	BaseURL           string
 	SessionRootURL    string
 	SessionCrumbURL   string
 	SessionConsentURL string
+	StreamingURL      string
 }
 
-// ConfigUpdateFns represents the callback functions for when asset quotes are updated
+// ConfigUpdateFns represents the callback functions for when asset quotes are updated and alerts are triggered
 type ConfigUpdateFns struct {
 	OnUpdateAssetQuote      func(symbol string, assetQuote c.AssetQuote, versionVector int)
 	OnUpdateAssetGroupQuote func(assetGroupQuote c.AssetGroupQuote, versionVector int)
+	OnAlert                 func(alert c.Alert) // Optional
 }
 
 // New creates a new instance of the Coinbase monitor
 func NewMonitor(configMonitor ConfigMonitor) (*Monitor, error) {
 
 	chanError := make(chan error, 5)
+	// Errors from each source are sent to a separate channel so they can be attributed to the source
+	chanErrorBySource := map[c.QuoteSource]chan error{
+		c.QuoteSourceCoinbase:  make(chan error, 5),
+		c.QuoteSourceCoingecko: make(chan error, 5),
+		c.QuoteSourceCoinCap:   make(chan error, 5),
+		c.QuoteSourceYahoo:     make(chan error, 5),
+	}
 	chanUpdateAssetQuote := make(chan c.MessageUpdate[c.AssetQuote], 10)
 	chanUpdateCurrencyRate := make(chan c.CurrencyRates, 10)
 	chanRequestCurrencyRate := make(chan []string, 10)
@@ -76,7 +178,7 @@ func NewMonitor(configMonitor ConfigMonitor) (*Monitor, error) {
 		monitorPriceCoinbase.Config{
 			Ctx:                      ctx,
 			UnaryURL:                 configMonitor.ConfigMonitorPriceCoinbase.BaseURL,
-			ChanError:                chanError,
+			ChanError:                chanErrorBySource[c.QuoteSourceCoinbase],
 			ChanUpdateAssetQuote:     chanUpdateAssetQuote,
 			ChanRequestCurrencyRates: chanRequestCurrencyRate,
 			Cache:                    configMonitor.Cache,
@@ -85,6 +187,38 @@ func NewMonitor(configMonitor ConfigMonitor) (*Monitor, error) {
 		monitorPriceCoinbase.WithRefreshInterval(time.Duration(configMonitor.RefreshInterval)*time.Second),
 	)
 
+	coingecko := monitorPriceCoingecko.NewMonitorPriceCoingecko(
+		monitorPriceCoingecko.Config{
+			Ctx:                      ctx,
+			UnaryURL:                 configMonitor.ConfigMonitorPriceCoingecko.BaseURL,
+			ChanError:                chanErrorBySource[c.QuoteSourceCoingecko],
+			ChanUpdateAssetQuote:     chanUpdateAssetQuote,
+			ChanRequestCurrencyRates: chanRequestCurrencyRate,
+			Cache:                    configMonitor.Cache,
+		},
+		monitorPriceCoingecko.WithRefreshInterval(time.Duration(configMonitor.RefreshInterval)*time.Second),
+	)
+
+	coincap := monitorPriceCoinCap.NewMonitorPriceCoinCap(
+		monitorPriceCoinCap.Config{
+			Ctx:                      ctx,
+			UnaryURL:                 configMonitor.ConfigMonitorPriceCoinCap.BaseURL,
+			APIKey:                   configMonitor.ConfigMonitorPriceCoinCap.APIKey,
+			ChanError:                chanErrorBySource[c.QuoteSourceCoinCap],
+			ChanUpdateAssetQuote:     chanUpdateAssetQuote,
+			ChanRequestCurrencyRates: chanRequestCurrencyRate,
+		},
+		monitorPriceCoinCap.WithStreamingURL(configMonitor.ConfigMonitorPriceCoinCap.StreamingURL),
+		monitorPriceCoinCap.WithRefreshInterval(time.Duration(configMonitor.RefreshInterval)*time.Second),
+	)
+
+	userDefined := monitorPriceUserDefined.NewMonitorPriceUserDefined(
+		monitorPriceUserDefined.Config{
+			Prices:                   configMonitor.ConfigMonitorPriceUserDefined.Prices,
+			ChanRequestCurrencyRates: chanRequestCurrencyRate,
+		},
+	)
+
 	// Create and configure the API client for the Yahoo API shared between monitors
 	unaryAPI := unaryClientYahoo.NewUnaryAPI(unaryClientYahoo.Config{
 		BaseURL:           configMonitor.ConfigMonitorsYahoo.BaseURL,
@@ -98,12 +232,14 @@ func NewMonitor(configMonitor ConfigMonitor) (*Monitor, error) {
 		monitorPriceYahoo.Config{
 			Ctx:                      ctx,
 			UnaryAPI:                 unaryAPI,
-			ChanError:                chanError,
+			ChanError:                chanErrorBySource[c.QuoteSourceYahoo],
 			ChanUpdateAssetQuote:     chanUpdateAssetQuote,
 			ChanRequestCurrencyRates: chanRequestCurrencyRate,
 			Cache:                    configMonitor.Cache,
 		},
+		monitorPriceYahoo.WithStreamingURL(configMonitor.ConfigMonitorsYahoo.StreamingURL),
 		monitorPriceYahoo.WithRefreshInterval(time.Duration(configMonitor.RefreshInterval)*time.Second),
+		monitorPriceYahoo.WithRefreshIntervalClosed(refreshIntervalClosed),
 	)
 
 	yahooCurrencyRate := monitorCurrencyRate.NewMonitorCurrencyRateYahoo(
@@ -119,20 +255,60 @@ func NewMonitor(configMonitor ConfigMonitor) (*Monitor, error) {
 
 	yahooCurrencyRate.SetTargetCurrency(configMonitor.TargetCurrency)
 
+	unaryCoinbase := unaryClientCoinbase.NewUnaryAPI(configMonitor.ConfigMonitorPriceCoinbase.BaseURL)
+
+	alertDispatcher, err := notifier.NewDispatcher(notifier.Config{
+		Notifiers: configMonitor.Notifiers,
+		Logger:    configMonitor.Logger,
+	})
+	if err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	if configMonitor.FailoverThreshold <= 0 {
+		configMonitor.FailoverThreshold = defaultFailoverThreshold
+	}
+
 	m := &Monitor{
 		monitors: map[c.QuoteSource]c.Monitor{
-			c.QuoteSourceCoinbase: coinbase,
-			c.QuoteSourceYahoo:    yahoo,
+			c.QuoteSourceCoinbase:    coinbase,
+			c.QuoteSourceCoinCap:     coincap,
+			c.QuoteSourceCoingecko:   coingecko,
+			c.QuoteSourceUserDefined: userDefined,
+			c.QuoteSourceYahoo:       yahoo,
 		},
 		monitorCurrencyRate:     yahooCurrencyRate,
 		chanUpdateAssetQuote:    chanUpdateAssetQuote,
 		chanUpdateCurrencyRates: chanUpdateCurrencyRate,
 		chanError:               chanError,
+		chanErrorBySource:       chanErrorBySource,
+		healthBySource:          make(map[c.QuoteSource]*sourceHealth),
+		lastUpdateBySymbol:      make(map[c.QuoteSource]map[string]time.Time),
+		failoverThreshold:       configMonitor.FailoverThreshold,
+		symbolsSetBySource:      make(map[c.QuoteSource][]string),
+		setSymbolsFailedAt:      make(map[c.QuoteSource]time.Time),
+		chanCheckFailovers:      make(chan struct{}, 1),
 		onUpdateAssetGroupQuote: func(assetGroupQuote c.AssetGroupQuote, versionVector int) {},
 		onUpdateAssetQuote:      func(symbol string, assetQuote c.AssetQuote, versionVector int) {},
+		onAlert:                 func(alert c.Alert) {},
 		logger:                  configMonitor.Logger,
 		ctx:                     ctx,
 		cancel:                  cancel,
+		symbolSearchers: []symbolSearcher{
+			unaryAPI,
+			unaryCoinbase,
+		},
+		priceHistoryGetters: map[c.QuoteSource]priceHistoryGetter{
+			c.QuoteSourceYahoo:    unaryAPI,
+			c.QuoteSourceCoinbase: unaryCoinbase,
+		},
+		alertEvaluator: alert.NewEvaluator(alert.Config{
+			Rules:       configMonitor.Alerts,
+			LotMatching: configMonitor.LotMatching,
+		}),
+		alertDispatcher: alertDispatcher,
 	}
 
 	return m, nil
@@ -149,23 +325,50 @@ func (m *Monitor) SetAssetGroup(assetGroup c.AssetGroup, versionVector int) erro
 	// Create a slice to collect errors
 	var errors []error
 
+	// Track which monitors were set so those which failed can be retried and alternatives set later
+	var muSymbolsSet sync.Mutex
+	symbolsSetBySource := make(map[c.QuoteSource][]string)
+	setSymbolsFailedAt := make(map[c.QuoteSource]time.Time)
+
+	m.muSetSymbols.Lock()
+
 	// Concurrently set symbols for each monitor (execute a synchronous call to update quotes for each monitor)
 	for _, symbolBySource := range assetGroup.SymbolsBySource {
 		if monitor, exists := m.monitors[symbolBySource.Source]; exists {
 			wg.Add(1)
-			go func(mon c.Monitor, symbols []string) {
+			go func(mon c.Monitor, source c.QuoteSource, symbols []string) {
 				defer wg.Done()
 				err := mon.SetSymbols(symbols, versionVector)
 				if err != nil {
-					chanError <- err
+					m.recordFailure(source, err, time.Now())
+
+					muSymbolsSet.Lock()
+					setSymbolsFailedAt[source] = time.Now()
+					muSymbolsSet.Unlock()
+
+					// Symbols which can be switched to an alternative source are not an error for the asset group
+					if !isFailoverAvailable(assetGroup, source, symbols) {
+						chanError <- err
+					}
+
+					return
 				}
-			}(monitor, symbolBySource.Symbols)
+
+				muSymbolsSet.Lock()
+				symbolsSetBySource[source] = symbols
+				muSymbolsSet.Unlock()
+
+				// Quotes for all symbols are requested when symbols are set
+				m.recordUpdate(source, symbols, time.Now())
+			}(monitor, symbolBySource.Source, symbolBySource.Symbols)
 		}
 	}
 
 	// Wait for the waitgroup to finish in the background
 	go func() {
 		wg.Wait()
+		m.setSymbolsSet(symbolsSetBySource, setSymbolsFailedAt, versionVector)
+		m.muSetSymbols.Unlock()
 		close(done)
 	}()
 
@@ -197,9 +400,19 @@ Continue:
 	// Update the versionVector so that any messages from the previous asset group can be ignored
 	m.assetGroupVersionVector = versionVector
 	m.assetGroup = assetGroup
+	m.alertEvaluator.SetLots(assetGroup.ConfigAssetGroup.Lots)
+	m.setFailovers(assetGroup)
+
+	// Check right away rather than on the next interval so that symbols from sources which failed are switched quickly
+	if len(assetGroup.SymbolAlternatives) > 0 {
+		select {
+		case m.chanCheckFailovers <- struct{}{}:
+		default:
+		}
+	}
 
 	// Get asset quotes for all sources
-	assetGroupQuote := m.GetAssetGroupQuote()
+	assetGroupQuote := m.getAssetGroupQuote(assetGroup)
 
 	// Run the callback in a goroutine to avoid blocking
 	go m.onUpdateAssetGroupQuote(assetGroupQuote, versionVector)
@@ -207,6 +420,110 @@ Continue:
 	return nil
 }
 
+// SearchSymbols finds symbols matching a query across all sources which support search. Results are ordered by source
+// and an error is only returned when every source fails.
+func (m *Monitor) SearchSymbols(query string) ([]c.SymbolSearchResult, error) {
+	var wg sync.WaitGroup
+
+	resultsBySearcher := make([][]c.SymbolSearchResult, len(m.symbolSearchers))
+	errorsBySearcher := make([]error, len(m.symbolSearchers))
+
+	for i, searcher := range m.symbolSearchers {
+		wg.Add(1)
+		go func(i int, searcher symbolSearcher) {
+			defer wg.Done()
+			resultsBySearcher[i], errorsBySearcher[i] = searcher.SearchSymbols(query)
+		}(i, searcher)
+	}
+
+	wg.Wait()
+
+	results := make([]c.SymbolSearchResult, 0)
+	errs := make([]error, 0)
+
+	for i, err := range errorsBySearcher {
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		results = append(results, resultsBySearcher[i]...)
+	}
+
+	if len(errs) == len(m.symbolSearchers) && len(errs) > 0 {
+		return nil, fmt.Errorf("failed to search symbols: %w", errors.Join(errs...))
+	}
+
+	// Log errors from sources which failed when results are available from others
+	if m.logger != nil {
+		for _, err := range errs {
+			m.logger.Printf("failed to search symbols: %v", err)
+		}
+	}
+
+	return results, nil
+}
+
+// GetIntradayPrices retrieves prices over the current trading session for each asset keyed by symbol. Assets from
+// sources without intraday prices and assets for which the request fails are omitted.
+func (m *Monitor) GetIntradayPrices(assets []c.Asset) map[string][]c.PricePoint {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	pricesBySymbol := make(map[string][]c.PricePoint)
+	semaphore := make(chan struct{}, intradayPricesConcurrency)
+
+	for _, asset := range assets {
+		getter, exists := m.priceHistoryGetters[asset.QuoteSource]
+
+		if !exists || asset.Meta.SymbolInSourceAPI == "" {
+			continue
+		}
+
+		wg.Add(1)
+		go func(symbol string, symbolInSourceAPI string) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			prices, err := getter.GetPriceHistory(symbolInSourceAPI, c.PriceHistoryRange1D)
+			<-semaphore
+
+			if err != nil {
+				if m.logger != nil {
+					m.logger.Printf("failed to get intraday prices for %s: %v", symbol, err)
+				}
+
+				return
+			}
+
+			mu.Lock()
+			pricesBySymbol[symbol] = prices
+			mu.Unlock()
+		}(asset.Symbol, asset.Meta.SymbolInSourceAPI)
+	}
+
+	wg.Wait()
+
+	return pricesBySymbol
+}
+
+// GetPriceHistory retrieves prices over a range for an asset
+func (m *Monitor) GetPriceHistory(asset c.Asset, historyRange c.PriceHistoryRange) ([]c.PricePoint, error) {
+	getter, exists := m.priceHistoryGetters[asset.QuoteSource]
+
+	if !exists || asset.Meta.SymbolInSourceAPI == "" {
+		return nil, fmt.Errorf("price history is not available for %s", asset.Symbol)
+	}
+
+	prices, err := getter.GetPriceHistory(asset.Meta.SymbolInSourceAPI, historyRange)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price history for %s: %w", asset.Symbol, err)
+	}
+
+	return prices, nil
+}
+
 // SetOnUpdate sets the callback functions for when asset quotes are updated
 func (m *Monitor) SetOnUpdate(config ConfigUpdateFns) error {
 
@@ -217,6 +534,10 @@ func (m *Monitor) SetOnUpdate(config ConfigUpdateFns) error {
 	m.onUpdateAssetQuote = config.OnUpdateAssetQuote
 	m.onUpdateAssetGroupQuote = config.OnUpdateAssetGroupQuote
 
+	if config.OnAlert != nil {
+		m.onAlert = config.OnAlert
+	}
+
 	return nil
 }
 
@@ -229,25 +550,73 @@ func (m *Monitor) Start() {
 		monitor.Start() //nolint:errcheck
 	}
 
+	for source, chanError := range m.chanErrorBySource {
+		go m.handleErrors(source, chanError)
+	}
+
 	go m.handleUpdates()
+	go m.handleFailovers()
 }
 
 // GetAssetGroupQuote synchronously gets price quotes a group of assets across all sources
 func (m *Monitor) GetAssetGroupQuote(ignoreCache ...bool) c.AssetGroupQuote {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.getAssetGroupQuote(m.assetGroup, ignoreCache...)
+}
+
+// getAssetGroupQuote gets price quotes for an asset group across all sources; the caller must hold the lock or own the
+// asset group
+func (m *Monitor) getAssetGroupQuote(assetGroup c.AssetGroup, ignoreCache ...bool) c.AssetGroupQuote {
 
 	assetQuotesFromAllSources := make([]c.AssetQuote, 0)
 
-	for _, symbolBySource := range m.assetGroup.SymbolsBySource {
+	for _, symbolBySource := range m.getSymbolsBySource(assetGroup) {
 
 		assetQuotes, _ := m.monitors[symbolBySource.Source].GetAssetQuotes(ignoreCache...)
 		assetQuotesFromAllSources = append(assetQuotesFromAllSources, assetQuotes...)
 
 	}
 
+	assetQuotesFromAllSources = m.replaceFailedOverQuotes(assetQuotesFromAllSources)
+
+	for i := range assetQuotesFromAllSources {
+		m.setFreshness(&assetQuotesFromAllSources[i])
+	}
+
 	return c.AssetGroupQuote{
 		AssetQuotes: assetQuotesFromAllSources,
-		AssetGroup:  m.assetGroup,
+		AssetGroup:  assetGroup,
+	}
+}
+
+// GetSourceStatuses returns the state of requests to each source in the current asset group
+func (m *Monitor) GetSourceStatuses() []c.SourceStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	statuses := make([]c.SourceStatus, 0)
+
+	// Sources of alternatives serving quotes in place of failing sources are included
+	for _, symbolBySource := range m.getSymbolsBySource(m.assetGroup) {
+		// User defined prices are set in the config rather than requested
+		if symbolBySource.Source == c.QuoteSourceUserDefined {
+			continue
+		}
+
+		statuses = append(statuses, m.getSourceStatus(symbolBySource.Source))
 	}
+
+	return statuses
+}
+
+// GetCurrencyRates returns the most recent currency rates used to convert quotes into the target currency
+func (m *Monitor) GetCurrencyRates() c.CurrencyRates {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.currencyRates
 }
 
 // handleUpdates listens for asset quote updates and errors from monitors
@@ -269,16 +638,35 @@ func (m *Monitor) handleUpdates() {
 			}
 			m.mu.RUnlock()
 
-			// Call the callback function for individual asset quote updates
-			go m.onUpdateAssetQuote(update.Data.Symbol, update.Data, update.VersionVector)
+			// Record the update from the source and mark the quote as fresh
+			now := time.Now()
+			m.recordUpdate(update.Data.QuoteSource, []string{update.Data.Meta.SymbolInSourceAPI}, now)
+			update.Data.Meta.LastUpdated = now
+			update.Data.Meta.IsStale = false
 
-		case err := <-m.chanError:
-			// Log errors using the configured logger if one is set
-			if m.logger != nil {
-				m.logger.Printf("%v", err)
+			// Quotes from alternatives take the place of quotes from failing sources
+			for _, assetQuote := range m.replaceFailedOverQuotes([]c.AssetQuote{update.Data}) {
+
+				// Call the callback function for individual asset quote updates
+				go m.onUpdateAssetQuote(assetQuote.Symbol, assetQuote, update.VersionVector)
+
+				// Check the updated quote against alert rules then call the callback and send to notifiers for each triggered alert
+				for _, triggeredAlert := range m.alertEvaluator.Evaluate(assetQuote, time.Now()) {
+					go m.onAlert(triggeredAlert)
+					m.alertDispatcher.Dispatch(triggeredAlert)
+				}
 			}
 
+		case err := <-m.chanError:
+			m.logError(err)
+
 		case currencyRates := <-m.chanUpdateCurrencyRates:
+			m.mu.Lock()
+			m.currencyRates = currencyRates
+			assetGroup := m.assetGroup
+			versionVector := m.assetGroupVersionVector
+			m.mu.Unlock()
+
 			// Set currency rates on each each monitor
 			for _, monitor := range m.monitors {
 				err := monitor.SetCurrencyRates(currencyRates)
@@ -288,14 +676,149 @@ func (m *Monitor) handleUpdates() {
 			}
 
 			// Get asset quotes for all sources with new currency rates
-			assetGroupQuote := m.GetAssetGroupQuote()
+			assetGroupQuote := m.getAssetGroupQuote(assetGroup)
 
 			// Callback with new asset quotes which include the new currency rates
-			go m.onUpdateAssetGroupQuote(assetGroupQuote, m.assetGroupVersionVector)
+			go m.onUpdateAssetGroupQuote(assetGroupQuote, versionVector)
 		}
 	}
 }
 
+// handleErrors listens for errors from a source and records them as failures of the source
+func (m *Monitor) handleErrors(source c.QuoteSource, chanError chan error) {
+	for {
+		select {
+		case <-m.ctx.Done():
+
+			return
+		case err := <-chanError:
+			m.recordFailure(source, err, time.Now())
+			m.logError(err)
+		}
+	}
+}
+
+func (m *Monitor) logError(err error) {
+
+	metrics.MonitorErrors.Inc()
+
+	// Log errors using the configured logger if one is set
+	if m.logger != nil {
+		m.logger.Printf("%v", err)
+	}
+}
+
+// recordUpdate records quotes for symbols received from a source which also ends any failures of the source
+func (m *Monitor) recordUpdate(source c.QuoteSource, symbolsInSourceAPI []string, now time.Time) {
+	m.muHealth.Lock()
+	defer m.muHealth.Unlock()
+
+	m.healthBySource[source] = &sourceHealth{lastUpdate: now}
+
+	if m.lastUpdateBySymbol[source] == nil {
+		m.lastUpdateBySymbol[source] = make(map[string]time.Time)
+	}
+
+	for _, symbol := range symbolsInSourceAPI {
+		m.lastUpdateBySymbol[source][strings.ToLower(symbol)] = now
+	}
+}
+
+// recordFailure records an error from a source
+func (m *Monitor) recordFailure(source c.QuoteSource, err error, now time.Time) {
+
+	polled, isPolled := m.getPolledStatus(source)
+
+	m.muHealth.Lock()
+	defer m.muHealth.Unlock()
+
+	h, exists := m.healthBySource[source]
+	if !exists {
+		h = &sourceHealth{}
+		m.healthBySource[source] = h
+	}
+
+	// A successful request since the last failure which did not change any prices also ends the failures
+	if h.failures == 0 || (isPolled && polled.LastUpdate.After(h.lastFailure)) {
+		h.failures = 0
+		h.failingSince = now
+	}
+
+	h.failures++
+	h.lastFailure = now
+	h.lastError = err
+}
+
+// getSourceStatus returns the state of requests to a source from the updates and errors received from it and the
+// successful requests which did not change any prices if the source polls
+func (m *Monitor) getSourceStatus(source c.QuoteSource) c.SourceStatus {
+
+	polled, isPolled := m.getPolledStatus(source)
+
+	m.muHealth.RLock()
+	defer m.muHealth.RUnlock()
+
+	status := c.SourceStatus{
+		Source:       source,
+		Name:         sourceNames[source],
+		BreakerState: c.BreakerStateClosed,
+	}
+
+	h, exists := m.healthBySource[source]
+	if exists {
+		status.LastUpdate = h.lastUpdate
+		status.Failures = h.failures
+		status.FailingSince = h.failingSince
+		status.LastError = h.lastError
+	}
+
+	if !isPolled {
+		return status
+	}
+
+	status.BreakerState = polled.BreakerState
+	status.RetryAt = polled.RetryAt
+
+	if polled.LastUpdate.After(status.LastUpdate) {
+		status.LastUpdate = polled.LastUpdate
+	}
+
+	if exists && polled.LastUpdate.After(h.lastFailure) {
+		status.Failures = 0
+		status.FailingSince = time.Time{}
+		status.LastError = nil
+	}
+
+	return status
+}
+
+func (m *Monitor) getPolledStatus(source c.QuoteSource) (c.SourceStatus, bool) {
+
+	getter, ok := m.monitors[source].(statusGetter)
+	if !ok {
+		return c.SourceStatus{}, false
+	}
+
+	return getter.GetStatus(), true
+}
+
+// setFreshness sets when a quote was last received and whether the source has been failing since
+func (m *Monitor) setFreshness(assetQuote *c.AssetQuote) {
+
+	status := m.getSourceStatus(assetQuote.QuoteSource)
+
+	m.muHealth.RLock()
+	assetQuote.Meta.LastUpdated = m.lastUpdateBySymbol[assetQuote.QuoteSource][strings.ToLower(assetQuote.Meta.SymbolInSourceAPI)]
+	m.muHealth.RUnlock()
+
+	assetQuote.Meta.IsStale = IsStale(assetQuote.Meta.LastUpdated, status)
+}
+
+// IsStale returns whether requests to a source have been failing since a quote from it was last received
+func IsStale(lastUpdated time.Time, status c.SourceStatus) bool {
+	return status.Failures > 0 && !lastUpdated.After(status.FailingSince)
+}
+
 // Stop stops all monitors and cancels the context
 func (m *Monitor) Stop() {
 
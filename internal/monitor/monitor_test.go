@@ -8,10 +8,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	g "github.com/onsi/gomega/gstruct"
 
 	c "github.com/achannarasappa/ticker/v5/internal/common"
 	"github.com/achannarasappa/ticker/v5/internal/monitor"
@@ -101,7 +104,7 @@ var _ = Describe("Monitor", func() {
 
 			It("should call the callback function", func() {
 
-				outputCallCountSingleAsset := 0
+				var outputCallCountSingleAsset atomic.Int32
 
 				// Set up mock responses
 				callCount := 0
@@ -170,7 +173,7 @@ var _ = Describe("Monitor", func() {
 				// Set the callback function
 				err = m.SetOnUpdate(monitor.ConfigUpdateFns{
 					OnUpdateAssetQuote: func(symbol string, assetQuote c.AssetQuote, versionVector int) {
-						outputCallCountSingleAsset++
+						outputCallCountSingleAsset.Add(1)
 					},
 					OnUpdateAssetGroupQuote: func(assetGroupQuote c.AssetGroupQuote, versionVector int) {},
 				})
@@ -180,15 +183,87 @@ var _ = Describe("Monitor", func() {
 				m.Start()
 
 				// Verify callback functions were called
-				Eventually(func() int {
-					return outputCallCountSingleAsset
-				}, 3*time.Second, 100*time.Millisecond).Should(Equal(1))
+				Eventually(outputCallCountSingleAsset.Load, 3*time.Second, 100*time.Millisecond).Should(Equal(int32(1)))
 
 				// Clean up
 				m.Stop()
 
 			})
 
+			When("an alert rule is met by the updated asset quote", func() {
+
+				It("should call the alert callback function", func() {
+
+					callCount := 0
+					serverYahoo.RouteToHandler("GET", "/v7/finance/quote",
+						func(w http.ResponseWriter, req *http.Request) {
+							callCount++
+							// Increment price for each call so that the rule threshold is passed after the first request
+							price := 150.00 + float64(callCount-1)*10.00
+
+							json.NewEncoder(w).Encode(unary.Response{
+								QuoteResponse: unary.ResponseQuoteResponse{
+									Quotes: []unary.ResponseQuote{
+										{
+											MarketState:                "REGULAR",
+											ShortName:                  "Apple Inc.",
+											RegularMarketPrice:         unary.ResponseFieldFloat{Raw: price, Fmt: fmt.Sprintf("%.2f", price)},
+											RegularMarketPreviousClose: unary.ResponseFieldFloat{Raw: 150.00, Fmt: "150.00"},
+											Symbol:                     "AAPL",
+										},
+									},
+								},
+							})
+						},
+					)
+
+					m, err := monitor.NewMonitor(monitor.ConfigMonitor{
+						RefreshInterval: 1,
+						Alerts: []c.ConfigAlert{
+							{Symbol: "AAPL", Condition: "above", Value: 155},
+						},
+						ConfigMonitorsYahoo: monitor.ConfigMonitorsYahoo{
+							BaseURL:           serverYahoo.URL(),
+							SessionRootURL:    serverYahoo.URL(),
+							SessionCrumbURL:   serverYahoo.URL(),
+							SessionConsentURL: serverYahoo.URL(),
+						},
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					err = m.SetAssetGroup(c.AssetGroup{
+						SymbolsBySource: []c.AssetGroupSymbolsBySource{
+							{
+								Source:  c.QuoteSourceYahoo,
+								Symbols: []string{"AAPL"},
+							},
+						},
+					}, 0)
+					Expect(err).NotTo(HaveOccurred())
+
+					outputAlerts := make(chan c.Alert, 10)
+					err = m.SetOnUpdate(monitor.ConfigUpdateFns{
+						OnUpdateAssetQuote:      func(symbol string, assetQuote c.AssetQuote, versionVector int) {},
+						OnUpdateAssetGroupQuote: func(assetGroupQuote c.AssetGroupQuote, versionVector int) {},
+						OnAlert: func(alert c.Alert) {
+							outputAlerts <- alert
+						},
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					m.Start()
+
+					var outputAlert c.Alert
+					Eventually(outputAlerts, 3*time.Second).Should(Receive(&outputAlert))
+					Expect(outputAlert.Symbol).To(Equal("AAPL"))
+					Expect(outputAlert.Message).To(HavePrefix("AAPL rose above 155.00 to "))
+
+					m.Stop()
+
+				})
+
+			})
+
 			When("the asset quote is outdated", func() {
 
 				PIt("should skip calling the callback")
@@ -339,6 +414,505 @@ var _ = Describe("Monitor", func() {
 
 	})
 
+	Describe("SearchSymbols", func() {
+
+		var m *monitor.Monitor
+
+		BeforeEach(func() {
+			m, _ = monitor.NewMonitor(monitor.ConfigMonitor{
+				ConfigMonitorPriceCoinbase: monitor.ConfigMonitorPriceCoinbase{
+					BaseURL: serverCoinbase.URL(),
+				},
+				ConfigMonitorsYahoo: monitor.ConfigMonitorsYahoo{
+					BaseURL: serverYahoo.URL(),
+				},
+			})
+
+			serverCoinbase.RouteToHandler("GET", "/api/v3/brokerage/market/products",
+				ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+					"products": []map[string]interface{}{
+						{"base_display_symbol": "SOL", "product_id": "SOL-USD", "base_name": "Solana", "quote_currency_id": "USD"},
+					},
+				}),
+			)
+		})
+
+		It("should return results from each source in order", func() {
+			serverYahoo.RouteToHandler("GET", "/v1/finance/search",
+				ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+					"quotes": []map[string]interface{}{
+						{"symbol": "SOL", "shortname": "Emeren Group Ltd", "isYahooFinance": true},
+					},
+				}),
+			)
+
+			results, err := m.SearchSymbols("sol")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(HaveLen(2))
+			Expect(results[0].Source).To(Equal(c.QuoteSourceYahoo))
+			Expect(results[1].Symbol).To(Equal("SOL.CB"))
+		})
+
+		When("a source fails", func() {
+			It("should return the results from the other sources", func() {
+				serverYahoo.RouteToHandler("GET", "/v1/finance/search", ghttp.RespondWith(http.StatusInternalServerError, ""))
+
+				results, err := m.SearchSymbols("sol")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Symbol).To(Equal("SOL.CB"))
+			})
+		})
+
+		When("all sources fail", func() {
+			It("should return an error", func() {
+				serverYahoo.RouteToHandler("GET", "/v1/finance/search", ghttp.RespondWith(http.StatusInternalServerError, ""))
+				serverCoinbase.RouteToHandler("GET", "/api/v3/brokerage/market/products", ghttp.RespondWith(http.StatusInternalServerError, ""))
+
+				_, err := m.SearchSymbols("sol")
+				Expect(err).To(MatchError(ContainSubstring("failed to search symbols")))
+			})
+		})
+	})
+
+	Describe("GetSourceStatuses", func() {
+		It("should return the state of requests to each source in the asset group", func() {
+			setupCoinbaseMockHandler(serverCoinbase)
+			setupYahooMockHandler(serverYahoo)
+
+			m, _ := monitor.NewMonitor(monitor.ConfigMonitor{
+				ConfigMonitorPriceCoinbase: monitor.ConfigMonitorPriceCoinbase{
+					BaseURL: serverCoinbase.URL(),
+				},
+				ConfigMonitorsYahoo: monitor.ConfigMonitorsYahoo{
+					BaseURL:           serverYahoo.URL(),
+					SessionRootURL:    serverYahoo.URL(),
+					SessionCrumbURL:   serverYahoo.URL(),
+					SessionConsentURL: serverYahoo.URL(),
+				},
+			})
+
+			m.SetAssetGroup(c.AssetGroup{
+				SymbolsBySource: []c.AssetGroupSymbolsBySource{
+					{
+						Source:  c.QuoteSourceYahoo,
+						Symbols: []string{"AAPL"},
+					},
+					{
+						Source:  c.QuoteSourceUserDefined,
+						Symbols: []string{"PRIVATE"},
+					},
+					{
+						Source:  c.QuoteSourceCoinbase,
+						Symbols: []string{"BTC-USD"},
+					},
+				},
+			}, 0)
+
+			Expect(m.GetSourceStatuses()).To(ConsistOf(
+				g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Source":       Equal(c.QuoteSourceYahoo),
+					"Name":         Equal("Yahoo"),
+					"BreakerState": Equal(c.BreakerStateClosed),
+					"Failures":     Equal(0),
+					"LastUpdate":   BeTemporally("~", time.Now(), time.Second),
+				}),
+				g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Source":     Equal(c.QuoteSourceCoinbase),
+					"Name":       Equal("Coinbase"),
+					"Failures":   Equal(0),
+					"LastUpdate": BeTemporally("~", time.Now(), time.Second),
+				}),
+			))
+		})
+
+		When("requests to a source fail", func() {
+			It("should return the failures and mark quotes from the source as stale", func() {
+				var isFailing atomic.Bool
+				serverYahoo.RouteToHandler("GET", "/v7/finance/quote", func(w http.ResponseWriter, req *http.Request) {
+					if isFailing.Load() {
+						w.WriteHeader(http.StatusTooManyRequests)
+
+						return
+					}
+
+					json.NewEncoder(w).Encode(unary.Response{
+						QuoteResponse: unary.ResponseQuoteResponse{
+							Quotes: []unary.ResponseQuote{
+								{
+									MarketState:        "REGULAR",
+									ShortName:          "Apple Inc.",
+									RegularMarketPrice: unary.ResponseFieldFloat{Raw: 150.00, Fmt: "150.00"},
+									Symbol:             "AAPL",
+								},
+							},
+						},
+					})
+				})
+
+				m, _ := monitor.NewMonitor(monitor.ConfigMonitor{
+					RefreshInterval: 1,
+					ConfigMonitorsYahoo: monitor.ConfigMonitorsYahoo{
+						BaseURL:           serverYahoo.URL(),
+						SessionRootURL:    serverYahoo.URL(),
+						SessionCrumbURL:   serverYahoo.URL(),
+						SessionConsentURL: serverYahoo.URL(),
+					},
+				})
+
+				err := m.SetAssetGroup(c.AssetGroup{
+					SymbolsBySource: []c.AssetGroupSymbolsBySource{
+						{
+							Source:  c.QuoteSourceYahoo,
+							Symbols: []string{"AAPL"},
+						},
+					},
+				}, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(m.GetAssetGroupQuote().AssetQuotes[0].Meta.IsStale).To(BeFalse())
+
+				m.Start()
+				isFailing.Store(true)
+
+				Eventually(func() int {
+					return m.GetSourceStatuses()[0].Failures
+				}, 3*time.Second, 100*time.Millisecond).Should(Equal(1))
+
+				status := m.GetSourceStatuses()[0]
+				Expect(status.LastError).To(MatchError(ContainSubstring("429")))
+				Expect(status.RetryAt).To(BeTemporally(">", time.Now()))
+
+				assetQuote := m.GetAssetGroupQuote().AssetQuotes[0]
+				Expect(assetQuote.Meta.IsStale).To(BeTrue())
+				Expect(assetQuote.Meta.LastUpdated).To(BeTemporally("<", status.FailingSince))
+
+				m.Stop()
+			})
+		})
+	})
+
+	Describe("IsStale", func() {
+		DescribeTable("should return whether the source has been failing since the quote was last received",
+			func(lastUpdated time.Time, status c.SourceStatus, expected bool) {
+				Expect(monitor.IsStale(lastUpdated, status)).To(Equal(expected))
+			},
+			Entry("source is not failing", time.Unix(100, 0), c.SourceStatus{}, false),
+			Entry("quote received before the failures", time.Unix(100, 0), c.SourceStatus{Failures: 2, FailingSince: time.Unix(200, 0)}, true),
+			Entry("quote received after the failures started", time.Unix(300, 0), c.SourceStatus{Failures: 2, FailingSince: time.Unix(200, 0)}, false),
+		)
+	})
+
+	Describe("GetIntradayPrices", func() {
+		It("should return prices for assets from sources with intraday prices", func() {
+			m, _ := monitor.NewMonitor(monitor.ConfigMonitor{
+				ConfigMonitorPriceCoinbase: monitor.ConfigMonitorPriceCoinbase{
+					BaseURL: serverCoinbase.URL(),
+				},
+				ConfigMonitorsYahoo: monitor.ConfigMonitorsYahoo{
+					BaseURL: serverYahoo.URL(),
+				},
+			})
+
+			serverYahoo.RouteToHandler("GET", "/v8/finance/chart/NET",
+				ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+					"chart": map[string]interface{}{
+						"result": []map[string]interface{}{
+							{
+								"timestamp":  []int64{1700000000},
+								"indicators": map[string]interface{}{"quote": []map[string]interface{}{{"close": []float64{84.5}}}},
+							},
+						},
+					},
+				}),
+			)
+			serverCoinbase.RouteToHandler("GET", "/api/v3/brokerage/market/products/SOL-USD/candles", ghttp.RespondWith(http.StatusInternalServerError, ""))
+
+			outputPrices := m.GetIntradayPrices([]c.Asset{
+				{Symbol: "NET", QuoteSource: c.QuoteSourceYahoo, Meta: c.Meta{SymbolInSourceAPI: "NET"}},
+				{Symbol: "SOL.CB", QuoteSource: c.QuoteSourceCoinbase, Meta: c.Meta{SymbolInSourceAPI: "SOL-USD"}},
+				{Symbol: "PRIVATE", QuoteSource: c.QuoteSourceUserDefined, Meta: c.Meta{SymbolInSourceAPI: "PRIVATE"}},
+			})
+
+			Expect(outputPrices).To(Equal(map[string][]c.PricePoint{
+				"NET": {{Time: 1700000000, Price: 84.5}},
+			}))
+		})
+	})
+
+	Describe("failover", func() {
+
+		var (
+			isFailingCoinbase atomic.Bool
+			isFailingYahoo    atomic.Bool
+		)
+
+		newMonitor := func() *monitor.Monitor {
+			m, err := monitor.NewMonitor(monitor.ConfigMonitor{
+				RefreshInterval:   1,
+				FailoverThreshold: 500 * time.Millisecond,
+				ConfigMonitorPriceCoinbase: monitor.ConfigMonitorPriceCoinbase{
+					BaseURL: serverCoinbase.URL(),
+				},
+				ConfigMonitorsYahoo: monitor.ConfigMonitorsYahoo{
+					BaseURL:           serverYahoo.URL(),
+					SessionRootURL:    serverYahoo.URL(),
+					SessionCrumbURL:   serverYahoo.URL(),
+					SessionConsentURL: serverYahoo.URL(),
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			return m
+		}
+
+		getAssetQuote := func(m *monitor.Monitor) c.AssetQuote {
+			assetQuotes := m.GetAssetGroupQuote().AssetQuotes
+			if len(assetQuotes) != 1 {
+				return c.AssetQuote{}
+			}
+
+			return assetQuotes[0]
+		}
+
+		BeforeEach(func() {
+			isFailingCoinbase.Store(false)
+			isFailingYahoo.Store(false)
+
+			serverCoinbase.RouteToHandler("GET", "/api/v3/brokerage/market/products", func(w http.ResponseWriter, req *http.Request) {
+				if isFailingCoinbase.Load() {
+					w.WriteHeader(http.StatusInternalServerError)
+
+					return
+				}
+
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"products": []map[string]interface{}{
+						{
+							"base_display_symbol": "BTC",
+							"product_id":          "BTC-USD",
+							"base_name":           "Bitcoin",
+							"price":               "50000.00",
+							"quote_currency_id":   "USD",
+							"product_type":        "SPOT",
+						},
+					},
+				})
+			})
+
+			serverYahoo.RouteToHandler("GET", "/v7/finance/quote", func(w http.ResponseWriter, req *http.Request) {
+				if isFailingYahoo.Load() {
+					w.WriteHeader(http.StatusTooManyRequests)
+
+					return
+				}
+
+				json.NewEncoder(w).Encode(unary.Response{
+					QuoteResponse: unary.ResponseQuoteResponse{
+						Quotes: []unary.ResponseQuote{
+							{
+								MarketState:        "REGULAR",
+								ShortName:          "Bitcoin USD",
+								RegularMarketPrice: unary.ResponseFieldFloat{Raw: 50010.00, Fmt: "50010.00"},
+								Symbol:             "BTC-USD",
+								Currency:           "USD",
+							},
+						},
+					},
+				})
+			})
+		})
+
+		When("the source for a symbol fails before any quote is received from it", func() {
+
+			It("should switch to the alternative source right away and back once the source recovers", func() {
+				isFailingCoinbase.Store(true)
+
+				m := newMonitor()
+
+				var assetGroupQuote atomic.Value
+				m.SetOnUpdate(monitor.ConfigUpdateFns{ //nolint:errcheck
+					OnUpdateAssetQuote: func(symbol string, assetQuote c.AssetQuote, versionVector int) {},
+					OnUpdateAssetGroupQuote: func(agq c.AssetGroupQuote, versionVector int) {
+						assetGroupQuote.Store(agq)
+					},
+				})
+
+				err := m.SetAssetGroup(c.AssetGroup{
+					SymbolsBySource: []c.AssetGroupSymbolsBySource{
+						{Source: c.QuoteSourceCoinbase, Symbols: []string{"BTC-USD"}},
+					},
+					SymbolAlternatives: []c.AssetGroupSymbolAlternatives{
+						{
+							Symbol: "BTC.X",
+							Source: c.AssetGroupSymbolSource{Symbol: "BTC-USD", Source: c.QuoteSourceCoinbase},
+							Alternatives: []c.AssetGroupSymbolSource{
+								{Symbol: "BTC-USD", Source: c.QuoteSourceYahoo},
+							},
+						},
+					},
+				}, 0)
+				Expect(err).NotTo(HaveOccurred())
+
+				m.Start()
+				defer m.Stop()
+
+				Eventually(func() c.AssetQuote {
+					return getAssetQuote(m)
+				}, 3*time.Second, 50*time.Millisecond).Should(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Symbol":      Equal("BTC.X"),
+					"QuoteSource": Equal(c.QuoteSourceYahoo),
+					"Meta": g.MatchFields(g.IgnoreExtras, g.Fields{
+						"AlternativeSourceName": Equal("Yahoo"),
+					}),
+				}))
+
+				Eventually(func() []c.AssetQuote {
+					agq, _ := assetGroupQuote.Load().(c.AssetGroupQuote)
+
+					return agq.AssetQuotes
+				}, time.Second, 50*time.Millisecond).Should(ContainElement(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Symbol":      Equal("BTC.X"),
+					"QuoteSource": Equal(c.QuoteSourceYahoo),
+				})))
+
+				Expect(m.GetSourceStatuses()).To(ContainElement(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Source": Equal(c.QuoteSourceYahoo),
+				})))
+
+				isFailingCoinbase.Store(false)
+
+				Eventually(func() c.AssetQuote {
+					return getAssetQuote(m)
+				}, 3*time.Second, 50*time.Millisecond).Should(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Symbol":      Equal("BTC.CB"),
+					"QuoteSource": Equal(c.QuoteSourceCoinbase),
+					"Meta": g.MatchFields(g.IgnoreExtras, g.Fields{
+						"AlternativeSourceName": BeEmpty(),
+					}),
+				}))
+			})
+
+		})
+
+		When("the quote for a symbol is stale for longer than the failover threshold", func() {
+
+			It("should switch to the alternative source with the symbol of the quote it replaces", func() {
+				m := newMonitor()
+
+				err := m.SetAssetGroup(c.AssetGroup{
+					SymbolsBySource: []c.AssetGroupSymbolsBySource{
+						{Source: c.QuoteSourceYahoo, Symbols: []string{"BTC-USD"}},
+					},
+					SymbolAlternatives: []c.AssetGroupSymbolAlternatives{
+						{
+							Symbol: "BTC.X",
+							Source: c.AssetGroupSymbolSource{Symbol: "BTC-USD", Source: c.QuoteSourceYahoo},
+							Alternatives: []c.AssetGroupSymbolSource{
+								{Symbol: "BTC-USD", Source: c.QuoteSourceCoinbase},
+							},
+						},
+					},
+				}, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(getAssetQuote(m).QuoteSource).To(Equal(c.QuoteSourceYahoo))
+
+				m.Start()
+				defer m.Stop()
+
+				isFailingYahoo.Store(true)
+
+				Eventually(func() bool {
+					return getAssetQuote(m).Meta.IsStale
+				}, 3*time.Second, 50*time.Millisecond).Should(BeTrue())
+
+				Consistently(func() c.QuoteSource {
+					return getAssetQuote(m).QuoteSource
+				}, 300*time.Millisecond, 50*time.Millisecond).Should(Equal(c.QuoteSourceYahoo))
+
+				Eventually(func() c.AssetQuote {
+					return getAssetQuote(m)
+				}, 3*time.Second, 50*time.Millisecond).Should(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Symbol":      Equal("BTC-USD"),
+					"QuoteSource": Equal(c.QuoteSourceCoinbase),
+					"Meta": g.MatchFields(g.IgnoreExtras, g.Fields{
+						"AlternativeSourceName": Equal("Coinbase"),
+						"IsStale":               BeFalse(),
+					}),
+				}))
+			})
+
+		})
+
+		When("the alternative source is polling other symbols while switching to and from it", func() {
+
+			It("should keep polling the other symbols along with the symbol switched to it", func() {
+				serverYahoo.RouteToHandler("GET", "/v7/finance/quote", func(w http.ResponseWriter, req *http.Request) {
+					quotes := make([]unary.ResponseQuote, 0)
+					for _, symbol := range strings.Split(req.URL.Query().Get("symbols"), ",") {
+						quotes = append(quotes, unary.ResponseQuote{
+							MarketState:        "REGULAR",
+							ShortName:          symbol,
+							RegularMarketPrice: unary.ResponseFieldFloat{Raw: 100.00, Fmt: "100.00"},
+							Symbol:             symbol,
+							Currency:           "USD",
+						})
+					}
+
+					json.NewEncoder(w).Encode(unary.Response{ //nolint:errcheck
+						QuoteResponse: unary.ResponseQuoteResponse{Quotes: quotes},
+					})
+				})
+
+				isFailingCoinbase.Store(true)
+
+				m := newMonitor()
+
+				err := m.SetAssetGroup(c.AssetGroup{
+					SymbolsBySource: []c.AssetGroupSymbolsBySource{
+						{Source: c.QuoteSourceCoinbase, Symbols: []string{"BTC-USD"}},
+						{Source: c.QuoteSourceYahoo, Symbols: []string{"ETH-USD"}},
+					},
+					SymbolAlternatives: []c.AssetGroupSymbolAlternatives{
+						{
+							Symbol: "BTC.X",
+							Source: c.AssetGroupSymbolSource{Symbol: "BTC-USD", Source: c.QuoteSourceCoinbase},
+							Alternatives: []c.AssetGroupSymbolSource{
+								{Symbol: "BTC-USD", Source: c.QuoteSourceYahoo},
+							},
+						},
+					},
+				}, 0)
+				Expect(err).NotTo(HaveOccurred())
+
+				m.Start()
+				defer m.Stop()
+
+				getSymbolsBySource := func() map[string]c.QuoteSource {
+					symbolsBySource := make(map[string]c.QuoteSource)
+					for _, assetQuote := range m.GetAssetGroupQuote().AssetQuotes {
+						symbolsBySource[assetQuote.Symbol] = assetQuote.QuoteSource
+					}
+
+					return symbolsBySource
+				}
+
+				Eventually(getSymbolsBySource, 3*time.Second, 50*time.Millisecond).Should(Equal(map[string]c.QuoteSource{
+					"BTC.X":   c.QuoteSourceYahoo,
+					"ETH-USD": c.QuoteSourceYahoo,
+				}))
+
+				isFailingCoinbase.Store(false)
+
+				Eventually(getSymbolsBySource, 3*time.Second, 50*time.Millisecond).Should(Equal(map[string]c.QuoteSource{
+					"BTC.CB":  c.QuoteSourceCoinbase,
+					"ETH-USD": c.QuoteSourceYahoo,
+				}))
+			})
+
+		})
+
+	})
+
 	Describe("SetAssetGroup", func() {
 
 		When("there is an error setting symbols for a monitor", func() {
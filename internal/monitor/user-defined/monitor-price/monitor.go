@@ -0,0 +1,236 @@
+package monitorPriceUserDefined
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+const (
+	defaultCurrencyCode = "USD"
+	exchangeName        = "User Defined"
+	cashSymbolSuffix    = ".CASH"
+)
+
+// MonitorPriceUserDefined serves quotes for prices set manually in config for assets without a market data source and
+// for cash balances which are quoted by currency (e.g. EUR.CASH) at a fixed price of one unit of that currency
+type MonitorPriceUserDefined struct {
+	pricesBySymbol           map[string]c.ConfigUserDefinedPrice // Configured prices by uppercase symbol
+	symbols                  []string
+	currencyRatesCache       c.CurrencyRates
+	mu                       sync.RWMutex
+	isStarted                bool
+	chanRequestCurrencyRates chan []string
+}
+
+// Config contains the required configuration for the user defined price monitor
+type Config struct {
+	Prices                   []c.ConfigUserDefinedPrice
+	ChanRequestCurrencyRates chan []string
+}
+
+// NewMonitorPriceUserDefined creates a new user defined price monitor
+func NewMonitorPriceUserDefined(config Config) *MonitorPriceUserDefined {
+	pricesBySymbol := make(map[string]c.ConfigUserDefinedPrice, len(config.Prices))
+	for _, price := range config.Prices {
+		pricesBySymbol[strings.ToUpper(price.Symbol)] = price
+	}
+
+	return &MonitorPriceUserDefined{
+		pricesBySymbol:           pricesBySymbol,
+		symbols:                  make([]string, 0),
+		chanRequestCurrencyRates: config.ChanRequestCurrencyRates,
+	}
+}
+
+// GetAssetQuotes returns quotes for the configured prices of the current symbols. Prices do not change while running so
+// ignoreCache has no effect.
+func (m *MonitorPriceUserDefined) GetAssetQuotes(_ ...bool) ([]c.AssetQuote, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	assetQuotes := make([]c.AssetQuote, 0, len(m.symbols))
+
+	for _, symbol := range m.symbols {
+		var assetQuote c.AssetQuote
+
+		if price, exists := m.pricesBySymbol[symbol]; exists {
+			assetQuote = transformPrice(price)
+		} else {
+			assetQuote = transformCash(symbol)
+		}
+
+		if currencyRate, exists := m.currencyRatesCache[assetQuote.Currency.FromCurrencyCode]; exists {
+			assetQuote.Currency.Rate = currencyRate.Rate
+			assetQuote.Currency.ToCurrencyCode = currencyRate.ToCurrency
+		}
+
+		assetQuotes = append(assetQuotes, assetQuote)
+	}
+
+	return assetQuotes, nil
+}
+
+// SetSymbols sets the symbols to serve quotes for and requests currency rates for their currencies
+func (m *MonitorPriceUserDefined) SetSymbols(symbols []string, _ int) error {
+	m.mu.Lock()
+
+	m.symbols = make([]string, 0, len(symbols))
+	fromCurrenciesToRequest := make([]string, 0)
+
+	for _, symbol := range symbols {
+		symbol = strings.ToUpper(symbol)
+
+		if price, exists := m.pricesBySymbol[symbol]; exists {
+			m.symbols = append(m.symbols, symbol)
+			fromCurrenciesToRequest = append(fromCurrenciesToRequest, getCurrencyCode(price))
+
+			continue
+		}
+
+		if currencyCode, isCash := getCashCurrencyCode(symbol); isCash {
+			m.symbols = append(m.symbols, symbol)
+			fromCurrenciesToRequest = append(fromCurrenciesToRequest, currencyCode)
+		}
+	}
+
+	m.mu.Unlock()
+
+	if len(fromCurrenciesToRequest) > 0 {
+		m.chanRequestCurrencyRates <- fromCurrenciesToRequest
+	}
+
+	return nil
+}
+
+// SetCurrencyRates sets the currency rates used to convert prices
+func (m *MonitorPriceUserDefined) SetCurrencyRates(currencyRates c.CurrencyRates) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.currencyRatesCache = currencyRates
+
+	return nil
+}
+
+// Start the monitor
+func (m *MonitorPriceUserDefined) Start() error {
+	if m.isStarted {
+		return errors.New("monitor already started")
+	}
+
+	m.isStarted = true
+
+	return nil
+}
+
+// Stop the monitor
+func (m *MonitorPriceUserDefined) Stop() error {
+	if !m.isStarted {
+		return errors.New("monitor not started")
+	}
+
+	m.isStarted = false
+
+	return nil
+}
+
+func getCurrencyCode(price c.ConfigUserDefinedPrice) string {
+	if price.Currency == "" {
+		return defaultCurrencyCode
+	}
+
+	return strings.ToUpper(price.Currency)
+}
+
+// getCashCurrencyCode returns the currency code of a cash symbol (e.g. EUR from EUR.CASH) and whether the symbol is a cash symbol
+func getCashCurrencyCode(symbol string) (string, bool) {
+	currencyCode, isCash := strings.CutSuffix(symbol, cashSymbolSuffix)
+
+	if !isCash || len(currencyCode) != 3 {
+		return "", false
+	}
+
+	return currencyCode, true
+}
+
+func transformCash(symbol string) c.AssetQuote {
+
+	currencyCode, _ := getCashCurrencyCode(symbol)
+
+	return c.AssetQuote{
+		Name:   "Cash (" + currencyCode + ")",
+		Symbol: symbol,
+		Class:  c.AssetClassCash,
+		Currency: c.Currency{
+			FromCurrencyCode: currencyCode,
+		},
+		QuotePrice: c.QuotePrice{
+			Price:          1,
+			PricePrevClose: 1,
+		},
+		QuoteSource: c.QuoteSourceUserDefined,
+		Exchange: c.Exchange{
+			Name:      "Cash",
+			DelayText: "Manual",
+			State:     c.ExchangeStateClosed,
+		},
+		Meta: c.Meta{
+			SymbolInSourceAPI: symbol,
+		},
+	}
+}
+
+func transformPrice(price c.ConfigUserDefinedPrice) c.AssetQuote {
+
+	var changePercent float64
+
+	pricePrevClose := price.PricePrevClose
+	if pricePrevClose == 0 {
+		pricePrevClose = price.Price
+	}
+
+	change := price.Price - pricePrevClose
+	if pricePrevClose != 0 {
+		changePercent = change / pricePrevClose * 100
+	}
+
+	name := price.Name
+	if name == "" {
+		name = price.Symbol
+	}
+
+	delayText := "Manual"
+	if price.AsOf != "" {
+		delayText = "As of " + price.AsOf
+	}
+
+	return c.AssetQuote{
+		Name:   name,
+		Symbol: strings.ToUpper(price.Symbol),
+		Class:  c.AssetClassPrivateSecurity,
+		Currency: c.Currency{
+			FromCurrencyCode: getCurrencyCode(price),
+		},
+		QuotePrice: c.QuotePrice{
+			Price:          price.Price,
+			PricePrevClose: pricePrevClose,
+			PriceOpen:      pricePrevClose,
+			PriceDayHigh:   max(price.Price, pricePrevClose),
+			PriceDayLow:    min(price.Price, pricePrevClose),
+			Change:         change,
+			ChangePercent:  changePercent,
+		},
+		QuoteSource: c.QuoteSourceUserDefined,
+		Exchange: c.Exchange{
+			Name:      exchangeName,
+			DelayText: delayText,
+			State:     c.ExchangeStateClosed,
+		},
+		Meta: c.Meta{
+			SymbolInSourceAPI: strings.ToUpper(price.Symbol),
+		},
+	}
+}
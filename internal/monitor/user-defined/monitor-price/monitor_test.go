@@ -0,0 +1,187 @@
+package monitorPriceUserDefined_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	g "github.com/onsi/gomega/gstruct"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	monitorPriceUserDefined "github.com/achannarasappa/ticker/v5/internal/monitor/user-defined/monitor-price"
+)
+
+var _ = Describe("Monitor User Defined", func() {
+	var (
+		inputPrices                   []c.ConfigUserDefinedPrice
+		inputChanRequestCurrencyRates chan []string
+		monitor                       *monitorPriceUserDefined.MonitorPriceUserDefined
+	)
+
+	BeforeEach(func() {
+		inputPrices = []c.ConfigUserDefinedPrice{
+			{
+				Symbol:         "acme",
+				Name:           "Acme Corp RSUs",
+				Price:          12,
+				PricePrevClose: 10,
+				Currency:       "EUR",
+				AsOf:           "2024-06-30",
+			},
+			{
+				Symbol: "HOUSE",
+				Price:  500000,
+			},
+		}
+		inputChanRequestCurrencyRates = make(chan []string, 1)
+		monitor = monitorPriceUserDefined.NewMonitorPriceUserDefined(monitorPriceUserDefined.Config{
+			Prices:                   inputPrices,
+			ChanRequestCurrencyRates: inputChanRequestCurrencyRates,
+		})
+	})
+
+	Describe("NewMonitorPriceUserDefined", func() {
+		It("should return a new MonitorPriceUserDefined", func() {
+			Expect(monitor).NotTo(BeNil())
+		})
+	})
+
+	Describe("SetSymbols", func() {
+		It("should request currency rates for the currencies of the symbols", func() {
+			err := monitor.SetSymbols([]string{"ACME", "HOUSE"}, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(inputChanRequestCurrencyRates).To(Receive(Equal([]string{"EUR", "USD"})))
+		})
+
+		When("a symbol is a cash symbol", func() {
+			It("should request the currency rate for the currency of the cash symbol", func() {
+				err := monitor.SetSymbols([]string{"eur.cash"}, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(inputChanRequestCurrencyRates).To(Receive(Equal([]string{"EUR"})))
+			})
+		})
+
+		When("none of the symbols have a user defined price", func() {
+			It("should not request currency rates", func() {
+				err := monitor.SetSymbols([]string{"TSLA"}, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(inputChanRequestCurrencyRates).NotTo(Receive())
+			})
+		})
+	})
+
+	Describe("GetAssetQuotes", func() {
+		It("should return quotes for the configured prices of the current symbols", func() {
+			monitor.SetSymbols([]string{"acme", "HOUSE", "TSLA"}, 0)
+
+			assetQuotes, err := monitor.GetAssetQuotes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(assetQuotes).To(HaveLen(2))
+			Expect(assetQuotes[0]).To(g.MatchFields(g.IgnoreExtras, g.Fields{
+				"Name":        Equal("Acme Corp RSUs"),
+				"Symbol":      Equal("ACME"),
+				"Class":       Equal(c.AssetClassPrivateSecurity),
+				"QuoteSource": Equal(c.QuoteSourceUserDefined),
+				"Currency": g.MatchFields(g.IgnoreExtras, g.Fields{
+					"FromCurrencyCode": Equal("EUR"),
+				}),
+				"QuotePrice": g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Price":          Equal(12.0),
+					"PricePrevClose": Equal(10.0),
+					"Change":         Equal(2.0),
+					"ChangePercent":  Equal(20.0),
+				}),
+				"Exchange": g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Name":      Equal("User Defined"),
+					"DelayText": Equal("As of 2024-06-30"),
+					"State":     Equal(c.ExchangeStateClosed),
+				}),
+			}))
+		})
+
+		When("optional fields are not set", func() {
+			It("should default the name to the symbol, the previous close to the price, and the currency to USD", func() {
+				monitor.SetSymbols([]string{"HOUSE"}, 0)
+
+				assetQuotes, _ := monitor.GetAssetQuotes(true)
+				Expect(assetQuotes).To(HaveLen(1))
+				Expect(assetQuotes[0]).To(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Name": Equal("HOUSE"),
+					"Currency": g.MatchFields(g.IgnoreExtras, g.Fields{
+						"FromCurrencyCode": Equal("USD"),
+					}),
+					"QuotePrice": g.MatchFields(g.IgnoreExtras, g.Fields{
+						"Price":          Equal(500000.0),
+						"PricePrevClose": Equal(500000.0),
+						"Change":         Equal(0.0),
+						"ChangePercent":  Equal(0.0),
+					}),
+					"Exchange": g.MatchFields(g.IgnoreExtras, g.Fields{
+						"DelayText": Equal("Manual"),
+					}),
+				}))
+			})
+		})
+
+		When("a symbol is a cash symbol", func() {
+			It("should return a cash quote with a price of one unit of the currency", func() {
+				monitor.SetSymbols([]string{"GBP.CASH", "EURO.CASH"}, 0)
+
+				assetQuotes, _ := monitor.GetAssetQuotes()
+				Expect(assetQuotes).To(HaveLen(1))
+				Expect(assetQuotes[0]).To(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Name":        Equal("Cash (GBP)"),
+					"Symbol":      Equal("GBP.CASH"),
+					"Class":       Equal(c.AssetClassCash),
+					"QuoteSource": Equal(c.QuoteSourceUserDefined),
+					"Currency": g.MatchFields(g.IgnoreExtras, g.Fields{
+						"FromCurrencyCode": Equal("GBP"),
+					}),
+					"QuotePrice": g.MatchFields(g.IgnoreExtras, g.Fields{
+						"Price":          Equal(1.0),
+						"PricePrevClose": Equal(1.0),
+						"Change":         Equal(0.0),
+					}),
+				}))
+			})
+		})
+	})
+
+	Describe("SetCurrencyRates", func() {
+		It("should set the currency rate on each asset quote", func() {
+			monitor.SetSymbols([]string{"ACME"}, 0)
+
+			err := monitor.SetCurrencyRates(c.CurrencyRates{
+				"EUR": c.CurrencyRate{FromCurrency: "EUR", ToCurrency: "USD", Rate: 1.1},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			assetQuotes, _ := monitor.GetAssetQuotes()
+			Expect(assetQuotes[0].Currency).To(Equal(c.Currency{
+				FromCurrencyCode: "EUR",
+				ToCurrencyCode:   "USD",
+				Rate:             1.1,
+			}))
+		})
+	})
+
+	Describe("Start", func() {
+		When("the monitor is already started", func() {
+			It("should return an error", func() {
+				Expect(monitor.Start()).To(Succeed())
+				Expect(monitor.Start()).To(MatchError("monitor already started"))
+			})
+		})
+	})
+
+	Describe("Stop", func() {
+		It("should stop the monitor", func() {
+			monitor.Start()
+			Expect(monitor.Stop()).To(Succeed())
+		})
+
+		When("the monitor is not started", func() {
+			It("should return an error", func() {
+				Expect(monitor.Stop()).To(MatchError("monitor not started"))
+			})
+		})
+	})
+})
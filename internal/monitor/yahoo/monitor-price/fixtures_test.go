@@ -1,9 +1,28 @@
 package monitorPriceYahoo_test
 
 import (
+	"encoding/base64"
+	"math"
+
 	"github.com/achannarasappa/ticker/v5/internal/monitor/yahoo/unary"
+	"google.golang.org/protobuf/encoding/protowire"
 )
 
+// encodePricingDataFixture encodes a regular session price for a symbol as sent by the Yahoo Finance streaming API
+func encodePricingDataFixture(id string, price float32) string {
+
+	var b []byte
+
+	b = protowire.AppendTag(b, 1, protowire.BytesType)
+	b = protowire.AppendString(b, id)
+	b = protowire.AppendTag(b, 2, protowire.Fixed32Type)
+	b = protowire.AppendFixed32(b, math.Float32bits(price))
+	b = protowire.AppendTag(b, 7, protowire.VarintType)
+	b = protowire.AppendVarint(b, 1)
+
+	return base64.StdEncoding.EncodeToString(b)
+}
+
 var (
 	responseQuote1Fixture = unary.Response{
 		QuoteResponse: unary.ResponseQuoteResponse{
@@ -10,6 +10,7 @@ import (
 
 	c "github.com/achannarasappa/ticker/v5/internal/common"
 	poller "github.com/achannarasappa/ticker/v5/internal/monitor/yahoo/monitor-price/poller"
+	streamer "github.com/achannarasappa/ticker/v5/internal/monitor/yahoo/monitor-price/streamer"
 	unary "github.com/achannarasappa/ticker/v5/internal/monitor/yahoo/unary"
 )
 
@@ -25,6 +26,7 @@ const (
 type MonitorPriceYahoo struct {
 	unaryAPI                 *unary.UnaryAPI
 	poller                   *poller.Poller
+	streamer                 *streamer.Streamer
 	cache                    c.Cache
 	input                    input
 	symbols                  []string
@@ -33,12 +35,15 @@ type MonitorPriceYahoo struct {
 	assetQuotesCacheLookup   map[string]*c.AssetQuote  // Asset quotes for all assets retrieved at least once (symbol change does not remove symbols)
 	currencyRatesCache       map[string]c.CurrencyRate // Cache of currency rates
 	chanPollUpdateAssetQuote chan c.MessageUpdate[c.AssetQuote]
+	chanStreamUpdatePrice    chan c.MessageUpdate[c.QuotePrice]
+	chanStreamReconnect      chan struct{}
 	chanError                chan error
 	mu                       sync.RWMutex
 	muCurrencyRates          sync.RWMutex
 	ctx                      context.Context
 	cancel                   context.CancelFunc
 	isStarted                bool
+	versionVector            int
 	chanUpdateAssetQuote     chan c.MessageUpdate[c.AssetQuote]
 	chanRequestCurrencyRates chan []string
 }
@@ -70,6 +75,8 @@ func NewMonitorPriceYahoo(config Config, opts ...Option) *MonitorPriceYahoo {
 		symbolToCurrency:         make(map[string]string),
 		assetQuotesCache:         make([]*c.AssetQuote, 0),
 		chanPollUpdateAssetQuote: make(chan c.MessageUpdate[c.AssetQuote]),
+		chanStreamUpdatePrice:    make(chan c.MessageUpdate[c.QuotePrice]),
+		chanStreamReconnect:      make(chan struct{}, 1),
 		chanError:                config.ChanError,
 		unaryAPI:                 config.UnaryAPI,
 		cache:                    config.Cache,
@@ -86,6 +93,12 @@ func NewMonitorPriceYahoo(config Config, opts ...Option) *MonitorPriceYahoo {
 	}
 	monitor.poller = poller.NewPoller(ctx, pollerConfig)
 
+	streamerConfig := streamer.StreamerConfig{
+		ChanStreamUpdateQuotePrice: monitor.chanStreamUpdatePrice,
+		ChanReconnect:              monitor.chanStreamReconnect,
+	}
+	monitor.streamer = streamer.NewStreamer(ctx, streamerConfig)
+
 	for _, opt := range opts {
 		opt(monitor)
 	}
@@ -93,11 +106,28 @@ func NewMonitorPriceYahoo(config Config, opts ...Option) *MonitorPriceYahoo {
 	return monitor
 }
 
+// WithStreamingURL sets the URL of the websocket API used to stream prices in addition to polling
+func WithStreamingURL(url string) Option {
+	return func(m *MonitorPriceYahoo) {
+		if err := m.streamer.SetURL(url); err != nil && m.chanError != nil {
+			m.chanError <- err
+		}
+	}
+}
+
 // WithRefreshInterval sets the refresh interval for the monitor
 func WithRefreshInterval(interval time.Duration) Option {
 	return func(m *MonitorPriceYahoo) {
-		// TODO: handle error
-		m.poller.SetRefreshInterval(interval) //nolint:errcheck
+		if err := m.poller.SetRefreshInterval(interval); err != nil && m.chanError != nil {
+			m.chanError <- err
+		}
+	}
+}
+
+// WithRefreshIntervalClosed sets the minimum time between requests when the markets for all symbols are closed
+func WithRefreshIntervalClosed(interval time.Duration) Option {
+	return func(m *MonitorPriceYahoo) {
+		m.poller.SetRefreshIntervalClosed(interval) //nolint:errcheck
 	}
 }
 
@@ -140,11 +170,13 @@ func (m *MonitorPriceYahoo) SetSymbols(symbols []string, versionVector int) erro
 	// Deduplicate symbols since input may have duplicates
 	slices.Sort(symbols)
 	m.symbols = slices.Compact(symbols)
+	symbolsUnique := m.symbols
 	m.input.symbols = symbols
 	m.input.symbolsLookup = make(map[string]bool)
 	for _, symbol := range symbols {
 		m.input.symbolsLookup[symbol] = true
 	}
+	m.versionVector = versionVector
 
 	m.mu.Unlock()
 
@@ -162,7 +194,13 @@ func (m *MonitorPriceYahoo) SetSymbols(symbols []string, versionVector int) erro
 	}
 
 	// Set the symbols to monitor on the poller
-	m.poller.SetSymbols(m.symbols, versionVector)
+	m.poller.SetSymbols(symbolsUnique, versionVector)
+
+	// Polling continues while streaming to fill in fields which are not streamed and in case streaming is unavailable
+	err = m.streamer.SetSymbolsAndUpdateSubscriptions(symbolsUnique, versionVector)
+	if err != nil {
+		return err
+	}
 
 	return nil
 
@@ -189,6 +227,12 @@ func (m *MonitorPriceYahoo) Start() error {
 		return err
 	}
 
+	// Start streaming prices in the background which is retried until the streaming API is available
+	err = m.streamer.Start()
+	if err != nil {
+		return err
+	}
+
 	// Start listening for price quote updates
 	go m.handleUpdates()
 
@@ -209,6 +253,11 @@ func (m *MonitorPriceYahoo) Stop() error {
 	return nil
 }
 
+// GetStatus returns the state of requests for quotes which are paused after repeated failures
+func (m *MonitorPriceYahoo) GetStatus() c.SourceStatus {
+	return m.poller.GetStatus()
+}
+
 func (m *MonitorPriceYahoo) SetCurrencyRates(currencyRates c.CurrencyRates) error {
 	m.muCurrencyRates.Lock()
 	m.currencyRatesCache = currencyRates
@@ -283,19 +332,112 @@ func (m *MonitorPriceYahoo) handleUpdates() {
 				VersionVector: updateMessage.VersionVector,
 			}
 
+			continue
+
+		case updateMessage := <-m.chanStreamUpdatePrice:
+			m.mu.RLock()
+
+			assetQuote, exists := m.assetQuotesCacheLookup[updateMessage.ID]
+
+			// Skip update if the symbol is not in the cache or the price has not changed
+			if !exists || assetQuote.QuotePrice.Price == updateMessage.Data.Price {
+				m.mu.RUnlock()
+
+				continue
+			}
+			m.mu.RUnlock()
+
+			m.mu.Lock()
+
+			assetQuote.QuotePrice.Price = updateMessage.Data.Price
+
+			// Streamed updates only include the fields which changed so others are kept as is
+			if updateMessage.Data.PricePrevClose != 0 {
+				assetQuote.QuotePrice.PricePrevClose = updateMessage.Data.PricePrevClose
+			}
+
+			if updateMessage.Data.PriceOpen != 0 {
+				assetQuote.QuotePrice.PriceOpen = updateMessage.Data.PriceOpen
+			}
+
+			if updateMessage.Data.PriceDayHigh != 0 {
+				assetQuote.QuotePrice.PriceDayHigh = updateMessage.Data.PriceDayHigh
+			}
+
+			if updateMessage.Data.PriceDayLow != 0 {
+				assetQuote.QuotePrice.PriceDayLow = updateMessage.Data.PriceDayLow
+			}
+
+			// Change is relative to the previous close in every session to match quotes from the unary API
+			if assetQuote.QuotePrice.PricePrevClose != 0 {
+				assetQuote.QuotePrice.Change = assetQuote.QuotePrice.Price - assetQuote.QuotePrice.PricePrevClose
+				assetQuote.QuotePrice.ChangePercent = assetQuote.QuotePrice.Change / assetQuote.QuotePrice.PricePrevClose * 100
+			}
+
+			m.mu.Unlock()
+
+			m.chanUpdateAssetQuote <- c.MessageUpdate[c.AssetQuote]{
+				ID:            assetQuote.Symbol,
+				Data:          *assetQuote,
+				VersionVector: updateMessage.VersionVector,
+			}
+
+			continue
+
+		case <-m.chanStreamReconnect:
+			m.refreshAfterReconnect()
+
 			continue
 		}
 	}
 }
 
+// refreshAfterReconnect gets quotes from the unary API to fill in any price changes missed while the streaming
+// connection was down and sends updates for the quotes which changed
+func (m *MonitorPriceYahoo) refreshAfterReconnect() {
+
+	m.mu.RLock()
+	pricesPrevious := make(map[string]float64, len(m.assetQuotesCacheLookup))
+	for symbol, assetQuote := range m.assetQuotesCacheLookup {
+		pricesPrevious[symbol] = assetQuote.QuotePrice.Price
+	}
+	versionVector := m.versionVector
+	m.mu.RUnlock()
+
+	assetQuotes, err := m.getAssetQuotesAndReplaceCache()
+	if err != nil {
+		if m.chanError != nil {
+			m.chanError <- err
+		}
+
+		return
+	}
+
+	for _, assetQuote := range assetQuotes {
+		if price, exists := pricesPrevious[assetQuote.Meta.SymbolInSourceAPI]; exists && price == assetQuote.QuotePrice.Price {
+			continue
+		}
+
+		m.chanUpdateAssetQuote <- c.MessageUpdate[c.AssetQuote]{
+			ID:            assetQuote.Symbol,
+			Data:          *assetQuote,
+			VersionVector: versionVector,
+		}
+	}
+}
+
 // Get asset quotes from unary API, add futures quotes, filter out assets not explicitly requested, and replace the asset quotes cache
 func (m *MonitorPriceYahoo) getAssetQuotesAndReplaceCache() ([]*c.AssetQuote, error) {
 
 	lookup := make(map[string]*c.AssetQuote)
 	cache := make([]*c.AssetQuote, 0)
 
+	m.mu.RLock()
+	symbols := m.symbols
+	m.mu.RUnlock()
+
 	// Make a synchronous call to get price quotes
-	assetQuotes, _, err := m.unaryAPI.GetAssetQuotes(m.symbols)
+	assetQuotes, _, err := m.unaryAPI.GetAssetQuotes(symbols)
 	if err != nil {
 		return []*c.AssetQuote{}, err
 	}
@@ -330,8 +472,12 @@ func (m *MonitorPriceYahoo) getAssetQuotesAndReplaceCache() ([]*c.AssetQuote, er
 }
 
 func (m *MonitorPriceYahoo) getCurrencyForEachSymbolAndUpdateCurrencyMap() error {
+	m.mu.RLock()
+	symbols := m.symbols
+	m.mu.RUnlock()
+
 	// No need to process if no symbols are provided
-	if len(m.symbols) == 0 {
+	if len(symbols) == 0 {
 		return nil
 	}
 
@@ -339,7 +485,7 @@ func (m *MonitorPriceYahoo) getCurrencyForEachSymbolAndUpdateCurrencyMap() error
 
 	// Check if symbols already have a currency mapping
 	m.muCurrencyRates.RLock()
-	for _, symbol := range m.symbols {
+	for _, symbol := range symbols {
 		if _, exists := m.symbolToCurrency[symbol]; !exists {
 			symbolsWithoutCurrency = append(symbolsWithoutCurrency, symbol)
 		}
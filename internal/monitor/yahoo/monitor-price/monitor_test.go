@@ -13,6 +13,7 @@ import (
 	c "github.com/achannarasappa/ticker/v5/internal/common"
 	monitorPriceYahoo "github.com/achannarasappa/ticker/v5/internal/monitor/yahoo/monitor-price"
 	"github.com/achannarasappa/ticker/v5/internal/monitor/yahoo/unary"
+	testWs "github.com/achannarasappa/ticker/v5/test/websocket"
 
 	"github.com/onsi/gomega/ghttp"
 	"github.com/spf13/afero"
@@ -293,6 +294,89 @@ var _ = Describe("Monitor Yahoo", func() {
 			})
 		})
 
+		When("there is a streaming price update", func() {
+			It("should send the asset quote with the streamed price and the change from the previous close to the channel", func() {
+				server.RouteToHandler("GET", "/v7/finance/quote",
+					ghttp.CombineHandlers(
+						ghttp.RespondWithJSONEncoded(http.StatusOK, responseQuote1Fixture),
+					),
+				)
+
+				inputServer := testWs.NewServer([]string{encodePricingDataFixture("NET", 86.1)})
+				defer inputServer.Close()
+
+				updateChan := make(chan c.MessageUpdate[c.AssetQuote], 10)
+
+				monitor := monitorPriceYahoo.NewMonitorPriceYahoo(monitorPriceYahoo.Config{
+					UnaryAPI:                 unaryAPI,
+					ChanUpdateAssetQuote:     updateChan,
+					Ctx:                      context.Background(),
+					ChanRequestCurrencyRates: make(chan []string, 1),
+				}, monitorPriceYahoo.WithRefreshInterval(10*time.Second),
+					monitorPriceYahoo.WithStreamingURL("ws://"+inputServer.URL[7:]))
+
+				monitor.SetSymbols([]string{"NET", "GOOG"}, 0)
+				monitor.Start()
+
+				var update c.MessageUpdate[c.AssetQuote]
+				Eventually(updateChan, 2*time.Second).Should(Receive(&update))
+
+				Expect(update.ID).To(Equal("NET"))
+				Expect(update.Data.QuotePrice.Price).To(Equal(86.1))
+				Expect(update.Data.QuotePrice.Change).To(BeNumerically("~", 2.1, 0.0001))
+				Expect(update.Data.QuotePrice.ChangePercent).To(BeNumerically("~", 2.5, 0.0001))
+				Expect(update.Data.QuotePrice.PriceDayHigh).To(Equal(90.00))
+				Expect(inputServer.GetReceivedMessages()).To(HaveExactElements(MatchJSON(`{"subscribe":["GOOG","NET"]}`)))
+
+				monitor.Stop()
+			})
+
+			When("the streaming API is unavailable", func() {
+				It("should continue to send polled asset quotes to the channel", func() {
+					server.RouteToHandler("GET", "/v7/finance/quote",
+						ghttp.CombineHandlers(
+							ghttp.RespondWithJSONEncoded(http.StatusOK, responseQuote1Fixture),
+						),
+					)
+
+					inputServer := testWs.NewServer([]string{})
+					inputServer.Close()
+
+					updateChan := make(chan c.MessageUpdate[c.AssetQuote], 10)
+
+					monitor := monitorPriceYahoo.NewMonitorPriceYahoo(monitorPriceYahoo.Config{
+						UnaryAPI:                 unaryAPI,
+						ChanUpdateAssetQuote:     updateChan,
+						Ctx:                      context.Background(),
+						ChanRequestCurrencyRates: make(chan []string, 1),
+					}, monitorPriceYahoo.WithRefreshInterval(100*time.Millisecond),
+						monitorPriceYahoo.WithStreamingURL("ws://"+inputServer.URL[7:]))
+
+					monitor.SetSymbols([]string{"NET", "GOOG"}, 0)
+					err := monitor.Start()
+					Expect(err).NotTo(HaveOccurred())
+
+					quoteNewPrice := quoteCloudflareFixture
+					quoteNewPrice.RegularMarketPrice = unary.ResponseFieldFloat{Raw: 310.00, Fmt: "310.00"}
+					server.RouteToHandler("GET", "/v7/finance/quote",
+						ghttp.CombineHandlers(
+							ghttp.RespondWithJSONEncoded(http.StatusOK, unary.Response{
+								QuoteResponse: unary.ResponseQuoteResponse{
+									Quotes: []unary.ResponseQuote{quoteNewPrice, quoteGoogleFixture},
+								},
+							}),
+						),
+					)
+
+					Eventually(updateChan, 2*time.Second).Should(Receive(
+						HaveField("Data.QuotePrice.Price", Equal(310.00)),
+					))
+
+					monitor.Stop()
+				})
+			})
+		})
+
 		When("there is a polling asset update", func() {
 			It("should send the updated asset quote to the channel", func() {
 
@@ -3,23 +3,31 @@ package poller
 import (
 	"context"
 	"errors"
+	"sync"
 	"time"
 
+	"github.com/achannarasappa/ticker/v5/internal/calendar"
 	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/monitor/breaker"
 	"github.com/achannarasappa/ticker/v5/internal/monitor/yahoo/unary"
 )
 
 // Poller represents a poller for Yahoo Finance
 type Poller struct {
-	refreshInterval      time.Duration
-	symbols              []string
-	isStarted            bool
-	ctx                  context.Context
-	cancel               context.CancelFunc
-	unaryAPI             *unary.UnaryAPI
-	chanUpdateAssetQuote chan c.MessageUpdate[c.AssetQuote]
-	chanError            chan error
-	versionVector        int
+	refreshInterval       time.Duration
+	refreshIntervalClosed time.Duration
+	lastAssetQuotes       []c.AssetQuote
+	lastPollTime          time.Time
+	symbols               []string
+	isStarted             bool
+	ctx                   context.Context
+	cancel                context.CancelFunc
+	unaryAPI              *unary.UnaryAPI
+	chanUpdateAssetQuote  chan c.MessageUpdate[c.AssetQuote]
+	chanError             chan error
+	versionVector         int
+	mu                    sync.Mutex // Guards symbols, versionVector, and lastAssetQuotes which are set while polling
+	breaker               *breaker.Breaker
 }
 
 // PollerConfig represents the configuration for the poller
@@ -42,13 +50,18 @@ func NewPoller(ctx context.Context, config PollerConfig) *Poller {
 		chanUpdateAssetQuote: config.ChanUpdateAssetQuote,
 		chanError:            config.ChanError,
 		versionVector:        0,
+		breaker:              breaker.New(breaker.Config{Name: "Yahoo"}),
 	}
 }
 
 // SetSymbols sets the symbols to poll
 func (p *Poller) SetSymbols(symbols []string, versionVector int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	p.symbols = symbols
 	p.versionVector = versionVector
+	p.lastAssetQuotes = nil
 }
 
 // SetRefreshInterval sets the refresh interval for the poller
@@ -63,6 +76,18 @@ func (p *Poller) SetRefreshInterval(interval time.Duration) error {
 	return nil
 }
 
+// SetRefreshIntervalClosed sets the minimum time between requests when the markets for all symbols are closed
+func (p *Poller) SetRefreshIntervalClosed(interval time.Duration) error {
+
+	if p.isStarted {
+		return errors.New("cannot set refresh interval while poller is started")
+	}
+
+	p.refreshIntervalClosed = interval
+
+	return nil
+}
+
 // Start starts the poller
 func (p *Poller) Start() error {
 	if p.isStarted {
@@ -86,23 +111,51 @@ func (p *Poller) Start() error {
 
 				return
 			case <-ticker.C:
+				p.mu.Lock()
+				symbols := p.symbols
+				versionVector := p.versionVector
+				lastAssetQuotes := p.lastAssetQuotes
+				p.mu.Unlock()
+
 				// Skip making a HTTP request if no symbols are set
-				if len(p.symbols) == 0 {
+				if len(symbols) == 0 {
 
 					continue
 				}
 
-				versionVector := p.versionVector
+				// Poll less often when the markets for all symbols have been closed since the last request since
+				// prices will not change until one of them opens
+				now := time.Now()
+				if now.Sub(p.lastPollTime) < p.refreshIntervalClosed && calendar.IsClosedBetween(lastAssetQuotes, p.lastPollTime, now) {
+
+					continue
+				}
+
+				// Wait for the backoff after a failed request or for the next probe if requests are paused
+				if !p.breaker.Allow(now) {
+
+					continue
+				}
 
 				// Make a HTTP request to get the asset quotes
-				assetQuotes, _, err := p.unaryAPI.GetAssetQuotes(p.symbols)
+				assetQuotes, _, err := p.unaryAPI.GetAssetQuotes(symbols)
 
 				if err != nil {
-					p.chanError <- err
+					p.chanError <- p.breaker.Failure(err, now)
 
 					continue
 				}
 
+				p.breaker.Success(now)
+
+				p.mu.Lock()
+				// Quotes for symbols which were replaced while polling are not kept
+				if versionVector == p.versionVector {
+					p.lastAssetQuotes = assetQuotes
+				}
+				p.mu.Unlock()
+				p.lastPollTime = now
+
 				// Send the asset quotes to the update channel
 				for _, assetQuote := range assetQuotes {
 					p.chanUpdateAssetQuote <- c.MessageUpdate[c.AssetQuote]{
@@ -118,6 +171,11 @@ func (p *Poller) Start() error {
 	return nil
 }
 
+// GetStatus returns the state of requests to Yahoo Finance
+func (p *Poller) GetStatus() c.SourceStatus {
+	return p.breaker.Status()
+}
+
 // Stop stops the poller
 func (p *Poller) Stop() error {
 	p.cancel()
@@ -149,6 +149,37 @@ var _ = Describe("Poller", func() {
 			})
 		})
 
+		When("the request is rate limited", func() {
+			It("should not make another request until the time in the Retry-After header", func() {
+
+				server.RouteToHandler("GET", "/v7/finance/quote",
+					ghttp.RespondWith(http.StatusTooManyRequests, "", http.Header{"Retry-After": []string{"60"}}),
+				)
+
+				p := poller.NewPoller(ctx, poller.PollerConfig{
+					UnaryAPI:             inputUnaryAPI,
+					ChanUpdateAssetQuote: inputChanUpdateAssetQuote,
+					ChanError:            inputChanError,
+				})
+
+				p.SetSymbols([]string{"NET"}, 0)
+				p.SetRefreshInterval(time.Millisecond * 20)
+
+				err := p.Start()
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(inputChanError).Should(Receive(MatchError("failed to get quotes: unexpected response: 429")))
+				Consistently(server.ReceivedRequests).Should(HaveLen(1))
+				Expect(p.GetStatus()).To(g.MatchFields(g.IgnoreExtras, g.Fields{
+					"Name":     Equal("Yahoo"),
+					"Failures": Equal(1),
+					"RetryAt":  BeTemporally("~", time.Now().Add(time.Minute), 2*time.Second),
+				}))
+
+				cancel()
+			})
+		})
+
 		When("the symbols are not set", func() {
 			It("should not return any price updates", func() {
 
@@ -0,0 +1,153 @@
+package streamer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// marketHours is the trading session a price was recorded in
+type marketHours int
+
+const (
+	marketHoursPreMarket marketHours = iota
+	marketHoursRegularMarket
+	marketHoursPostMarket
+	marketHoursExtendedHoursMarket
+)
+
+// Field numbers in the PricingData protobuf message sent by the Yahoo Finance streaming API. Fields which are not
+// used are skipped when decoding.
+const (
+	fieldID            protowire.Number = 1
+	fieldPrice         protowire.Number = 2
+	fieldTime          protowire.Number = 3
+	fieldMarketHours   protowire.Number = 7
+	fieldChangePercent protowire.Number = 8
+	fieldDayHigh       protowire.Number = 10
+	fieldDayLow        protowire.Number = 11
+	fieldChange        protowire.Number = 12
+	fieldOpenPrice     protowire.Number = 15
+	fieldPreviousClose protowire.Number = 16
+)
+
+// pricingData is a price update for a single symbol. Fields with a zero value were not included in the update.
+type pricingData struct {
+	ID            string
+	Price         float64
+	Time          int64 // Milliseconds since the epoch
+	MarketHours   marketHours
+	ChangePercent float64
+	DayHigh       float64
+	DayLow        float64
+	Change        float64
+	OpenPrice     float64
+	PreviousClose float64
+}
+
+// messageEnvelope wraps the encoded price update in version 2 of the streaming API
+type messageEnvelope struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// decodeMessage decodes a message from the streaming API which is either a base64 encoded PricingData message or a
+// JSON envelope containing one. false is returned for messages which are not price updates.
+func decodeMessage(message []byte) (pricingData, bool, error) {
+
+	encoded := string(message)
+
+	if len(message) > 0 && message[0] == '{' {
+		var envelope messageEnvelope
+
+		if err := json.Unmarshal(message, &envelope); err != nil {
+			return pricingData{}, false, fmt.Errorf("failed to decode message: %w", err)
+		}
+
+		if envelope.Type != "pricing" {
+			return pricingData{}, false, nil
+		}
+
+		encoded = envelope.Message
+	}
+
+	b, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return pricingData{}, false, fmt.Errorf("failed to decode message: %w", err)
+	}
+
+	p, err := decodePricingData(b)
+	if err != nil {
+		return pricingData{}, false, err
+	}
+
+	return p, true, nil
+}
+
+// decodePricingData decodes the fields used from a PricingData protobuf message
+func decodePricingData(b []byte) (pricingData, error) {
+
+	var p pricingData
+
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return pricingData{}, fmt.Errorf("failed to decode pricing data: %w", protowire.ParseError(n))
+		}
+		b = b[n:]
+
+		switch {
+		case typ == protowire.BytesType && num == fieldID:
+			p.ID, n = protowire.ConsumeString(b)
+		case typ == protowire.Fixed32Type:
+			var v uint32
+			v, n = protowire.ConsumeFixed32(b)
+			setFloat(&p, num, math.Float32frombits(v))
+		case typ == protowire.VarintType && num == fieldTime:
+			var v uint64
+			v, n = protowire.ConsumeVarint(b)
+			p.Time = protowire.DecodeZigZag(v)
+		case typ == protowire.VarintType && num == fieldMarketHours:
+			var v uint64
+			v, n = protowire.ConsumeVarint(b)
+			p.MarketHours = marketHours(v) //nolint:gosec // Enum values are small
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+
+		if n < 0 {
+			return pricingData{}, fmt.Errorf("failed to decode pricing data field %d: %w", num, protowire.ParseError(n))
+		}
+		b = b[n:]
+	}
+
+	return p, nil
+}
+
+func setFloat(p *pricingData, num protowire.Number, v float32) {
+
+	// Prices are sent as 32 bit floats so they are rounded to the shortest decimal which represents them rather than
+	// converted directly (e.g. 187.19 rather than 187.19000244140625)
+	f, _ := strconv.ParseFloat(strconv.FormatFloat(float64(v), 'f', -1, 32), 64)
+
+	switch num {
+	case fieldPrice:
+		p.Price = f
+	case fieldChangePercent:
+		p.ChangePercent = f
+	case fieldDayHigh:
+		p.DayHigh = f
+	case fieldDayLow:
+		p.DayLow = f
+	case fieldChange:
+		p.Change = f
+	case fieldOpenPrice:
+		p.OpenPrice = f
+	case fieldPreviousClose:
+		p.PreviousClose = f
+	}
+}
@@ -0,0 +1,396 @@
+package streamer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/metrics"
+	"github.com/achannarasappa/ticker/v5/internal/monitor/breaker"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	defaultHeartbeatInterval       = 30 * time.Second
+	defaultReconnectBackoffInitial = time.Second
+	defaultReconnectBackoffMax     = time.Minute
+)
+
+type messageSubscription struct {
+	Subscribe   []string `json:"subscribe,omitempty"`
+	Unsubscribe []string `json:"unsubscribe,omitempty"`
+}
+
+// Streamer streams prices from the Yahoo Finance websocket API. The connection is opened in the background and
+// replaced with backoff whenever it is dropped or stops responding to pings so quotes are only polled until then.
+type Streamer struct {
+	symbols                    []string
+	conn                       *websocket.Conn
+	isStarted                  bool
+	url                        string
+	heartbeatInterval          time.Duration
+	backoff                    *breaker.Breaker
+	mu                         sync.Mutex
+	muWrite                    sync.Mutex // Serializes writes since a connection supports one concurrent writer
+	wg                         sync.WaitGroup
+	ctx                        context.Context
+	cancel                     context.CancelFunc
+	chanStreamUpdateQuotePrice chan c.MessageUpdate[c.QuotePrice]
+	chanError                  chan error
+	chanReconnect              chan struct{}
+	versionVector              int
+}
+
+// StreamerConfig represents the configuration for the streamer
+type StreamerConfig struct {
+	ChanStreamUpdateQuotePrice chan c.MessageUpdate[c.QuotePrice]
+	ChanError                  chan error
+	ChanReconnect              chan struct{} // Signaled after a dropped connection is replaced so quotes missed in between can be refreshed
+	HeartbeatInterval          time.Duration // Interval between pings with the connection replaced if nothing is received for twice as long, defaults to 30s
+	ReconnectBackoffInitial    time.Duration // Delay before the first reconnect attempt which doubles after each failure, defaults to 1s
+	ReconnectBackoffMax        time.Duration // Longest delay between reconnect attempts, defaults to 1m
+}
+
+// NewStreamer creates a new streamer
+func NewStreamer(ctx context.Context, config StreamerConfig) *Streamer {
+	ctx, cancel := context.WithCancel(ctx) //nolint:gosec // cancel stored in struct and called via Stop()
+
+	s := &Streamer{
+		chanStreamUpdateQuotePrice: config.ChanStreamUpdateQuotePrice,
+		chanError:                  config.ChanError,
+		chanReconnect:              config.ChanReconnect,
+		heartbeatInterval:          config.HeartbeatInterval,
+		ctx:                        ctx,
+		cancel:                     cancel,
+		wg:                         sync.WaitGroup{},
+		versionVector:              0,
+	}
+
+	if s.heartbeatInterval <= 0 {
+		s.heartbeatInterval = defaultHeartbeatInterval
+	}
+
+	if config.ReconnectBackoffInitial <= 0 {
+		config.ReconnectBackoffInitial = defaultReconnectBackoffInitial
+	}
+
+	if config.ReconnectBackoffMax <= 0 {
+		config.ReconnectBackoffMax = defaultReconnectBackoffMax
+	}
+
+	s.backoff = breaker.New(breaker.Config{
+		Name:           "Yahoo streaming",
+		BackoffInitial: config.ReconnectBackoffInitial,
+		BackoffMax:     config.ReconnectBackoffMax,
+		ProbeInterval:  config.ReconnectBackoffMax,
+	})
+
+	return s
+}
+
+// Start starts connecting to the websocket API in the background until the context is cancelled. An error is only
+// returned if the streamer is already started since an unavailable API is retried.
+func (s *Streamer) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.isStarted {
+		return errors.New("streamer already started")
+	}
+
+	// Streaming is optional so prices are only polled when no URL is set
+	if s.url == "" {
+		return nil
+	}
+
+	// Disconnect on stop signal
+	go func() {
+		<-s.ctx.Done()
+		s.mu.Lock()
+		if s.conn != nil {
+			s.conn.Close()
+		}
+		s.isStarted = false
+		s.symbols = []string{}
+		s.mu.Unlock()
+		s.wg.Wait()
+	}()
+
+	s.isStarted = true
+
+	s.wg.Add(1)
+	go s.run()
+
+	return nil
+}
+
+// SetSymbolsAndUpdateSubscriptions sets the symbols to stream and updates the subscriptions if connected
+func (s *Streamer) SetSymbolsAndUpdateSubscriptions(symbols []string, versionVector int) error {
+	s.mu.Lock()
+
+	symbolsPrevious := s.symbols
+	s.symbols = symbols
+	s.versionVector = versionVector
+	conn := s.conn
+
+	s.mu.Unlock()
+
+	// Subscriptions are sent when the connection is opened if not connected yet
+	if conn == nil {
+		return nil
+	}
+
+	symbolsRemoved := make([]string, 0)
+	for _, symbol := range symbolsPrevious {
+		if !slices.Contains(symbols, symbol) {
+			symbolsRemoved = append(symbolsRemoved, symbol)
+		}
+	}
+
+	s.write(conn, messageSubscription{Unsubscribe: symbolsRemoved})
+	s.write(conn, messageSubscription{Subscribe: symbols})
+
+	return nil
+}
+
+// SetURL sets the websocket URL
+func (s *Streamer) SetURL(url string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.isStarted {
+
+		return errors.New("cannot set URL while streamer is connected")
+	}
+
+	s.url = url
+
+	return nil
+}
+
+// IsConnected returns whether there is an open connection to the websocket API
+func (s *Streamer) IsConnected() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.conn != nil
+}
+
+// run opens a connection, reads messages from it, and replaces it whenever it is dropped until the streamer is stopped
+func (s *Streamer) run() {
+	defer s.wg.Done()
+
+	var err error
+
+	for isReconnect := false; ; isReconnect = true {
+		conn := s.reconnect(err)
+		if conn == nil {
+			return
+		}
+
+		if isReconnect {
+			metrics.StreamerReconnects.WithLabelValues("yahoo").Inc()
+
+			// Signal without blocking since a single refresh covers any number of reconnects
+			select {
+			case s.chanReconnect <- struct{}{}:
+			default:
+			}
+		}
+
+		err = s.readStreamQuote(conn)
+
+		s.mu.Lock()
+		s.conn = nil
+		s.mu.Unlock()
+
+		conn.Close()
+
+		if s.ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// reconnect opens a connection with backoff between attempts after the previous one failed with an error and
+// subscribes to the current symbols. nil is returned if the streamer is stopped before a connection is opened.
+func (s *Streamer) reconnect(err error) *websocket.Conn {
+
+	for {
+		if err != nil {
+			s.sendError(s.backoff.Failure(fmt.Errorf("streaming connection unavailable: %w", err), time.Now()))
+
+			select {
+			case <-s.ctx.Done():
+				return nil
+			case <-time.After(time.Until(s.backoff.Status().RetryAt)):
+			}
+
+			s.backoff.Allow(time.Now())
+		}
+
+		var conn *websocket.Conn
+
+		conn, err = s.connect()
+		if err != nil {
+			if s.ctx.Err() != nil {
+				return nil
+			}
+
+			continue
+		}
+
+		s.backoff.Success(time.Now())
+
+		s.mu.Lock()
+
+		// Stop may have run while the connection was being opened
+		if s.ctx.Err() != nil {
+			s.mu.Unlock()
+			conn.Close()
+
+			return nil
+		}
+
+		s.conn = conn
+		symbols := s.symbols
+
+		s.mu.Unlock()
+
+		s.write(conn, messageSubscription{Subscribe: symbols})
+
+		return conn
+	}
+}
+
+// connect opens a connection and starts sending pings on it
+func (s *Streamer) connect() (*websocket.Conn, error) {
+
+	conn, _, err := websocket.DefaultDialer.DialContext(s.ctx, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// A connection which has not received a message or pong for two heartbeats is assumed to be half-open
+	conn.SetReadDeadline(time.Now().Add(2 * s.heartbeatInterval)) //nolint:errcheck
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * s.heartbeatInterval))
+	})
+
+	s.wg.Add(1)
+	go s.sendHeartbeats(conn)
+
+	return conn, nil
+}
+
+// sendHeartbeats pings the server until the connection is closed so that a half-open connection is detected by the
+// missing pongs
+func (s *Streamer) sendHeartbeats(conn *websocket.Conn) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(s.heartbeatInterval)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (s *Streamer) readStreamQuote(conn *websocket.Conn) error {
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+
+		// Any message shows the connection is still open
+		conn.SetReadDeadline(time.Now().Add(2 * s.heartbeatInterval)) //nolint:errcheck
+
+		p, isPricing, err := decodeMessage(message)
+		if err != nil {
+			s.sendError(err)
+
+			continue
+		}
+
+		if !isPricing || p.ID == "" || p.Price == 0 {
+			continue
+		}
+
+		s.mu.Lock()
+		versionVector := s.versionVector
+		s.mu.Unlock()
+
+		select {
+		case s.chanStreamUpdateQuotePrice <- transformPricingData(p, versionVector):
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		}
+	}
+}
+
+// sendError reports an error without blocking once the streamer is stopped
+func (s *Streamer) sendError(err error) {
+
+	if s.chanError == nil {
+		return
+	}
+
+	select {
+	case s.chanError <- err:
+	case <-s.ctx.Done():
+	}
+}
+
+// write sends a subscription message if it has any symbols. A failed write closes the connection so that it is
+// replaced and the subscriptions are sent again on the new connection.
+func (s *Streamer) write(conn *websocket.Conn, message messageSubscription) {
+
+	if len(message.Subscribe) == 0 && len(message.Unsubscribe) == 0 {
+		return
+	}
+
+	s.muWrite.Lock()
+	defer s.muWrite.Unlock()
+
+	if err := conn.WriteJSON(message); err != nil {
+		conn.Close()
+	}
+}
+
+// transformPricingData converts a price update to a quote price. The day range and open are only set from updates
+// during the regular session since they are not for the extended hours session.
+func transformPricingData(p pricingData, versionVector int) c.MessageUpdate[c.QuotePrice] {
+
+	qp := c.MessageUpdate[c.QuotePrice]{
+		ID:            p.ID,
+		Sequence:      p.Time,
+		VersionVector: versionVector,
+		Data: c.QuotePrice{
+			Price:          p.Price,
+			PricePrevClose: p.PreviousClose,
+			Change:         p.Change,
+			ChangePercent:  p.ChangePercent,
+		},
+	}
+
+	if p.MarketHours == marketHoursRegularMarket {
+		qp.Data.PriceOpen = p.OpenPrice
+		qp.Data.PriceDayHigh = p.DayHigh
+		qp.Data.PriceDayLow = p.DayLow
+	}
+
+	return qp
+}
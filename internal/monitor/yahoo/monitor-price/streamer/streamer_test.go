@@ -0,0 +1,276 @@
+package streamer_test
+
+import (
+	"context"
+	"encoding/base64"
+	"math"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	g "github.com/onsi/gomega/gstruct"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	streamer "github.com/achannarasappa/ticker/v5/internal/monitor/yahoo/monitor-price/streamer"
+	testWs "github.com/achannarasappa/ticker/v5/test/websocket"
+)
+
+// encodePricingData encodes a PricingData message with a symbol, price, time, market hours, and previous close as
+// sent by the Yahoo Finance streaming API
+func encodePricingData(id string, price float32, time int64, marketHours uint64, previousClose float32) string {
+
+	var b []byte
+
+	b = protowire.AppendTag(b, 1, protowire.BytesType)
+	b = protowire.AppendString(b, id)
+	b = protowire.AppendTag(b, 2, protowire.Fixed32Type)
+	b = protowire.AppendFixed32(b, math.Float32bits(price))
+	b = protowire.AppendTag(b, 3, protowire.VarintType)
+	b = protowire.AppendVarint(b, protowire.EncodeZigZag(time))
+	b = protowire.AppendTag(b, 5, protowire.BytesType)
+	b = protowire.AppendString(b, "NMS")
+	b = protowire.AppendTag(b, 7, protowire.VarintType)
+	b = protowire.AppendVarint(b, marketHours)
+	b = protowire.AppendTag(b, 10, protowire.Fixed32Type)
+	b = protowire.AppendFixed32(b, math.Float32bits(price+1))
+	b = protowire.AppendTag(b, 16, protowire.Fixed32Type)
+	b = protowire.AppendFixed32(b, math.Float32bits(previousClose))
+
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+var _ = Describe("Streamer", func() {
+	var (
+		server                     *testWs.Server
+		s                          *streamer.Streamer
+		ctx                        context.Context
+		cancel                     context.CancelFunc
+		chanStreamUpdateQuotePrice chan c.MessageUpdate[c.QuotePrice]
+		chanError                  chan error
+		chanReconnect              chan struct{}
+	)
+
+	newStreamer := func(messages []string) {
+		server = testWs.NewServer(messages)
+		ctx, cancel = context.WithCancel(context.Background())
+		chanStreamUpdateQuotePrice = make(chan c.MessageUpdate[c.QuotePrice], 5)
+		chanError = make(chan error, 5)
+		chanReconnect = make(chan struct{}, 1)
+		s = streamer.NewStreamer(ctx, streamer.StreamerConfig{
+			ChanStreamUpdateQuotePrice: chanStreamUpdateQuotePrice,
+			ChanError:                  chanError,
+			ChanReconnect:              chanReconnect,
+			HeartbeatInterval:          50 * time.Millisecond,
+			ReconnectBackoffInitial:    10 * time.Millisecond,
+		})
+		s.SetURL("ws://" + server.URL[7:])
+	}
+
+	AfterEach(func() {
+		if cancel != nil {
+			cancel()
+			server.Close()
+		}
+	})
+
+	Describe("NewStreamer", func() {
+		It("should return a new Streamer", func() {
+			s := streamer.NewStreamer(context.Background(), streamer.StreamerConfig{})
+			Expect(s).NotTo(BeNil())
+		})
+	})
+
+	Describe("Start", func() {
+		It("should connect and subscribe to the symbols", func() {
+			newStreamer([]string{})
+			s.SetSymbolsAndUpdateSubscriptions([]string{"NET", "GOOG"}, 0)
+
+			err := s.Start()
+
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(s.IsConnected).Should(BeTrue())
+			Eventually(server.GetReceivedMessages).Should(HaveExactElements(MatchJSON(`{"subscribe":["NET","GOOG"]}`)))
+		})
+
+		When("the streamer is already started", func() {
+			It("should return the error 'streamer already started'", func() {
+				newStreamer([]string{})
+
+				Expect(s.Start()).To(Succeed())
+				Expect(s.Start()).To(MatchError("streamer already started"))
+			})
+		})
+
+		When("the url is not set", func() {
+			It("should not start the streamer and not return an error", func() {
+				s = streamer.NewStreamer(context.Background(), streamer.StreamerConfig{})
+
+				Expect(s.Start()).To(Succeed())
+				Consistently(s.IsConnected, 100*time.Millisecond).Should(BeFalse())
+			})
+		})
+
+		When("the streaming API is unavailable", func() {
+			It("should not return an error and keep trying to connect", func() {
+				newStreamer([]string{})
+				server.Close()
+
+				Expect(s.Start()).To(Succeed())
+				Eventually(chanError).Should(Receive(MatchError(ContainSubstring("streaming connection unavailable"))))
+				Eventually(chanError).Should(Receive())
+				Expect(s.IsConnected()).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("readStreamQuote", func() {
+		When("a base64 encoded price message is received", func() {
+			It("should send the quote price to the channel", func() {
+				newStreamer([]string{encodePricingData("NET", 84.51, 1760000000000, 1, 80.12)})
+
+				Expect(s.Start()).To(Succeed())
+				Eventually(chanStreamUpdateQuotePrice).Should(Receive(
+					g.MatchFields(g.IgnoreExtras, g.Fields{
+						"ID":       Equal("NET"),
+						"Sequence": Equal(int64(1760000000000)),
+						"Data": g.MatchFields(g.IgnoreExtras, g.Fields{
+							"Price":          Equal(84.51),
+							"PricePrevClose": Equal(80.12),
+							"PriceDayHigh":   Equal(85.51),
+						}),
+					}),
+				))
+			})
+		})
+
+		When("a price message is wrapped in a JSON envelope", func() {
+			It("should send the quote price to the channel", func() {
+				newStreamer([]string{
+					`{"type":"heartbeat"}`,
+					`{"type":"pricing","message":"` + encodePricingData("NET", 84.51, 1760000000000, 1, 80.12) + `"}`,
+				})
+
+				Expect(s.Start()).To(Succeed())
+				Eventually(chanStreamUpdateQuotePrice).Should(Receive(
+					g.MatchFields(g.IgnoreExtras, g.Fields{
+						"ID": Equal("NET"),
+						"Data": g.MatchFields(g.IgnoreExtras, g.Fields{
+							"Price": Equal(84.51),
+						}),
+					}),
+				))
+				Expect(chanError).NotTo(Receive())
+			})
+		})
+
+		When("the price is from the extended hours session", func() {
+			It("should not set the day range", func() {
+				newStreamer([]string{encodePricingData("NET", 84.51, 1760000000000, 2, 80.12)})
+
+				Expect(s.Start()).To(Succeed())
+				Eventually(chanStreamUpdateQuotePrice).Should(Receive(
+					g.MatchFields(g.IgnoreExtras, g.Fields{
+						"Data": g.MatchFields(g.IgnoreExtras, g.Fields{
+							"Price":        Equal(84.51),
+							"PriceDayHigh": BeZero(),
+						}),
+					}),
+				))
+			})
+		})
+
+		When("a message can not be decoded", func() {
+			It("should send an error to the error channel and continue reading", func() {
+				newStreamer([]string{"not base64!", encodePricingData("NET", 84.51, 1760000000000, 1, 80.12)})
+
+				Expect(s.Start()).To(Succeed())
+				Eventually(chanError).Should(Receive(MatchError(ContainSubstring("failed to decode message"))))
+				Eventually(chanStreamUpdateQuotePrice).Should(Receive())
+			})
+		})
+	})
+
+	Describe("SetSymbolsAndUpdateSubscriptions", func() {
+		It("should unsubscribe from symbols which were removed and subscribe to the new symbols", func() {
+			newStreamer([]string{})
+			s.SetSymbolsAndUpdateSubscriptions([]string{"NET", "GOOG"}, 0)
+
+			Expect(s.Start()).To(Succeed())
+			Eventually(server.GetReceivedMessages).Should(HaveLen(1))
+
+			err := s.SetSymbolsAndUpdateSubscriptions([]string{"NET", "MSFT"}, 1)
+
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(server.GetReceivedMessages).Should(HaveExactElements(
+				MatchJSON(`{"subscribe":["NET","GOOG"]}`),
+				MatchJSON(`{"unsubscribe":["GOOG"]}`),
+				MatchJSON(`{"subscribe":["NET","MSFT"]}`),
+			))
+		})
+
+		When("the streamer is not started", func() {
+			It("should return early without error", func() {
+				s = streamer.NewStreamer(context.Background(), streamer.StreamerConfig{})
+
+				Expect(s.SetSymbolsAndUpdateSubscriptions([]string{"NET"}, 0)).To(Succeed())
+			})
+		})
+	})
+
+	Describe("SetURL", func() {
+		When("the streamer is started", func() {
+			It("should return the error 'cannot set URL while streamer is connected'", func() {
+				newStreamer([]string{})
+
+				Expect(s.Start()).To(Succeed())
+				Expect(s.SetURL("wss://example.com")).To(MatchError("cannot set URL while streamer is connected"))
+			})
+		})
+	})
+
+	Describe("reconnect", func() {
+		BeforeEach(func() {
+			newStreamer([]string{})
+			s.SetSymbolsAndUpdateSubscriptions([]string{"NET"}, 0)
+
+			Expect(s.Start()).To(Succeed())
+			Eventually(server.GetReceivedMessages).Should(HaveLen(1))
+		})
+
+		When("the connection is dropped", func() {
+			It("should open a new connection, resubscribe to the symbols, and signal the reconnect", func() {
+				server.DropConnections()
+
+				Eventually(chanReconnect).Should(Receive())
+				Expect(server.GetConnectionCount()).To(Equal(2))
+				Eventually(server.GetReceivedMessages).Should(HaveExactElements(
+					MatchJSON(`{"subscribe":["NET"]}`),
+					MatchJSON(`{"subscribe":["NET"]}`),
+				))
+			})
+		})
+
+		When("the server stops responding to pings", func() {
+			It("should replace the half-open connection", func() {
+				server.SetUnresponsive(true)
+
+				Eventually(chanReconnect).Should(Receive())
+				Expect(server.GetConnectionCount()).To(BeNumerically(">=", 2))
+			})
+
+			It("should keep the connection open while the server responds to pings", func() {
+				Consistently(server.GetConnectionCount, 300*time.Millisecond).Should(Equal(1))
+			})
+		})
+
+		When("the streamer is stopped", func() {
+			It("should not reconnect", func() {
+				cancel()
+
+				Consistently(server.GetConnectionCount, 200*time.Millisecond).Should(Equal(1))
+				Expect(chanReconnect).NotTo(Receive())
+			})
+		})
+	})
+})
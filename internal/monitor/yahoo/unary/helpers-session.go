@@ -10,6 +10,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/achannarasappa/ticker/v5/internal/metrics"
 )
 
 // Constants for URLs and common header values
@@ -214,6 +216,7 @@ func (u *UnaryAPI) getCrumb() (string, error) {
 // createClientWithRedirectLimit returns a new http.Client with the specified redirect limit
 func (a *UnaryAPI) createClientWithRedirectLimit(limit int) *http.Client {
 	return &http.Client{
+		Transport: metrics.NewTransport("yahoo", nil),
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
 			if len(via) >= limit {
 				return http.ErrUseLastResponse
@@ -48,3 +48,43 @@ type ResponseFieldString struct {
 	Raw string `json:"raw"`
 	Fmt string `json:"fmt"`
 }
+
+// ResponseSearch represents the container object from the symbol search API response
+type ResponseSearch struct {
+	Quotes []ResponseSearchQuote `json:"quotes"`
+}
+
+// ResponseSearchQuote represents a single security matching a search query
+type ResponseSearchQuote struct {
+	Symbol          string `json:"symbol"`
+	ShortName       string `json:"shortname"`
+	LongName        string `json:"longname"`
+	ExchangeDisplay string `json:"exchDisp"`
+	TypeDisplay     string `json:"typeDisp"`
+	IsYahooFinance  bool   `json:"isYahooFinance"`
+}
+
+// ResponseChart represents the container object from the chart API response
+type ResponseChart struct {
+	Chart ResponseChartChart `json:"chart"`
+}
+
+type ResponseChartChart struct {
+	Result []ResponseChartResult `json:"result"`
+	Error  interface{}           `json:"error"`
+}
+
+// ResponseChartResult represents the price history of a single security. Prices are null for intervals without trades.
+type ResponseChartResult struct {
+	Timestamp  []int64                       `json:"timestamp"`
+	Indicators ResponseChartResultIndicators `json:"indicators"`
+}
+
+type ResponseChartResultIndicators struct {
+	Quote []ResponseChartResultQuote `json:"quote"`
+}
+
+type ResponseChartResultQuote struct {
+	Close  []*float64 `json:"close"`
+	Volume []*float64 `json:"volume"`
+}
@@ -10,6 +10,8 @@ import (
 	"time"
 
 	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/metrics"
+	"github.com/achannarasappa/ticker/v5/internal/monitor/breaker"
 )
 
 const (
@@ -23,6 +25,22 @@ const (
 	ttlSession = 24 * time.Hour
 )
 
+// chartParams are the range and interval query parameters of the chart API for a price history range
+type chartParams struct {
+	Range    string
+	Interval string
+}
+
+//nolint:gochecknoglobals
+var chartParamsByRange = map[c.PriceHistoryRange]chartParams{
+	c.PriceHistoryRange1D: {Range: "1d", Interval: "5m"},
+	c.PriceHistoryRange5D: {Range: "5d", Interval: "30m"},
+	c.PriceHistoryRange1M: {Range: "1mo", Interval: "1h"},
+	c.PriceHistoryRange6M: {Range: "6mo", Interval: "1d"},
+	c.PriceHistoryRange1Y: {Range: "1y", Interval: "1d"},
+	c.PriceHistoryRange5Y: {Range: "5y", Interval: "1wk"},
+}
+
 // UnaryAPI is a client for the API
 type UnaryAPI struct {
 	client            *http.Client
@@ -65,6 +83,7 @@ type sessionCookie struct {
 func NewUnaryAPI(config Config) *UnaryAPI {
 	// Create client with limited redirects
 	client := &http.Client{
+		Transport: metrics.NewTransport("yahoo", nil),
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
 			if len(via) >= 1 {
 				return http.ErrUseLastResponse
@@ -231,6 +250,159 @@ func (u *UnaryAPI) GetCurrencyRates(fromCurrencies []string, toCurrency string)
 	return currencyRates, nil
 }
 
+// SearchSymbols issues a HTTP request to find securities with a symbol or name matching the query
+func (u *UnaryAPI) SearchSymbols(query string) ([]c.SymbolSearchResult, error) {
+	if strings.TrimSpace(query) == "" {
+		return []c.SymbolSearchResult{}, nil
+	}
+
+	reqURL, err := url.Parse(u.baseURL + "/v1/finance/search")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	q := reqURL.Query()
+	q.Set("q", query)
+	q.Set("quotesCount", "10")
+	q.Set("newsCount", "0")
+	q.Set("listsCount", "0")
+	q.Set("lang", "en-US")
+	q.Set("region", "US")
+	reqURL.RawQuery = q.Encode()
+
+	req, _ := http.NewRequest(http.MethodGet, reqURL.String(), nil)
+
+	req.Header.Set("Accept", "*/*")
+	req.Header.Set("Accept-Language", defaultAcceptLang)
+	req.Header.Set("User-Agent", defaultUserAgent)
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response: %d", resp.StatusCode)
+	}
+
+	var result ResponseSearch
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return transformResponseSearch(result.Quotes), nil
+}
+
+// GetPriceHistory issues a HTTP request to retrieve prices and volume over a range. The 1D range covers the current or
+// most recent trading session.
+func (u *UnaryAPI) GetPriceHistory(symbol string, historyRange c.PriceHistoryRange) ([]c.PricePoint, error) {
+	params, ok := chartParamsByRange[historyRange]
+	if !ok {
+		return nil, fmt.Errorf("unsupported price history range: %d", historyRange)
+	}
+
+	reqURL, err := url.Parse(u.baseURL + "/v8/finance/chart/" + url.PathEscape(symbol))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	q := reqURL.Query()
+	q.Set("range", params.Range)
+	q.Set("interval", params.Interval)
+	q.Set("includePrePost", "false")
+	q.Set("lang", "en-US")
+	q.Set("region", "US")
+	reqURL.RawQuery = q.Encode()
+
+	req, _ := http.NewRequest(http.MethodGet, reqURL.String(), nil)
+
+	req.Header.Set("Accept", "*/*")
+	req.Header.Set("Accept-Language", defaultAcceptLang)
+	req.Header.Set("User-Agent", defaultUserAgent)
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response: %d", resp.StatusCode)
+	}
+
+	var result ResponseChart
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if len(result.Chart.Result) == 0 {
+		return []c.PricePoint{}, nil
+	}
+
+	return transformResponseChart(result.Chart.Result[0]), nil
+}
+
+func transformResponseChart(responseChart ResponseChartResult) []c.PricePoint {
+	points := make([]c.PricePoint, 0, len(responseChart.Timestamp))
+
+	if len(responseChart.Indicators.Quote) == 0 {
+		return points
+	}
+
+	closes := responseChart.Indicators.Quote[0].Close
+	volumes := responseChart.Indicators.Quote[0].Volume
+
+	for i, timestamp := range responseChart.Timestamp {
+
+		// Intervals without any trades do not have a price
+		if i >= len(closes) || closes[i] == nil {
+			continue
+		}
+
+		var volume float64
+		if i < len(volumes) && volumes[i] != nil {
+			volume = *volumes[i]
+		}
+
+		points = append(points, c.PricePoint{
+			Time:   timestamp,
+			Price:  *closes[i],
+			Volume: volume,
+		})
+	}
+
+	return points
+}
+
+func transformResponseSearch(responseQuotes []ResponseSearchQuote) []c.SymbolSearchResult {
+	results := make([]c.SymbolSearchResult, 0, len(responseQuotes))
+
+	for _, responseQuote := range responseQuotes {
+
+		// Results that are not securities (e.g. news topics) do not have quotes
+		if responseQuote.Symbol == "" || !responseQuote.IsYahooFinance {
+			continue
+		}
+
+		name := responseQuote.ShortName
+		if name == "" {
+			name = responseQuote.LongName
+		}
+
+		results = append(results, c.SymbolSearchResult{
+			Symbol:            responseQuote.Symbol,
+			SymbolInSourceAPI: responseQuote.Symbol,
+			Name:              name,
+			Exchange:          responseQuote.ExchangeDisplay,
+			Type:              responseQuote.TypeDisplay,
+			Source:            c.QuoteSourceYahoo,
+		})
+	}
+
+	return results
+}
+
 func (u *UnaryAPI) getQuotes(symbols []string, fields []string) (Response, error) {
 
 	// Reuse a session shared by other instances when one is cached, so the first
@@ -286,6 +458,11 @@ func (u *UnaryAPI) getQuotes(symbols []string, fields []string) (Response, error
 	}
 	defer resp.Body.Close()
 
+	// Rate limited responses are returned as is since refreshing the session would only make another request
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return Response{}, breaker.NewStatusError(resp, fmt.Errorf("unexpected response: %d", resp.StatusCode))
+	}
+
 	// Handle not ok responses
 	if resp.StatusCode >= 400 {
 		// Try to refresh session and retry once
@@ -824,6 +824,121 @@ var _ = Describe("Unary", func() {
 
 	})
 
+	Describe("SearchSymbols", func() {
+		It("should return securities matching the query", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/finance/search"),
+					ghttp.VerifyFormKV("q", "apple"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, unary.ResponseSearch{
+						Quotes: []unary.ResponseSearchQuote{
+							{Symbol: "AAPL", ShortName: "Apple Inc.", ExchangeDisplay: "NASDAQ", TypeDisplay: "Equity", IsYahooFinance: true},
+							{Symbol: "APLE", LongName: "Apple Hospitality REIT, Inc.", ExchangeDisplay: "NYSE", TypeDisplay: "Equity", IsYahooFinance: true},
+							{Symbol: "APPLE-TOPIC", IsYahooFinance: false},
+						},
+					}),
+				),
+			)
+
+			outputResults, outputErr := client.SearchSymbols("apple")
+			Expect(outputErr).NotTo(HaveOccurred())
+			Expect(outputResults).To(Equal([]c.SymbolSearchResult{
+				{Symbol: "AAPL", SymbolInSourceAPI: "AAPL", Name: "Apple Inc.", Exchange: "NASDAQ", Type: "Equity", Source: c.QuoteSourceYahoo},
+				{Symbol: "APLE", SymbolInSourceAPI: "APLE", Name: "Apple Hospitality REIT, Inc.", Exchange: "NYSE", Type: "Equity", Source: c.QuoteSourceYahoo},
+			}))
+		})
+
+		When("the query is empty", func() {
+			It("should return no results without making a request", func() {
+				outputResults, outputErr := client.SearchSymbols(" ")
+				Expect(outputErr).NotTo(HaveOccurred())
+				Expect(outputResults).To(BeEmpty())
+				Expect(server.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+
+		When("the request fails", func() {
+			It("should return an error", func() {
+				server.AppendHandlers(ghttp.RespondWith(http.StatusInternalServerError, ""))
+
+				_, outputErr := client.SearchSymbols("apple")
+				Expect(outputErr).To(MatchError("unexpected response: 500"))
+			})
+		})
+	})
+
+	Describe("GetPriceHistory", func() {
+		It("should return prices and volume for intervals with trades", func() {
+			price1, price2 := 150.25, 151.5
+			volume1, volume2 := 1200.0, 900.0
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v8/finance/chart/AAPL"),
+					ghttp.VerifyFormKV("range", "1d"),
+					ghttp.VerifyFormKV("interval", "5m"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, unary.ResponseChart{
+						Chart: unary.ResponseChartChart{
+							Result: []unary.ResponseChartResult{
+								{
+									Timestamp: []int64{1700000000, 1700000300, 1700000600},
+									Indicators: unary.ResponseChartResultIndicators{
+										Quote: []unary.ResponseChartResultQuote{
+											{
+												Close:  []*float64{&price1, nil, &price2},
+												Volume: []*float64{&volume1, nil, &volume2},
+											},
+										},
+									},
+								},
+							},
+						},
+					}),
+				),
+			)
+
+			outputPoints, outputErr := client.GetPriceHistory("AAPL", c.PriceHistoryRange1D)
+			Expect(outputErr).NotTo(HaveOccurred())
+			Expect(outputPoints).To(Equal([]c.PricePoint{
+				{Time: 1700000000, Price: 150.25, Volume: 1200},
+				{Time: 1700000600, Price: 151.5, Volume: 900},
+			}))
+		})
+
+		It("should request the range and interval for the price history range", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v8/finance/chart/AAPL"),
+					ghttp.VerifyFormKV("range", "5y"),
+					ghttp.VerifyFormKV("interval", "1wk"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, unary.ResponseChart{}),
+				),
+			)
+
+			_, outputErr := client.GetPriceHistory("AAPL", c.PriceHistoryRange5Y)
+			Expect(outputErr).NotTo(HaveOccurred())
+		})
+
+		When("there is no chart for the symbol", func() {
+			It("should return no prices", func() {
+				server.AppendHandlers(ghttp.RespondWithJSONEncoded(http.StatusOK, unary.ResponseChart{}))
+
+				outputPoints, outputErr := client.GetPriceHistory("AAPL", c.PriceHistoryRange1D)
+				Expect(outputErr).NotTo(HaveOccurred())
+				Expect(outputPoints).To(BeEmpty())
+			})
+		})
+
+		When("the request fails", func() {
+			It("should return an error", func() {
+				server.AppendHandlers(ghttp.RespondWith(http.StatusNotFound, ""))
+
+				_, outputErr := client.GetPriceHistory("AAPL", c.PriceHistoryRange1D)
+				Expect(outputErr).To(MatchError("unexpected response: 404"))
+			})
+		})
+	})
+
 	Describe("startup cache", func() {
 
 		var testCache c.Cache
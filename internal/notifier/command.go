@@ -0,0 +1,67 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+// Command runs a program for each alert with the alert set in environment variables and as JSON on stdin
+type Command struct {
+	command []string
+}
+
+// ConfigCommand represents the configuration for the command notifier
+type ConfigCommand struct {
+	Command []string // Program to run followed by its arguments
+}
+
+// NewCommand creates a new command notifier
+func NewCommand(config ConfigCommand) *Command {
+	return &Command{
+		command: config.Command,
+	}
+}
+
+// Notify runs the command and returns an error with its output if it fails
+func (n *Command) Notify(ctx context.Context, alert c.Alert) error {
+
+	if len(n.command) == 0 {
+		return errors.New("no command set")
+	}
+
+	p := newPayload(alert)
+
+	stdin, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	var output bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, n.command[0], n.command[1:]...) //nolint:gosec
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	cmd.Env = append(os.Environ(),
+		"TICKER_ALERT_SYMBOL="+p.Symbol,
+		"TICKER_ALERT_CONDITION="+p.Condition,
+		"TICKER_ALERT_MESSAGE="+p.Message,
+		"TICKER_ALERT_PRICE="+strconv.FormatFloat(p.Price, 'f', -1, 64),
+		"TICKER_ALERT_TIME="+p.Time,
+	)
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("command '%s' failed: %w: %s", n.command[0], err, strings.TrimSpace(output.String()))
+	}
+
+	return nil
+}
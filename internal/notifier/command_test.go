@@ -0,0 +1,52 @@
+package notifier_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	. "github.com/achannarasappa/ticker/v5/internal/notifier"
+)
+
+var _ = Describe("Command", func() {
+
+	alertFixture := c.Alert{
+		Symbol:    "AAPL",
+		Condition: "above",
+		Message:   "AAPL rose above 200.00 to 201.00",
+		Price:     201,
+		Time:      time.Date(2025, 3, 4, 14, 5, 6, 0, time.UTC),
+	}
+
+	It("should run the command with the alert in environment variables and on stdin", func() {
+		outputPath := filepath.Join(GinkgoT().TempDir(), "output")
+
+		command := NewCommand(ConfigCommand{
+			Command: []string{"sh", "-c", `echo "$TICKER_ALERT_SYMBOL $TICKER_ALERT_PRICE $TICKER_ALERT_TIME" > "$0" && cat >> "$0"`, outputPath},
+		})
+
+		Expect(command.Notify(context.Background(), alertFixture)).To(Succeed())
+
+		output, err := os.ReadFile(outputPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(output)).To(Equal(
+			"AAPL 201 2025-03-04T14:05:06Z\n" +
+				`{"symbol":"AAPL","condition":"above","message":"AAPL rose above 200.00 to 201.00","price":201,"time":"2025-03-04T14:05:06Z"}`,
+		))
+	})
+
+	When("the command fails", func() {
+		It("should return an error with the output of the command", func() {
+			command := NewCommand(ConfigCommand{
+				Command: []string{"sh", "-c", "echo 'no display' >&2; exit 3"},
+			})
+
+			Expect(command.Notify(context.Background(), alertFixture)).To(MatchError("command 'sh' failed: exit status 3: no display"))
+		})
+	})
+})
@@ -0,0 +1,78 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+// Desktop shows each alert as a desktop notification with notify-send on Linux and other unix desktops or osascript
+// on macOS
+type Desktop struct {
+	goos string
+}
+
+// ConfigDesktop represents the configuration for the desktop notifier
+type ConfigDesktop struct {
+	OS string // Operating system to show notifications on in the same form as runtime.GOOS, defaults to the current one
+}
+
+// NewDesktop creates a new desktop notifier
+func NewDesktop(config ConfigDesktop) *Desktop {
+
+	goos := config.OS
+	if goos == "" {
+		goos = runtime.GOOS
+	}
+
+	return &Desktop{
+		goos: goos,
+	}
+}
+
+// Notify shows a notification for the alert and returns an error with the output of the notification program if it fails
+func (n *Desktop) Notify(ctx context.Context, alert c.Alert) error {
+
+	command, err := getDesktopCommand(n.goos, "ticker: "+alert.Symbol, alert.Message)
+	if err != nil {
+		return err
+	}
+
+	var output bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...) //nolint:gosec
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("notification failed: %s: %w: %s", command[0], err, strings.TrimSpace(output.String()))
+	}
+
+	return nil
+}
+
+// getDesktopCommand returns the program and arguments which show a notification on an operating system. The title and
+// message are passed as arguments rather than in the script for osascript so they do not need to be escaped.
+func getDesktopCommand(goos string, title string, message string) ([]string, error) {
+
+	switch goos {
+	case "darwin":
+		return []string{
+			"osascript",
+			"-e", "on run argv",
+			"-e", "display notification (item 2 of argv) with title (item 1 of argv)",
+			"-e", "end run",
+			title,
+			message,
+		}, nil
+	case "windows":
+		return nil, fmt.Errorf("desktop notifications are not supported on %s", goos) //nolint:goerr113
+	default:
+		return []string{"notify-send", "--app-name=ticker", title, message}, nil
+	}
+}
@@ -0,0 +1,82 @@
+package notifier_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	. "github.com/achannarasappa/ticker/v5/internal/notifier"
+)
+
+// setNotificationProgram puts a program with the name of a notification program first in PATH which writes each of its
+// arguments on a line to a file and runs script after and returns the path of the file
+func setNotificationProgram(name string, script string) string {
+
+	dir := GinkgoT().TempDir()
+	outputPath := filepath.Join(dir, "output")
+
+	err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\nprintf '%s\\n' \"$@\" > '"+outputPath+"'\n"+script+"\n"), 0755) //nolint:gosec
+	Expect(err).NotTo(HaveOccurred())
+
+	GinkgoT().Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	return outputPath
+}
+
+var _ = Describe("Desktop", func() {
+
+	alertFixture := c.Alert{
+		Symbol:  "AAPL",
+		Message: "AAPL rose above 200.00 to 201.00",
+		Time:    time.Date(2025, 3, 4, 14, 5, 6, 0, time.UTC),
+	}
+
+	It("should show the alert with notify-send", func() {
+		outputPath := setNotificationProgram("notify-send", "")
+
+		desktop := NewDesktop(ConfigDesktop{OS: "linux"})
+
+		Expect(desktop.Notify(context.Background(), alertFixture)).To(Succeed())
+
+		output, err := os.ReadFile(outputPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(output)).To(Equal("--app-name=ticker\nticker: AAPL\nAAPL rose above 200.00 to 201.00\n"))
+	})
+
+	When("the operating system is macOS", func() {
+		It("should show the alert with osascript", func() {
+			outputPath := setNotificationProgram("osascript", "")
+
+			desktop := NewDesktop(ConfigDesktop{OS: "darwin"})
+
+			Expect(desktop.Notify(context.Background(), alertFixture)).To(Succeed())
+
+			output, err := os.ReadFile(outputPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(output)).To(HaveSuffix("end run\nticker: AAPL\nAAPL rose above 200.00 to 201.00\n"))
+		})
+	})
+
+	When("the notification program fails", func() {
+		It("should return an error with the output of the program", func() {
+			setNotificationProgram("notify-send", "echo 'no notification service' >&2; exit 1")
+
+			desktop := NewDesktop(ConfigDesktop{OS: "linux"})
+
+			Expect(desktop.Notify(context.Background(), alertFixture)).To(MatchError("notification failed: notify-send: exit status 1: no notification service"))
+		})
+	})
+
+	When("the operating system does not support desktop notifications", func() {
+		It("should return an error", func() {
+			desktop := NewDesktop(ConfigDesktop{OS: "windows"})
+
+			Expect(desktop.Notify(context.Background(), alertFixture)).To(MatchError("desktop notifications are not supported on windows"))
+		})
+	})
+})
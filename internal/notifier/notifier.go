@@ -0,0 +1,132 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+// Types of notifiers which can be set in the config
+const (
+	TypeDesktop = "desktop"
+	TypeCommand = "command"
+	TypeWebhook = "webhook"
+)
+
+// notifyTimeout is the maximum time to deliver an alert to a single notifier including retries
+const notifyTimeout = 30 * time.Second
+
+// Notifier delivers a triggered alert to a destination outside of the terminal
+type Notifier interface {
+	Notify(ctx context.Context, alert c.Alert) error
+}
+
+// Dispatcher sends alerts to the notifiers named by the rule which triggered them
+type Dispatcher struct {
+	notifiers map[string]Notifier
+	logger    *log.Logger
+}
+
+// Config represents the configuration for the dispatcher
+type Config struct {
+	Notifiers []c.ConfigNotifier
+	Logger    *log.Logger
+}
+
+// payload is the representation of an alert sent to commands and webhooks
+type payload struct {
+	Symbol    string  `json:"symbol"`
+	Condition string  `json:"condition"`
+	Message   string  `json:"message"`
+	Price     float64 `json:"price"`
+	Time      string  `json:"time"`
+}
+
+// IsValidType returns whether a type is one which notifiers can be set to
+func IsValidType(notifierType string) bool {
+	switch notifierType {
+	case TypeDesktop, TypeCommand, TypeWebhook:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewDispatcher creates a dispatcher with a notifier for each notifier in the config
+func NewDispatcher(config Config) (*Dispatcher, error) {
+
+	notifiers := make(map[string]Notifier)
+
+	for _, configNotifier := range config.Notifiers {
+		switch configNotifier.Type {
+		case TypeDesktop:
+			notifiers[configNotifier.Name] = NewDesktop(ConfigDesktop{})
+		case TypeCommand:
+			notifiers[configNotifier.Name] = NewCommand(ConfigCommand{
+				Command: configNotifier.Command,
+			})
+		case TypeWebhook:
+			configWebhook := ConfigWebhook{
+				URL:     configNotifier.URL,
+				Headers: configNotifier.Headers,
+				Retries: defaultRetries,
+			}
+
+			if configNotifier.Retries != nil {
+				configWebhook.Retries = *configNotifier.Retries
+			}
+
+			notifiers[configNotifier.Name] = NewWebhook(configWebhook)
+		default:
+			return nil, fmt.Errorf("notifier '%s' has unknown type '%s'", configNotifier.Name, configNotifier.Type)
+		}
+	}
+
+	return &Dispatcher{
+		notifiers: notifiers,
+		logger:    config.Logger,
+	}, nil
+}
+
+// Dispatch sends an alert to each of its notifiers in the background. Failures are logged since there is no one to
+// report them to.
+func (d *Dispatcher) Dispatch(alert c.Alert) {
+
+	for _, name := range alert.Notify {
+		n, exists := d.notifiers[name]
+
+		if !exists {
+			d.logf("failed to send alert for %s: notifier '%s' is not set", alert.Symbol, name)
+
+			continue
+		}
+
+		go func(name string, n Notifier) {
+			ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+			defer cancel()
+
+			if err := n.Notify(ctx, alert); err != nil {
+				d.logf("failed to send alert for %s to notifier '%s': %v", alert.Symbol, name, err)
+			}
+		}(name, n)
+	}
+}
+
+func (d *Dispatcher) logf(format string, v ...any) {
+	if d.logger != nil {
+		d.logger.Printf(format, v...)
+	}
+}
+
+func newPayload(alert c.Alert) payload {
+	return payload{
+		Symbol:    alert.Symbol,
+		Condition: alert.Condition,
+		Message:   alert.Message,
+		Price:     alert.Price,
+		Time:      alert.Time.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,66 @@
+package notifier_test
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/ghttp"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	. "github.com/achannarasappa/ticker/v5/internal/notifier"
+)
+
+var _ = Describe("Notifier", func() {
+
+	Describe("NewDispatcher", func() {
+		When("a notifier has an unknown type", func() {
+			It("should return an error", func() {
+				_, err := NewDispatcher(Config{Notifiers: []c.ConfigNotifier{{Name: "pager", Type: "sms"}}})
+
+				Expect(err).To(MatchError("notifier 'pager' has unknown type 'sms'"))
+			})
+		})
+	})
+
+	Describe("Dispatch", func() {
+		It("should send the alert to each notifier named by the alert", func() {
+			server := ghttp.NewServer()
+			defer server.Close()
+			server.AppendHandlers(ghttp.RespondWith(http.StatusOK, nil))
+
+			dispatcher, err := NewDispatcher(Config{Notifiers: []c.ConfigNotifier{
+				{Name: "chat", Type: "webhook", URL: server.URL()},
+				{Name: "other", Type: "webhook", URL: server.URL() + "/other"},
+			}})
+			Expect(err).NotTo(HaveOccurred())
+
+			dispatcher.Dispatch(c.Alert{Symbol: "AAPL", Time: time.Now(), Notify: []string{"chat"}})
+
+			Eventually(server.ReceivedRequests).Should(HaveLen(1))
+			Consistently(server.ReceivedRequests, 100*time.Millisecond).Should(HaveLen(1))
+		})
+
+		When("a notifier fails", func() {
+			It("should log the error", func() {
+				buffer := gbytes.NewBuffer()
+
+				dispatcher, err := NewDispatcher(Config{
+					Notifiers: []c.ConfigNotifier{
+						{Name: "script", Type: "command", Command: []string{"sh", "-c", "exit 1"}},
+					},
+					Logger: log.New(buffer, "", 0),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				dispatcher.Dispatch(c.Alert{Symbol: "AAPL", Notify: []string{"script", "missing"}})
+
+				Eventually(buffer).Should(gbytes.Say("failed to send alert for AAPL: notifier 'missing' is not set"))
+				Eventually(buffer).Should(gbytes.Say("failed to send alert for AAPL to notifier 'script': command 'sh' failed: exit status 1"))
+			})
+		})
+	})
+})
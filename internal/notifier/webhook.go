@@ -0,0 +1,113 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+const (
+	defaultRetries    = 3
+	defaultRetryDelay = time.Second
+)
+
+// Webhook sends each alert as JSON in a POST request to a URL and retries failed requests
+type Webhook struct {
+	url        string
+	headers    map[string]string
+	retries    int
+	retryDelay time.Duration
+	client     *http.Client
+}
+
+// ConfigWebhook represents the configuration for the webhook notifier
+type ConfigWebhook struct {
+	URL        string
+	Headers    map[string]string
+	Retries    int
+	RetryDelay time.Duration // Delay before the first retry which doubles on each retry after, defaults to 1s
+}
+
+// NewWebhook creates a new webhook notifier
+func NewWebhook(config ConfigWebhook) *Webhook {
+
+	retryDelay := config.RetryDelay
+	if retryDelay == 0 {
+		retryDelay = defaultRetryDelay
+	}
+
+	return &Webhook{
+		url:        config.URL,
+		headers:    config.Headers,
+		retries:    config.Retries,
+		retryDelay: retryDelay,
+		client:     &http.Client{},
+	}
+}
+
+// Notify sends the alert and retries on network errors and server errors until the retries are used up
+func (n *Webhook) Notify(ctx context.Context, alert c.Alert) error {
+
+	body, err := json.Marshal(newPayload(alert))
+	if err != nil {
+		return err
+	}
+
+	retryDelay := n.retryDelay
+
+	for attempt := 0; ; attempt++ {
+		isRetryable, err := n.send(ctx, body)
+
+		if err == nil {
+			return nil
+		}
+
+		if !isRetryable || attempt >= n.retries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (last error: %w)", ctx.Err(), err)
+		case <-time.After(retryDelay):
+		}
+
+		retryDelay *= 2
+	}
+}
+
+// send makes a single request and returns whether the request can be retried when it fails
+func (n *Webhook) send(ctx context.Context, body []byte) (bool, error) {
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	for key, value := range n.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+		return true, fmt.Errorf("request failed with status %d", resp.StatusCode)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return false, fmt.Errorf("request failed with status %d", resp.StatusCode)
+	}
+
+	return false, nil
+}
@@ -0,0 +1,103 @@
+package notifier_test
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	. "github.com/achannarasappa/ticker/v5/internal/notifier"
+)
+
+var _ = Describe("Webhook", func() {
+
+	var server *ghttp.Server
+
+	alertFixture := c.Alert{
+		Symbol:    "AAPL",
+		Condition: "above",
+		Message:   "AAPL rose above 200.00 to 201.00",
+		Price:     201,
+		Time:      time.Date(2025, 3, 4, 14, 5, 6, 0, time.UTC),
+	}
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should send the alert as JSON with the configured headers", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("POST", "/hooks/ticker"),
+			ghttp.VerifyHeaderKV("Content-Type", "application/json"),
+			ghttp.VerifyHeaderKV("Authorization", "Bearer abc"),
+			ghttp.VerifyJSON(`{"symbol":"AAPL","condition":"above","message":"AAPL rose above 200.00 to 201.00","price":201,"time":"2025-03-04T14:05:06Z"}`),
+			ghttp.RespondWith(http.StatusNoContent, nil),
+		))
+
+		webhook := NewWebhook(ConfigWebhook{
+			URL:     server.URL() + "/hooks/ticker",
+			Headers: map[string]string{"Authorization": "Bearer abc"},
+		})
+
+		Expect(webhook.Notify(context.Background(), alertFixture)).To(Succeed())
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+	})
+
+	When("the server fails", func() {
+		It("should retry the request until it succeeds", func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusServiceUnavailable, nil),
+				ghttp.RespondWith(http.StatusTooManyRequests, nil),
+				ghttp.RespondWith(http.StatusOK, nil),
+			)
+
+			webhook := NewWebhook(ConfigWebhook{
+				URL:        server.URL(),
+				Retries:    3,
+				RetryDelay: time.Millisecond,
+			})
+
+			Expect(webhook.Notify(context.Background(), alertFixture)).To(Succeed())
+			Expect(server.ReceivedRequests()).To(HaveLen(3))
+		})
+
+		It("should return an error once the retries are used up", func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusInternalServerError, nil),
+				ghttp.RespondWith(http.StatusInternalServerError, nil),
+			)
+
+			webhook := NewWebhook(ConfigWebhook{
+				URL:        server.URL(),
+				Retries:    1,
+				RetryDelay: time.Millisecond,
+			})
+
+			Expect(webhook.Notify(context.Background(), alertFixture)).To(MatchError("request failed with status 500"))
+			Expect(server.ReceivedRequests()).To(HaveLen(2))
+		})
+	})
+
+	When("the request is rejected", func() {
+		It("should return an error without retrying", func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusUnauthorized, nil))
+
+			webhook := NewWebhook(ConfigWebhook{
+				URL:        server.URL(),
+				Retries:    3,
+				RetryDelay: time.Millisecond,
+			})
+
+			Expect(webhook.Notify(context.Background(), alertFixture)).To(MatchError("request failed with status 401"))
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+})
@@ -16,17 +16,22 @@ import (
 
 // Options to configure print behavior
 type Options struct {
-	Format string
+	Format           string
+	Watch            bool
+	SnapshotInterval int
 }
 
 type jsonRow struct {
-	Name     string `json:"name"`
-	Symbol   string `json:"symbol"`
-	Price    string `json:"price"`
-	Value    string `json:"value"`
-	Cost     string `json:"cost"`
-	Quantity string `json:"quantity"`
-	Weight   string `json:"weight"`
+	Name         string `json:"name"`
+	Symbol       string `json:"symbol"`
+	Price        string `json:"price"`
+	Value        string `json:"value"`
+	Cost         string `json:"cost"`
+	Quantity     string `json:"quantity"`
+	Weight       string `json:"weight"`
+	RealizedGain string `json:"realized_gain"`
+	Income       string `json:"income"`
+	TotalReturn  string `json:"total_return"`
 }
 
 type jsonSummary struct {
@@ -36,15 +41,24 @@ type jsonSummary struct {
 	DayChangePercent   string `json:"day_change_percent"`
 	TotalChangeAmount  string `json:"total_change_amount"`
 	TotalChangePercent string `json:"total_change_percent"`
+	RealizedGain       string `json:"realized_gain"`
+	Income             string `json:"income"`
+	TotalReturnAmount  string `json:"total_return_amount"`
+	TotalReturnPercent string `json:"total_return_percent"`
+}
+
+// isPosition returns whether an asset is held or was held and sold or paid income which should still be reported
+func isPosition(asset c.Asset) bool {
+	return asset.Position.Quantity > 0 || asset.Position.RealizedGain != 0 || asset.Position.Income != 0
 }
 
 func convertAssetsToCSV(assets []c.Asset) string {
 	rows := [][]string{
-		{"name", "symbol", "price", "value", "cost", "quantity", "weight"},
+		{"name", "symbol", "price", "value", "cost", "quantity", "weight", "realized_gain", "income", "total_return"},
 	}
 
 	for _, asset := range assets {
-		if asset.Position.Quantity > 0 {
+		if isPosition(asset) {
 			rows = append(rows, []string{
 				asset.Name,
 				asset.Symbol,
@@ -53,6 +67,9 @@ func convertAssetsToCSV(assets []c.Asset) string {
 				util.ConvertFloatToString(asset.Position.Cost, true),
 				util.ConvertFloatToString(asset.Position.Quantity, true),
 				util.ConvertFloatToString(asset.Position.Weight, true),
+				util.ConvertFloatToString(asset.Position.RealizedGain, true),
+				util.ConvertFloatToString(asset.Position.Income, true),
+				util.ConvertFloatToString(asset.Position.TotalReturn.Amount, true),
 			})
 		}
 	}
@@ -70,15 +87,18 @@ func convertAssetsToJSON(assets []c.Asset) string {
 	var rows []jsonRow
 
 	for _, asset := range assets {
-		if asset.Position.Quantity > 0 {
+		if isPosition(asset) {
 			rows = append(rows, jsonRow{
-				Name:     asset.Name,
-				Symbol:   asset.Symbol,
-				Price:    fmt.Sprintf("%f", asset.QuotePrice.Price),
-				Value:    fmt.Sprintf("%f", asset.Position.Value),
-				Cost:     fmt.Sprintf("%f", asset.Position.Cost),
-				Quantity: fmt.Sprintf("%f", asset.Position.Quantity),
-				Weight:   fmt.Sprintf("%f", asset.Position.Weight),
+				Name:         asset.Name,
+				Symbol:       asset.Symbol,
+				Price:        fmt.Sprintf("%f", asset.QuotePrice.Price),
+				Value:        fmt.Sprintf("%f", asset.Position.Value),
+				Cost:         fmt.Sprintf("%f", asset.Position.Cost),
+				Quantity:     fmt.Sprintf("%f", asset.Position.Quantity),
+				Weight:       fmt.Sprintf("%f", asset.Position.Weight),
+				RealizedGain: fmt.Sprintf("%f", asset.Position.RealizedGain),
+				Income:       fmt.Sprintf("%f", asset.Position.Income),
+				TotalReturn:  fmt.Sprintf("%f", asset.Position.TotalReturn.Amount),
 			})
 		}
 	}
@@ -105,6 +125,10 @@ func convertSummaryToJSON(summary asset.PositionSummary) string {
 		DayChangePercent:   fmt.Sprintf("%f", summary.DayChange.Percent),
 		TotalChangeAmount:  fmt.Sprintf("%f", summary.TotalChange.Amount),
 		TotalChangePercent: fmt.Sprintf("%f", summary.TotalChange.Percent),
+		RealizedGain:       fmt.Sprintf("%f", summary.RealizedGain),
+		Income:             fmt.Sprintf("%f", summary.Income),
+		TotalReturnAmount:  fmt.Sprintf("%f", summary.TotalReturn.Amount),
+		TotalReturnPercent: fmt.Sprintf("%f", summary.TotalReturn.Percent),
 	}
 
 	out, err := json.Marshal(row)
@@ -118,7 +142,7 @@ func convertSummaryToJSON(summary asset.PositionSummary) string {
 
 func convertSummaryToCSV(summary asset.PositionSummary) string {
 	rows := [][]string{
-		{"total_value", "total_cost", "day_change_amount", "day_change_percent", "total_change_amount", "total_change_percent"},
+		{"total_value", "total_cost", "day_change_amount", "day_change_percent", "total_change_amount", "total_change_percent", "realized_gain", "income", "total_return_amount", "total_return_percent"},
 		{
 			fmt.Sprintf("%f", summary.Value),
 			fmt.Sprintf("%f", summary.Cost),
@@ -126,6 +150,10 @@ func convertSummaryToCSV(summary asset.PositionSummary) string {
 			fmt.Sprintf("%f", summary.DayChange.Percent),
 			fmt.Sprintf("%f", summary.TotalChange.Amount),
 			fmt.Sprintf("%f", summary.TotalChange.Percent),
+			fmt.Sprintf("%f", summary.RealizedGain),
+			fmt.Sprintf("%f", summary.Income),
+			fmt.Sprintf("%f", summary.TotalReturn.Amount),
+			fmt.Sprintf("%f", summary.TotalReturn.Percent),
 		},
 	}
 
@@ -138,8 +166,12 @@ func convertSummaryToCSV(summary asset.PositionSummary) string {
 }
 
 // Run prints holdings to the terminal
-func Run(dep *c.Dependencies, ctx *c.Context, options *Options) func(*cobra.Command, []string) {
-	return func(_ *cobra.Command, _ []string) {
+func Run(dep *c.Dependencies, ctx *c.Context, options *Options) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+
+		if options.Watch {
+			return watch(cmd, dep, ctx, options)
+		}
 
 		monitors, _ := mon.NewMonitor(mon.ConfigMonitor{
 			RefreshInterval: ctx.Config.RefreshInterval,
@@ -148,11 +180,23 @@ func Run(dep *c.Dependencies, ctx *c.Context, options *Options) func(*cobra.Comm
 				SessionRootURL:    dep.MonitorYahooSessionRootURL,
 				SessionCrumbURL:   dep.MonitorYahooSessionCrumbURL,
 				SessionConsentURL: dep.MonitorYahooSessionConsentURL,
+				StreamingURL:      dep.MonitorYahooStreamingURL,
 			},
 			ConfigMonitorPriceCoinbase: mon.ConfigMonitorPriceCoinbase{
 				BaseURL:      dep.MonitorPriceCoinbaseBaseURL,
 				StreamingURL: dep.MonitorPriceCoinbaseStreamingURL,
 			},
+			ConfigMonitorPriceCoingecko: mon.ConfigMonitorPriceCoingecko{
+				BaseURL: dep.MonitorPriceCoingeckoBaseURL,
+			},
+			ConfigMonitorPriceCoinCap: mon.ConfigMonitorPriceCoinCap{
+				BaseURL:      dep.MonitorPriceCoinCapBaseURL,
+				StreamingURL: dep.MonitorPriceCoinCapStreamingURL,
+				APIKey:       ctx.Config.CoinCapAPIKey,
+			},
+			ConfigMonitorPriceUserDefined: mon.ConfigMonitorPriceUserDefined{
+				Prices: ctx.Config.UserDefinedPrices,
+			},
 		})
 		monitors.SetAssetGroup(ctx.Groups[0], 0) //nolint:errcheck
 		assetGroupQuote := monitors.GetAssetGroupQuote()
@@ -161,10 +205,18 @@ func Run(dep *c.Dependencies, ctx *c.Context, options *Options) func(*cobra.Comm
 		if options.Format == "csv" {
 			fmt.Println(convertAssetsToCSV(assets))
 
-			return
+			return nil
+		}
+
+		if options.Format == FormatNDJSON {
+			fmt.Print(convertAssetsToNDJSON(assets))
+
+			return nil
 		}
 
 		fmt.Println(convertAssetsToJSON(assets))
+
+		return nil
 	}
 }
 
@@ -180,6 +232,17 @@ func RunSummary(dep *c.Dependencies, ctx *c.Context, options *Options) func(cmd
 				SessionCrumbURL:   dep.MonitorYahooSessionCrumbURL,
 				SessionConsentURL: dep.MonitorYahooSessionConsentURL,
 			},
+			ConfigMonitorPriceCoingecko: mon.ConfigMonitorPriceCoingecko{
+				BaseURL: dep.MonitorPriceCoingeckoBaseURL,
+			},
+			ConfigMonitorPriceCoinCap: mon.ConfigMonitorPriceCoinCap{
+				BaseURL:      dep.MonitorPriceCoinCapBaseURL,
+				StreamingURL: dep.MonitorPriceCoinCapStreamingURL,
+				APIKey:       ctx.Config.CoinCapAPIKey,
+			},
+			ConfigMonitorPriceUserDefined: mon.ConfigMonitorPriceUserDefined{
+				Prices: ctx.Config.UserDefinedPrices,
+			},
 		})
 		monitors.SetAssetGroup(ctx.Groups[0], 0) //nolint:errcheck
 		assetGroupQuote := monitors.GetAssetGroupQuote()
@@ -1,16 +1,19 @@
 package print_test
 
 import (
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"os"
+	"strings"
 
 	c "github.com/achannarasappa/ticker/v5/internal/common"
 	"github.com/achannarasappa/ticker/v5/internal/monitor/yahoo/unary"
 	"github.com/achannarasappa/ticker/v5/internal/print"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 	"github.com/onsi/gomega/ghttp"
 	"github.com/spf13/cobra"
 )
@@ -117,7 +120,7 @@ var _ = Describe("Print", func() {
 			output := getStdout(func() {
 				print.Run(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
 			})
-			Expect(output).To(Equal("[{\"name\":\"Alphabet Inc.\",\"symbol\":\"GOOG\",\"price\":\"2838.420000\",\"value\":\"28384.200000\",\"cost\":\"10000.000000\",\"quantity\":\"10.000000\",\"weight\":\"96.996890\"},{\"name\":\"Roblox Corporation\",\"symbol\":\"RBLX\",\"price\":\"87.880000\",\"value\":\"878.800000\",\"cost\":\"500.000000\",\"quantity\":\"10.000000\",\"weight\":\"3.003110\"}]\n"))
+			Expect(output).To(Equal("[{\"name\":\"Alphabet Inc.\",\"symbol\":\"GOOG\",\"price\":\"2838.420000\",\"value\":\"28384.200000\",\"cost\":\"10000.000000\",\"quantity\":\"10.000000\",\"weight\":\"96.996890\",\"realized_gain\":\"0.000000\",\"income\":\"0.000000\",\"total_return\":\"18384.200000\"},{\"name\":\"Roblox Corporation\",\"symbol\":\"RBLX\",\"price\":\"87.880000\",\"value\":\"878.800000\",\"cost\":\"500.000000\",\"quantity\":\"10.000000\",\"weight\":\"3.003110\",\"realized_gain\":\"0.000000\",\"income\":\"0.000000\",\"total_return\":\"378.800000\"}]\n"))
 		})
 
 		When("there are no holdings in the default group", func() {
@@ -134,6 +137,52 @@ var _ = Describe("Print", func() {
 			})
 		})
 
+		When("a position has been sold entirely or paid income", func() {
+			BeforeEach(func() {
+				inputContext.Groups[0].ConfigAssetGroup.Lots = []c.Lot{
+					{Symbol: "RBLX", UnitCost: 50, Quantity: 10},
+					{Symbol: "RBLX", UnitCost: 100, Quantity: 10, Type: "sell"},
+				}
+				inputContext.Groups[0].ConfigAssetGroup.Dividends = []c.Dividend{
+					{Symbol: "goog", Amount: 200},
+				}
+			})
+
+			It("should print the realized gain and income of the position", func() {
+				output := getStdout(func() {
+					print.Run(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+				})
+				Expect(output).To(ContainSubstring("\"symbol\":\"GOOG\",\"price\":\"2838.420000\",\"value\":\"0.000000\",\"cost\":\"0.000000\",\"quantity\":\"0.000000\",\"weight\":\"0.000000\",\"realized_gain\":\"0.000000\",\"income\":\"200.000000\""))
+				Expect(output).To(ContainSubstring("\"symbol\":\"RBLX\",\"price\":\"87.880000\",\"value\":\"0.000000\",\"cost\":\"0.000000\",\"quantity\":\"0.000000\",\"weight\":\"0.000000\",\"realized_gain\":\"500.000000\",\"income\":\"0.000000\""))
+			})
+
+			When("the format option is set to ndjson", func() {
+				It("should print the realized gain and income of the position", func() {
+					output := getStdout(func() {
+						print.Run(&inputDependencies, &inputContext, &print.Options{Format: "ndjson"})(&cobra.Command{}, []string{})
+					})
+					Expect(output).To(ContainSubstring(`"symbol":"GOOG","name":"Alphabet Inc.","price":2838.42,"value":0,"cost":0,"quantity":0,"weight":0,"day_change":0,"total_change":0,"realized_gain":0,"income":200,"total_return":200}`))
+					Expect(output).To(ContainSubstring(`"symbol":"RBLX","name":"Roblox Corporation","price":87.88,"value":0,"cost":0,"quantity":0,"weight":0,"day_change":0,"total_change":0,"realized_gain":500,"income":0,"total_return":0}`))
+				})
+			})
+		})
+
+		When("the format option is set to ndjson", func() {
+			It("should print each holding on a separate line", func() {
+				inputOptions := print.Options{
+					Format: "ndjson",
+				}
+				output := getStdout(func() {
+					print.Run(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+				})
+				Expect(strings.Split(output, "\n")).To(HaveExactElements(
+					HavePrefix(`{"symbol":"GOOG","name":"Alphabet Inc.","price":2838.42,"value":28384.2,"cost":10000,"quantity":10,`),
+					HavePrefix(`{"symbol":"RBLX","name":"Roblox Corporation","price":87.88,"value":878.8,"cost":500,"quantity":10,`),
+					BeEmpty(),
+				))
+			})
+		})
+
 		When("the format option is set to csv", func() {
 			It("should print the holdings in CSV format", func() {
 				inputOptions := print.Options{
@@ -142,7 +191,62 @@ var _ = Describe("Print", func() {
 				output := getStdout(func() {
 					print.Run(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
 				})
-				Expect(output).To(Equal("name,symbol,price,value,cost,quantity,weight\nAlphabet Inc.,GOOG,2838.42,28384.20,10000.00,10.000,96.997\nRoblox Corporation,RBLX,87.880,878.80,500.00,10.000,3.0031\n\n"))
+				Expect(output).To(Equal("name,symbol,price,value,cost,quantity,weight,realized_gain,income,total_return\nAlphabet Inc.,GOOG,2838.42,28384.20,10000.00,10.000,96.997,0.00,0.00,18384.20\nRoblox Corporation,RBLX,87.880,878.80,500.00,10.000,3.0031,0.00,0.00,378.80\n\n"))
+			})
+		})
+	})
+
+	Describe("Run with watch", func() {
+
+		var (
+			cmd    *cobra.Command
+			out    *gbytes.Buffer
+			cancel context.CancelFunc
+		)
+
+		BeforeEach(func() {
+			var ctx context.Context
+
+			ctx, cancel = context.WithCancel(context.Background())
+			out = gbytes.NewBuffer()
+			cmd = &cobra.Command{}
+			cmd.SetContext(ctx)
+			cmd.SetOut(out)
+		})
+
+		AfterEach(func() {
+			cancel()
+		})
+
+		It("should print a line for each quote until stopped", func() {
+			chanErr := make(chan error, 1)
+
+			go func() {
+				chanErr <- print.Run(&inputDependencies, &inputContext, &print.Options{Watch: true})(cmd, []string{})
+			}()
+
+			Eventually(out).Should(gbytes.Say(`{"type":"quote","time":"[^"]+","symbol":"GOOG","name":"Alphabet Inc.","currency":"USD","price":2838.42,`))
+			Eventually(out).Should(gbytes.Say(`{"type":"quote","time":"[^"]+","symbol":"RBLX",`))
+
+			cancel()
+			Eventually(chanErr).Should(Receive(BeNil()))
+			Expect(string(out.Contents())).NotTo(ContainSubstring(`"type":"snapshot"`))
+		})
+
+		When("the snapshot interval is set", func() {
+			It("should also print a snapshot of the default group", func() {
+				go print.Run(&inputDependencies, &inputContext, &print.Options{Watch: true, SnapshotInterval: 60, Format: "ndjson"})(cmd, []string{}) //nolint:errcheck
+
+				Eventually(out).Should(gbytes.Say(`{"type":"snapshot","time":"[^"]+","group":"","assets":\[{"symbol":"GOOG","name":"Alphabet Inc.","price":2838.42,"value":28384.2,"cost":10000,"quantity":10,`))
+				Eventually(out).Should(gbytes.Say(`"summary":{"value":29263,"cost":10500,`))
+			})
+		})
+
+		When("the format is not ndjson", func() {
+			It("should return an error", func() {
+				err := print.Run(&inputDependencies, &inputContext, &print.Options{Watch: true, Format: "csv"})(cmd, []string{})
+
+				Expect(err).To(MatchError("format 'csv' can not be used with --watch, use ndjson instead"))
 			})
 		})
 	})
@@ -153,7 +257,41 @@ var _ = Describe("Print", func() {
 			output := getStdout(func() {
 				print.RunSummary(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
 			})
-			Expect(output).To(Equal("{\"total_value\":\"29263.000000\",\"total_cost\":\"10500.000000\",\"day_change_amount\":\"2750.500000\",\"day_change_percent\":\"9.399241\",\"total_change_amount\":\"18763.000000\",\"total_change_percent\":\"178.695238\"}\n"))
+			Expect(output).To(Equal("{\"total_value\":\"29263.000000\",\"total_cost\":\"10500.000000\",\"day_change_amount\":\"2750.500000\",\"day_change_percent\":\"9.399241\",\"total_change_amount\":\"18763.000000\",\"total_change_percent\":\"178.695238\",\"realized_gain\":\"0.000000\",\"income\":\"0.000000\",\"total_return_amount\":\"18763.000000\",\"total_return_percent\":\"178.695238\"}\n"))
+		})
+
+		When("there are sells", func() {
+			BeforeEach(func() {
+				inputContext.Groups[0].ConfigAssetGroup.Lots = append(inputContext.Groups[0].ConfigAssetGroup.Lots, c.Lot{
+					Symbol:   "RBLX",
+					UnitCost: 100,
+					Quantity: 5,
+					Type:     "sell",
+				})
+			})
+
+			It("should print the realized gain", func() {
+				output := getStdout(func() {
+					print.RunSummary(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+				})
+				Expect(output).To(ContainSubstring("\"realized_gain\":\"250.000000\""))
+			})
+		})
+
+		When("there are dividends", func() {
+			BeforeEach(func() {
+				inputContext.Groups[0].ConfigAssetGroup.Dividends = []c.Dividend{
+					{Symbol: "GOOG", Amount: 200},
+					{Symbol: "RBLX", Amount: 37},
+				}
+			})
+
+			It("should print the income and total return", func() {
+				output := getStdout(func() {
+					print.RunSummary(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
+				})
+				Expect(output).To(ContainSubstring("\"income\":\"237.000000\",\"total_return_amount\":\"19000.000000\""))
+			})
 		})
 
 		When("the format option is set to csv", func() {
@@ -164,7 +302,7 @@ var _ = Describe("Print", func() {
 				output := getStdout(func() {
 					print.RunSummary(&inputDependencies, &inputContext, &inputOptions)(&cobra.Command{}, []string{})
 				})
-				Expect(output).To(Equal("total_value,total_cost,day_change_amount,day_change_percent,total_change_amount,total_change_percent\n29263.000000,10500.000000,2750.500000,9.399241,18763.000000,178.695238\n\n"))
+				Expect(output).To(Equal("total_value,total_cost,day_change_amount,day_change_percent,total_change_amount,total_change_percent,realized_gain,income,total_return_amount,total_return_percent\n29263.000000,10500.000000,2750.500000,9.399241,18763.000000,178.695238,0.000000,0.000000,18763.000000,178.695238\n\n"))
 			})
 		})
 
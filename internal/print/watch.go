@@ -0,0 +1,263 @@
+package print //nolint:predeclared
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/achannarasappa/ticker/v5/internal/asset"
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	mon "github.com/achannarasappa/ticker/v5/internal/monitor"
+
+	"github.com/spf13/cobra"
+)
+
+// FormatNDJSON prints one JSON object per line
+const FormatNDJSON = "ndjson"
+
+type ndjsonQuote struct {
+	Type           string  `json:"type"`
+	Time           string  `json:"time"`
+	Symbol         string  `json:"symbol"`
+	Name           string  `json:"name"`
+	Currency       string  `json:"currency"`
+	Price          float64 `json:"price"`
+	PricePrevClose float64 `json:"price_prev_close"`
+	Change         float64 `json:"change"`
+	ChangePercent  float64 `json:"change_percent"`
+	Volume         float64 `json:"volume"`
+	IsActive       bool    `json:"is_active"`
+}
+
+type ndjsonAsset struct {
+	Symbol       string  `json:"symbol"`
+	Name         string  `json:"name"`
+	Price        float64 `json:"price"`
+	Value        float64 `json:"value"`
+	Cost         float64 `json:"cost"`
+	Quantity     float64 `json:"quantity"`
+	Weight       float64 `json:"weight"`
+	DayChange    float64 `json:"day_change"`
+	TotalChange  float64 `json:"total_change"`
+	RealizedGain float64 `json:"realized_gain"`
+	Income       float64 `json:"income"`
+	TotalReturn  float64 `json:"total_return"`
+}
+
+type ndjsonSummary struct {
+	Value              float64 `json:"value"`
+	Cost               float64 `json:"cost"`
+	DayChangeAmount    float64 `json:"day_change_amount"`
+	DayChangePercent   float64 `json:"day_change_percent"`
+	TotalChangeAmount  float64 `json:"total_change_amount"`
+	TotalChangePercent float64 `json:"total_change_percent"`
+	RealizedGain       float64 `json:"realized_gain"`
+	Income             float64 `json:"income"`
+	TotalReturnAmount  float64 `json:"total_return_amount"`
+	TotalReturnPercent float64 `json:"total_return_percent"`
+}
+
+type ndjsonSnapshot struct {
+	Type    string        `json:"type"`
+	Time    string        `json:"time"`
+	Group   string        `json:"group"`
+	Assets  []ndjsonAsset `json:"assets"`
+	Summary ndjsonSummary `json:"summary"`
+}
+
+// lineWriter writes values as JSON lines from any goroutine without interleaving them
+type lineWriter struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+func newLineWriter(w io.Writer) *lineWriter {
+	return &lineWriter{
+		encoder: json.NewEncoder(w),
+	}
+}
+
+func (l *lineWriter) write(v any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.encoder.Encode(v) //nolint:errcheck,errchkjson
+}
+
+func newNDJSONQuote(assetQuote c.AssetQuote, now time.Time) ndjsonQuote {
+	return ndjsonQuote{
+		Type:           "quote",
+		Time:           now.Format(time.RFC3339),
+		Symbol:         assetQuote.Symbol,
+		Name:           assetQuote.Name,
+		Currency:       assetQuote.Currency.FromCurrencyCode,
+		Price:          assetQuote.QuotePrice.Price,
+		PricePrevClose: assetQuote.QuotePrice.PricePrevClose,
+		Change:         assetQuote.QuotePrice.Change,
+		ChangePercent:  assetQuote.QuotePrice.ChangePercent,
+		Volume:         assetQuote.QuoteExtended.Volume,
+		IsActive:       assetQuote.Exchange.IsActive,
+	}
+}
+
+func newNDJSONAsset(a c.Asset) ndjsonAsset {
+	return ndjsonAsset{
+		Symbol:       a.Symbol,
+		Name:         a.Name,
+		Price:        a.QuotePrice.Price,
+		Value:        a.Position.Value,
+		Cost:         a.Position.Cost,
+		Quantity:     a.Position.Quantity,
+		Weight:       a.Position.Weight,
+		DayChange:    a.Position.DayChange.Amount,
+		TotalChange:  a.Position.TotalChange.Amount,
+		RealizedGain: a.Position.RealizedGain,
+		Income:       a.Position.Income,
+		TotalReturn:  a.Position.TotalReturn.Amount,
+	}
+}
+
+func newNDJSONSnapshot(ctx c.Context, assetGroupQuote c.AssetGroupQuote, now time.Time) ndjsonSnapshot {
+
+	assets, summary := asset.GetAssets(ctx, assetGroupQuote)
+	snapshot := ndjsonSnapshot{
+		Type:   "snapshot",
+		Time:   now.Format(time.RFC3339),
+		Group:  assetGroupQuote.AssetGroup.Name,
+		Assets: make([]ndjsonAsset, 0, len(assets)),
+		Summary: ndjsonSummary{
+			Value:              summary.Value,
+			Cost:               summary.Cost,
+			DayChangeAmount:    summary.DayChange.Amount,
+			DayChangePercent:   summary.DayChange.Percent,
+			TotalChangeAmount:  summary.TotalChange.Amount,
+			TotalChangePercent: summary.TotalChange.Percent,
+			RealizedGain:       summary.RealizedGain,
+			Income:             summary.Income,
+			TotalReturnAmount:  summary.TotalReturn.Amount,
+			TotalReturnPercent: summary.TotalReturn.Percent,
+		},
+	}
+
+	for _, a := range assets {
+		snapshot.Assets = append(snapshot.Assets, newNDJSONAsset(a))
+	}
+
+	return snapshot
+}
+
+func convertAssetsToNDJSON(assets []c.Asset) string {
+
+	var out string
+
+	for _, a := range assets {
+		if isPosition(a) {
+			line, err := json.Marshal(newNDJSONAsset(a))
+			if err != nil {
+				return err.Error()
+			}
+
+			out += string(line) + "\n"
+		}
+	}
+
+	return out
+}
+
+// watch keeps the monitors running and prints a line for each quote update and optionally a snapshot of the default
+// group on an interval until interrupted
+func watch(cmd *cobra.Command, dep *c.Dependencies, ctx *c.Context, options *Options) error {
+
+	if options.Format != "" && options.Format != FormatNDJSON {
+		return fmt.Errorf("format '%s' can not be used with --watch, use ndjson instead", options.Format) //nolint:goerr113
+	}
+
+	runCtx := cmd.Context()
+	if runCtx == nil {
+		runCtx = context.Background()
+	}
+
+	runCtx, stop := signal.NotifyContext(runCtx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	monitors, err := mon.NewMonitor(mon.ConfigMonitor{
+		RefreshInterval: ctx.Config.RefreshInterval,
+		Logger:          ctx.Logger,
+		ConfigMonitorsYahoo: mon.ConfigMonitorsYahoo{
+			BaseURL:           dep.MonitorYahooBaseURL,
+			SessionRootURL:    dep.MonitorYahooSessionRootURL,
+			SessionCrumbURL:   dep.MonitorYahooSessionCrumbURL,
+			SessionConsentURL: dep.MonitorYahooSessionConsentURL,
+			StreamingURL:      dep.MonitorYahooStreamingURL,
+		},
+		ConfigMonitorPriceCoinbase: mon.ConfigMonitorPriceCoinbase{
+			BaseURL:      dep.MonitorPriceCoinbaseBaseURL,
+			StreamingURL: dep.MonitorPriceCoinbaseStreamingURL,
+		},
+		ConfigMonitorPriceCoingecko: mon.ConfigMonitorPriceCoingecko{
+			BaseURL: dep.MonitorPriceCoingeckoBaseURL,
+		},
+		ConfigMonitorPriceCoinCap: mon.ConfigMonitorPriceCoinCap{
+			BaseURL:      dep.MonitorPriceCoinCapBaseURL,
+			StreamingURL: dep.MonitorPriceCoinCapStreamingURL,
+			APIKey:       ctx.Config.CoinCapAPIKey,
+		},
+		ConfigMonitorPriceUserDefined: mon.ConfigMonitorPriceUserDefined{
+			Prices: ctx.Config.UserDefinedPrices,
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	out := newLineWriter(cmd.OutOrStdout())
+
+	err = monitors.SetOnUpdate(mon.ConfigUpdateFns{
+		OnUpdateAssetQuote: func(_ string, assetQuote c.AssetQuote, _ int) {
+			out.write(newNDJSONQuote(assetQuote, time.Now()))
+		},
+		OnUpdateAssetGroupQuote: func(_ c.AssetGroupQuote, _ int) {},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	monitors.Start()
+	defer monitors.Stop()
+
+	if err := monitors.SetAssetGroup(ctx.Groups[0], 0); err != nil {
+		return err
+	}
+
+	// Print the initial quotes since updates are only sent when a quote changes
+	for _, assetQuote := range monitors.GetAssetGroupQuote().AssetQuotes {
+		out.write(newNDJSONQuote(assetQuote, time.Now()))
+	}
+
+	if options.SnapshotInterval <= 0 {
+		<-runCtx.Done()
+
+		return nil
+	}
+
+	ticker := time.NewTicker(time.Duration(options.SnapshotInterval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		out.write(newNDJSONSnapshot(*ctx, monitors.GetAssetGroupQuote(), time.Now()))
+
+		select {
+		case <-runCtx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/achannarasappa/ticker/v5/internal/asset"
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/metrics"
+)
+
+// gauge is a gauge collected on each scrape with a function to get its value from a quote or position
+type gauge[T any] struct {
+	desc  *prometheus.Desc
+	value func(T) float64
+}
+
+//nolint:gochecknoglobals
+var (
+	quoteLabels    = []string{"symbol", "source", "currency"}
+	positionLabels = []string{"group", "symbol", "currency"}
+	groupLabels    = []string{"group"}
+
+	quoteGauges = []gauge[c.AssetQuote]{
+		{prometheus.NewDesc("ticker_quote_price", "Price of a symbol in the currency of its quote.", quoteLabels, nil), func(q c.AssetQuote) float64 { return q.QuotePrice.Price }},
+		{prometheus.NewDesc("ticker_quote_change_percent", "Change in price since the previous close in percent.", quoteLabels, nil), func(q c.AssetQuote) float64 { return q.QuotePrice.ChangePercent }},
+		{prometheus.NewDesc("ticker_quote_volume", "Volume traded in the current session.", quoteLabels, nil), func(q c.AssetQuote) float64 { return q.QuoteExtended.Volume }},
+	}
+	marketStateDesc = prometheus.NewDesc("ticker_quote_market_state", "Set to 1 for the current state of the market a symbol trades on.", append(quoteLabels[:len(quoteLabels):len(quoteLabels)], "state"), nil)
+	positionGauges  = []gauge[c.Asset]{
+		{prometheus.NewDesc("ticker_position_value", "Value of a position.", positionLabels, nil), func(a c.Asset) float64 { return a.Position.Value }},
+		{prometheus.NewDesc("ticker_position_cost", "Cost basis of a position.", positionLabels, nil), func(a c.Asset) float64 { return a.Position.Cost }},
+		{prometheus.NewDesc("ticker_position_day_change", "Change in value of a position since the previous close.", positionLabels, nil), func(a c.Asset) float64 { return a.Position.DayChange.Amount }},
+		{prometheus.NewDesc("ticker_position_weight", "Weight of a position in its group in percent.", positionLabels, nil), func(a c.Asset) float64 { return a.Position.Weight }},
+	}
+	groupGauges = []gauge[asset.PositionSummary]{
+		{prometheus.NewDesc("ticker_group_value", "Total value of the positions in a group.", groupLabels, nil), func(s asset.PositionSummary) float64 { return s.Value }},
+		{prometheus.NewDesc("ticker_group_cost", "Total cost basis of the positions in a group.", groupLabels, nil), func(s asset.PositionSummary) float64 { return s.Cost }},
+		{prometheus.NewDesc("ticker_group_day_change", "Change in total value of the positions in a group since the previous close.", groupLabels, nil), func(s asset.PositionSummary) float64 { return s.DayChange.Amount }},
+	}
+)
+
+// groupAssets is the assets and position summary of a single group
+type groupAssets struct {
+	name    string
+	assets  []c.Asset
+	summary asset.PositionSummary
+}
+
+// metricsHandler returns a handler which serves gauges for quotes, positions, and groups along with the counters of
+// operational events in the Prometheus text exposition format
+func (s *Server) metricsHandler() http.Handler {
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collector{server: s})
+	metrics.MustRegister(registry)
+
+	// A sample which can not be collected is left out rather than failing the whole scrape
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{ErrorHandling: promhttp.ContinueOnError})
+}
+
+// collector collects gauges for the current quotes, positions, and groups from the monitor on each scrape
+type collector struct {
+	server *Server
+}
+
+// Describe sends the descriptions of all gauges
+func (m collector) Describe(ch chan<- *prometheus.Desc) {
+
+	for _, g := range quoteGauges {
+		ch <- g.desc
+	}
+
+	ch <- marketStateDesc
+
+	for _, g := range positionGauges {
+		ch <- g.desc
+	}
+
+	for _, g := range groupGauges {
+		ch <- g.desc
+	}
+}
+
+// Collect sends the value of each gauge for each quote, position, and group
+func (m collector) Collect(ch chan<- prometheus.Metric) {
+
+	s := m.server
+	assetQuotes := uniqueAssetQuotes(s.monitor.GetAssetGroupQuote().AssetQuotes)
+	groups := make([]groupAssets, 0, len(s.ctx.Groups))
+
+	for _, group := range s.ctx.Groups {
+		assets, summary := asset.GetAssets(s.ctx, c.AssetGroupQuote{
+			AssetGroup:  group,
+			AssetQuotes: filterAssetQuotes(assetQuotes, group),
+		})
+
+		groups = append(groups, groupAssets{name: group.Name, assets: assets, summary: summary})
+	}
+
+	for _, assetQuote := range assetQuotes {
+		labelValues := []string{assetQuote.Symbol, quoteSourceNames[assetQuote.QuoteSource], assetQuote.Currency.FromCurrencyCode}
+
+		for _, g := range quoteGauges {
+			ch <- prometheus.MustNewConstMetric(g.desc, prometheus.GaugeValue, g.value(assetQuote), labelValues...)
+		}
+
+		collectMarketState(ch, assetQuote, labelValues)
+	}
+
+	for _, group := range groups {
+		for _, a := range group.assets {
+			if a.Position == (c.Position{}) {
+				continue
+			}
+
+			for _, g := range positionGauges {
+				ch <- prometheus.MustNewConstMetric(g.desc, prometheus.GaugeValue, g.value(a), group.name, a.Symbol, a.Currency.ToCurrencyCode)
+			}
+		}
+
+		for _, g := range groupGauges {
+			ch <- prometheus.MustNewConstMetric(g.desc, prometheus.GaugeValue, g.value(group.summary), group.name)
+		}
+	}
+}
+
+// collectMarketState sends a sample for each market state of a symbol which is 1 for the current state and 0 otherwise
+// so that state changes can be graphed
+func collectMarketState(ch chan<- prometheus.Metric, assetQuote c.AssetQuote, labelValues []string) {
+
+	states := []c.ExchangeState{c.ExchangeStateOpen, c.ExchangeStatePremarket, c.ExchangeStatePostmarket, c.ExchangeStateClosed}
+
+	for _, state := range states {
+		value := 0.0
+		if assetQuote.Exchange.State == state {
+			value = 1.0
+		}
+
+		ch <- prometheus.MustNewConstMetric(marketStateDesc, prometheus.GaugeValue, value, append(labelValues[:len(labelValues):len(labelValues)], exchangeStateNames[state])...)
+	}
+}
+
+// uniqueAssetQuotes removes quotes for symbols which are in more than one group so each series is only written once
+func uniqueAssetQuotes(assetQuotes []c.AssetQuote) []c.AssetQuote {
+
+	unique := make([]c.AssetQuote, 0, len(assetQuotes))
+	isAdded := make(map[string]bool)
+
+	for _, assetQuote := range assetQuotes {
+		key := quoteSourceNames[assetQuote.QuoteSource] + ":" + assetQuote.Symbol
+
+		if isAdded[key] {
+			continue
+		}
+
+		isAdded[key] = true
+		unique = append(unique, assetQuote)
+	}
+
+	return unique
+}
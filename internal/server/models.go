@@ -0,0 +1,246 @@
+package server
+
+import (
+	"time"
+
+	"github.com/achannarasappa/ticker/v5/internal/asset"
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+//nolint:gochecknoglobals
+var (
+	assetClassNames = map[c.AssetClass]string{
+		c.AssetClassCash:            "cash",
+		c.AssetClassStock:           "stock",
+		c.AssetClassCryptocurrency:  "cryptocurrency",
+		c.AssetClassPrivateSecurity: "private-security",
+		c.AssetClassUnknown:         "unknown",
+		c.AssetClassFuturesContract: "futures-contract",
+		c.AssetClassCurrency:        "currency",
+	}
+	quoteSourceNames = map[c.QuoteSource]string{
+		c.QuoteSourceYahoo:       "yahoo",
+		c.QuoteSourceUserDefined: "user-defined",
+		c.QuoteSourceCoingecko:   "coingecko",
+		c.QuoteSourceUnknown:     "unknown",
+		c.QuoteSourceCoinCap:     "coincap",
+		c.QuoteSourceCoinbase:    "coinbase",
+	}
+	exchangeStateNames = map[c.ExchangeState]string{
+		c.ExchangeStateOpen:       "open",
+		c.ExchangeStatePremarket:  "premarket",
+		c.ExchangeStatePostmarket: "postmarket",
+		c.ExchangeStateClosed:     "closed",
+	}
+)
+
+type responseError struct {
+	Error string `json:"error"`
+}
+
+type responseGroup struct {
+	ID      int      `json:"id"`
+	Name    string   `json:"name"`
+	Symbols []string `json:"symbols"`
+}
+
+type responseCurrency struct {
+	From string  `json:"from"`
+	To   string  `json:"to"`
+	Rate float64 `json:"rate,omitempty"`
+}
+
+type responseQuotePrice struct {
+	Price          float64 `json:"price"`
+	PricePrevClose float64 `json:"price_prev_close"`
+	PriceOpen      float64 `json:"price_open"`
+	PriceDayHigh   float64 `json:"price_day_high"`
+	PriceDayLow    float64 `json:"price_day_low"`
+	Change         float64 `json:"change"`
+	ChangePercent  float64 `json:"change_percent"`
+}
+
+type responseQuoteExtended struct {
+	FiftyTwoWeekHigh float64 `json:"fifty_two_week_high"`
+	FiftyTwoWeekLow  float64 `json:"fifty_two_week_low"`
+	MarketCap        float64 `json:"market_cap"`
+	Volume           float64 `json:"volume"`
+}
+
+type responseQuoteFutures struct {
+	SymbolUnderlying string  `json:"symbol_underlying"`
+	IndexPrice       float64 `json:"index_price"`
+	Basis            float64 `json:"basis"`
+	OpenInterest     float64 `json:"open_interest"`
+	Expiry           string  `json:"expiry"`
+	ContractSize     float64 `json:"contract_size"`
+}
+
+type responseExchange struct {
+	Name                    string  `json:"name"`
+	Delay                   float64 `json:"delay"`
+	State                   string  `json:"state"`
+	IsActive                bool    `json:"is_active"`
+	IsRegularTradingSession bool    `json:"is_regular_trading_session"`
+}
+
+type responseChange struct {
+	Amount  float64 `json:"amount"`
+	Percent float64 `json:"percent"`
+}
+
+type responsePosition struct {
+	Value        float64        `json:"value"`
+	Cost         float64        `json:"cost"`
+	Quantity     float64        `json:"quantity"`
+	UnitValue    float64        `json:"unit_value"`
+	UnitCost     float64        `json:"unit_cost"`
+	DayChange    responseChange `json:"day_change"`
+	TotalChange  responseChange `json:"total_change"`
+	Weight       float64        `json:"weight"`
+	RealizedGain float64        `json:"realized_gain"`
+	Income       float64        `json:"income"`
+	TotalReturn  responseChange `json:"total_return"`
+}
+
+// responseQuote is an asset quote or an asset without its position
+type responseQuote struct {
+	Symbol        string                `json:"symbol"`
+	Name          string                `json:"name"`
+	Class         string                `json:"class"`
+	Source        string                `json:"source"`
+	Currency      responseCurrency      `json:"currency"`
+	QuotePrice    responseQuotePrice    `json:"quote_price"`
+	QuoteExtended responseQuoteExtended `json:"quote_extended"`
+	QuoteFutures  *responseQuoteFutures `json:"quote_futures,omitempty"`
+	Exchange      responseExchange      `json:"exchange"`
+}
+
+type responseAsset struct {
+	responseQuote
+	Position *responsePosition `json:"position,omitempty"`
+}
+
+type responseSummary struct {
+	Value        float64        `json:"value"`
+	Cost         float64        `json:"cost"`
+	DayChange    responseChange `json:"day_change"`
+	TotalChange  responseChange `json:"total_change"`
+	RealizedGain float64        `json:"realized_gain"`
+	Income       float64        `json:"income"`
+	TotalReturn  responseChange `json:"total_return"`
+}
+
+// responseUpdate is a single asset quote update pushed to event stream subscribers
+type responseUpdate struct {
+	ID       string        `json:"id"`
+	Sequence int64         `json:"sequence"`
+	Data     responseQuote `json:"data"`
+}
+
+type responseCurrencyRate struct {
+	From string  `json:"from"`
+	To   string  `json:"to"`
+	Rate float64 `json:"rate"`
+}
+
+type responseAlert struct {
+	Symbol    string  `json:"symbol"`
+	Condition string  `json:"condition"`
+	Message   string  `json:"message"`
+	Price     float64 `json:"price"`
+	Time      string  `json:"time"`
+}
+
+func newResponseQuote(assetQuote c.AssetQuote) responseQuote {
+
+	response := responseQuote{
+		Symbol: assetQuote.Symbol,
+		Name:   assetQuote.Name,
+		Class:  assetClassNames[assetQuote.Class],
+		Source: quoteSourceNames[assetQuote.QuoteSource],
+		Currency: responseCurrency{
+			From: assetQuote.Currency.FromCurrencyCode,
+			To:   assetQuote.Currency.ToCurrencyCode,
+			Rate: assetQuote.Currency.Rate,
+		},
+		QuotePrice: responseQuotePrice(assetQuote.QuotePrice),
+		QuoteExtended: responseQuoteExtended{
+			FiftyTwoWeekHigh: assetQuote.QuoteExtended.FiftyTwoWeekHigh,
+			FiftyTwoWeekLow:  assetQuote.QuoteExtended.FiftyTwoWeekLow,
+			MarketCap:        assetQuote.QuoteExtended.MarketCap,
+			Volume:           assetQuote.QuoteExtended.Volume,
+		},
+		Exchange: responseExchange{
+			Name:                    assetQuote.Exchange.Name,
+			Delay:                   assetQuote.Exchange.Delay,
+			State:                   exchangeStateNames[assetQuote.Exchange.State],
+			IsActive:                assetQuote.Exchange.IsActive,
+			IsRegularTradingSession: assetQuote.Exchange.IsRegularTradingSession,
+		},
+	}
+
+	if assetQuote.Class == c.AssetClassFuturesContract {
+		quoteFutures := responseQuoteFutures(assetQuote.QuoteFutures)
+		response.QuoteFutures = &quoteFutures
+	}
+
+	return response
+}
+
+func newResponseAsset(a c.Asset) responseAsset {
+
+	response := responseAsset{
+		responseQuote: newResponseQuote(c.AssetQuote{
+			Name:          a.Name,
+			Symbol:        a.Symbol,
+			Class:         a.Class,
+			Currency:      a.Currency,
+			QuotePrice:    a.QuotePrice,
+			QuoteExtended: a.QuoteExtended,
+			QuoteFutures:  a.QuoteFutures,
+			QuoteSource:   a.QuoteSource,
+			Exchange:      a.Exchange,
+		}),
+	}
+
+	if a.Position != (c.Position{}) {
+		response.Position = &responsePosition{
+			Value:        a.Position.Value,
+			Cost:         a.Position.Cost,
+			Quantity:     a.Position.Quantity,
+			UnitValue:    a.Position.UnitValue,
+			UnitCost:     a.Position.UnitCost,
+			DayChange:    responseChange(a.Position.DayChange),
+			TotalChange:  responseChange(a.Position.TotalChange),
+			Weight:       a.Position.Weight,
+			RealizedGain: a.Position.RealizedGain,
+			Income:       a.Position.Income,
+			TotalReturn:  responseChange(a.Position.TotalReturn),
+		}
+	}
+
+	return response
+}
+
+func newResponseSummary(summary asset.PositionSummary) responseSummary {
+	return responseSummary{
+		Value:        summary.Value,
+		Cost:         summary.Cost,
+		DayChange:    responseChange(summary.DayChange),
+		TotalChange:  responseChange(summary.TotalChange),
+		RealizedGain: summary.RealizedGain,
+		Income:       summary.Income,
+		TotalReturn:  responseChange(summary.TotalReturn),
+	}
+}
+
+func newResponseAlert(alert c.Alert) responseAlert {
+	return responseAlert{
+		Symbol:    alert.Symbol,
+		Condition: alert.Condition,
+		Message:   alert.Message,
+		Price:     alert.Price,
+		Time:      alert.Time.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	mon "github.com/achannarasappa/ticker/v5/internal/monitor"
+
+	"github.com/spf13/cobra"
+)
+
+const shutdownTimeout = 5 * time.Second
+
+// Options to configure serve behavior
+type Options struct {
+	Address string
+}
+
+// Run starts the monitors for all groups and serves the API until the process is interrupted
+func Run(dep *c.Dependencies, ctx *c.Context, options *Options) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+
+		monitors, err := mon.NewMonitor(mon.ConfigMonitor{
+			RefreshInterval: ctx.Config.RefreshInterval,
+			TargetCurrency:  ctx.Config.Currency,
+			Logger:          ctx.Logger,
+			Cache:           ctx.Cache,
+			Alerts:          ctx.Config.Alerts,
+			Notifiers:       ctx.Config.Notifiers,
+			LotMatching:     ctx.Config.LotMatching,
+			ConfigMonitorsYahoo: mon.ConfigMonitorsYahoo{
+				BaseURL:           dep.MonitorYahooBaseURL,
+				SessionRootURL:    dep.MonitorYahooSessionRootURL,
+				SessionCrumbURL:   dep.MonitorYahooSessionCrumbURL,
+				SessionConsentURL: dep.MonitorYahooSessionConsentURL,
+				StreamingURL:      dep.MonitorYahooStreamingURL,
+			},
+			ConfigMonitorPriceCoinbase: mon.ConfigMonitorPriceCoinbase{
+				BaseURL:      dep.MonitorPriceCoinbaseBaseURL,
+				StreamingURL: dep.MonitorPriceCoinbaseStreamingURL,
+			},
+			ConfigMonitorPriceCoingecko: mon.ConfigMonitorPriceCoingecko{
+				BaseURL: dep.MonitorPriceCoingeckoBaseURL,
+			},
+			ConfigMonitorPriceCoinCap: mon.ConfigMonitorPriceCoinCap{
+				BaseURL:      dep.MonitorPriceCoinCapBaseURL,
+				StreamingURL: dep.MonitorPriceCoinCapStreamingURL,
+				APIKey:       ctx.Config.CoinCapAPIKey,
+			},
+			ConfigMonitorPriceUserDefined: mon.ConfigMonitorPriceUserDefined{
+				Prices: ctx.Config.UserDefinedPrices,
+			},
+		})
+
+		if err != nil {
+			return err
+		}
+
+		s := NewServer(Config{
+			Context: *ctx,
+			Monitor: monitors,
+		})
+
+		err = monitors.SetOnUpdate(mon.ConfigUpdateFns{
+			OnUpdateAssetQuote: func(symbol string, assetQuote c.AssetQuote, _ int) {
+				s.PublishAssetQuote(symbol, assetQuote)
+			},
+			OnUpdateAssetGroupQuote: func(_ c.AssetGroupQuote, _ int) {},
+			OnAlert:                 s.PublishAlert,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		monitors.Start()
+		defer monitors.Stop()
+
+		// All groups are tracked at once since clients can request any group at any time
+		if err := monitors.SetAssetGroup(MergeAssetGroups(ctx.Groups), 0); err != nil && ctx.Logger != nil {
+			ctx.Logger.Printf("failed to get initial quotes: %v", err)
+		}
+
+		listener, err := net.Listen("tcp", options.Address)
+		if err != nil {
+			return err
+		}
+
+		httpServer := &http.Server{
+			Handler:           s.Handler(),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		chanError := make(chan error, 1)
+
+		go func() {
+			chanError <- httpServer.Serve(listener)
+		}()
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Serving on http://%s\n", listener.Addr())
+
+		signalCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		select {
+		case err := <-chanError:
+			return err
+		case <-signalCtx.Done():
+		}
+
+		s.Close()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		if err := httpServer.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+
+		return nil
+	}
+}
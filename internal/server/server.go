@@ -0,0 +1,431 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/achannarasappa/ticker/v5/internal/asset"
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+)
+
+const (
+	defaultKeepAliveInterval = 15 * time.Second
+	// subscriberBufferSize is the number of events held for a subscriber which is slow to read before it is dropped
+	subscriberBufferSize = 64
+)
+
+// Monitor is the source of quotes and currency rates served by the API
+type Monitor interface {
+	GetAssetGroupQuote(ignoreCache ...bool) c.AssetGroupQuote
+	GetCurrencyRates() c.CurrencyRates
+}
+
+// Server serves quotes, assets, and position summaries for each group over HTTP and pushes updates to subscribers
+// as server-sent events
+type Server struct {
+	ctx               c.Context
+	monitor           Monitor
+	keepAliveInterval time.Duration
+	mu                sync.Mutex
+	sequence          int64
+	subscribers       map[chan event]struct{}
+	isClosed          bool
+}
+
+// Config represents the configuration for the server
+type Config struct {
+	Context           c.Context
+	Monitor           Monitor
+	KeepAliveInterval time.Duration // Interval between comments sent to keep idle event streams open, defaults to 15s
+}
+
+// event is a single server-sent event
+type event struct {
+	id   int64
+	name string
+	data []byte
+}
+
+// NewServer creates a new server
+func NewServer(config Config) *Server {
+
+	keepAliveInterval := config.KeepAliveInterval
+	if keepAliveInterval == 0 {
+		keepAliveInterval = defaultKeepAliveInterval
+	}
+
+	return &Server{
+		ctx:               config.Context,
+		monitor:           config.Monitor,
+		keepAliveInterval: keepAliveInterval,
+		subscribers:       make(map[chan event]struct{}),
+	}
+}
+
+// Handler returns the handler for all API routes
+func (s *Server) Handler() http.Handler {
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/groups", s.handleGroups)
+	mux.HandleFunc("GET /api/groups/{group}/quotes", s.handleQuotes)
+	mux.HandleFunc("GET /api/groups/{group}/assets", s.handleAssets)
+	mux.HandleFunc("GET /api/groups/{group}/summary", s.handleSummary)
+	mux.HandleFunc("GET /api/currency-rates", s.handleCurrencyRates)
+	mux.HandleFunc("GET /api/events", s.handleEvents)
+	mux.Handle("GET /metrics", s.metricsHandler())
+
+	return mux
+}
+
+// PublishAssetQuote sends an asset quote update to all event stream subscribers
+func (s *Server) PublishAssetQuote(symbol string, assetQuote c.AssetQuote) {
+	s.publish("quote", func(sequence int64) any {
+		return responseUpdate{
+			ID:       symbol,
+			Sequence: sequence,
+			Data:     newResponseQuote(assetQuote),
+		}
+	})
+}
+
+// PublishAlert sends a triggered alert to all event stream subscribers
+func (s *Server) PublishAlert(alert c.Alert) {
+	s.publish("alert", func(_ int64) any {
+		return newResponseAlert(alert)
+	})
+}
+
+// Close ends all open event streams so the HTTP server can shut down
+func (s *Server) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.isClosed = true
+
+	for subscriber := range s.subscribers {
+		close(subscriber)
+		delete(s.subscribers, subscriber)
+	}
+}
+
+func (s *Server) publish(name string, newData func(sequence int64) any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sequence++
+
+	data, err := json.Marshal(newData(s.sequence))
+	if err != nil {
+		return
+	}
+
+	e := event{id: s.sequence, name: name, data: data}
+
+	for subscriber := range s.subscribers {
+		select {
+		case subscriber <- e:
+		default:
+			// Drop subscribers which are not keeping up rather than blocking updates for everyone else
+			close(subscriber)
+			delete(s.subscribers, subscriber)
+		}
+	}
+}
+
+func (s *Server) subscribe() (chan event, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.isClosed {
+		return nil, false
+	}
+
+	subscriber := make(chan event, subscriberBufferSize)
+	s.subscribers[subscriber] = struct{}{}
+
+	return subscriber, true
+}
+
+func (s *Server) unsubscribe(subscriber chan event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.subscribers[subscriber]; exists {
+		close(subscriber)
+		delete(s.subscribers, subscriber)
+	}
+}
+
+func (s *Server) handleGroups(w http.ResponseWriter, _ *http.Request) {
+
+	groups := make([]responseGroup, 0, len(s.ctx.Groups))
+
+	for i, group := range s.ctx.Groups {
+		groups = append(groups, responseGroup{
+			ID:      i,
+			Name:    group.Name,
+			Symbols: getGroupSymbols(group),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, groups)
+}
+
+func (s *Server) handleQuotes(w http.ResponseWriter, r *http.Request) {
+
+	assetGroupQuote, ok := s.getAssetGroupQuote(w, r)
+	if !ok {
+		return
+	}
+
+	quotes := make([]responseQuote, 0, len(assetGroupQuote.AssetQuotes))
+
+	for _, assetQuote := range assetGroupQuote.AssetQuotes {
+		quotes = append(quotes, newResponseQuote(assetQuote))
+	}
+
+	writeJSON(w, http.StatusOK, quotes)
+}
+
+func (s *Server) handleAssets(w http.ResponseWriter, r *http.Request) {
+
+	assetGroupQuote, ok := s.getAssetGroupQuote(w, r)
+	if !ok {
+		return
+	}
+
+	assets, _ := asset.GetAssets(s.ctx, assetGroupQuote)
+	response := make([]responseAsset, 0, len(assets))
+
+	for _, a := range assets {
+		response = append(response, newResponseAsset(a))
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) handleSummary(w http.ResponseWriter, r *http.Request) {
+
+	assetGroupQuote, ok := s.getAssetGroupQuote(w, r)
+	if !ok {
+		return
+	}
+
+	_, positionSummary := asset.GetAssets(s.ctx, assetGroupQuote)
+
+	writeJSON(w, http.StatusOK, newResponseSummary(positionSummary))
+}
+
+func (s *Server) handleCurrencyRates(w http.ResponseWriter, _ *http.Request) {
+
+	currencyRates := s.monitor.GetCurrencyRates()
+	response := make(map[string]responseCurrencyRate, len(currencyRates))
+
+	for code, rate := range currencyRates {
+		response[code] = responseCurrencyRate{
+			From: rate.FromCurrency,
+			To:   rate.ToCurrency,
+			Rate: rate.Rate,
+		}
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+
+	rc := http.NewResponseController(w)
+
+	subscriber, ok := s.subscribe()
+	if !ok {
+		writeJSON(w, http.StatusServiceUnavailable, responseError{Error: "server is shutting down"})
+
+		return
+	}
+	defer s.unsubscribe(subscriber)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	keepAlive := time.NewTicker(s.keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		var err error
+
+		select {
+		case <-r.Context().Done():
+			return
+		case e, ok := <-subscriber:
+			if !ok {
+				return
+			}
+
+			_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.id, e.name, e.data)
+		case <-keepAlive.C:
+			_, err = fmt.Fprint(w, ": keep-alive\n\n")
+		}
+
+		if err != nil {
+			return
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// getAssetGroupQuote returns the quotes for the group in the request path or writes an error response if there is no
+// such group
+func (s *Server) getAssetGroupQuote(w http.ResponseWriter, r *http.Request) (c.AssetGroupQuote, bool) {
+
+	groupIndex, err := strconv.Atoi(r.PathValue("group"))
+
+	if err != nil || groupIndex < 0 || groupIndex >= len(s.ctx.Groups) {
+		writeJSON(w, http.StatusNotFound, responseError{Error: fmt.Sprintf("group '%s' not found", r.PathValue("group"))})
+
+		return c.AssetGroupQuote{}, false
+	}
+
+	group := s.ctx.Groups[groupIndex]
+
+	return c.AssetGroupQuote{
+		AssetGroup:  group,
+		AssetQuotes: filterAssetQuotes(s.monitor.GetAssetGroupQuote().AssetQuotes, group),
+	}, true
+}
+
+// MergeAssetGroups combines all groups into a single group so one monitor can track the symbols of every group
+func MergeAssetGroups(groups []c.AssetGroup) c.AssetGroup {
+
+	var merged c.AssetGroup
+
+	symbolsBySourceIndex := make(map[c.QuoteSource]int)
+	isSymbolAdded := make(map[c.QuoteSource]map[string]bool)
+
+	for _, group := range groups {
+		merged.ConfigAssetGroup.Lots = append(merged.ConfigAssetGroup.Lots, group.ConfigAssetGroup.Lots...)
+
+		for _, symbolsBySource := range group.SymbolsBySource {
+			if _, exists := symbolsBySourceIndex[symbolsBySource.Source]; !exists {
+				symbolsBySourceIndex[symbolsBySource.Source] = len(merged.SymbolsBySource)
+				isSymbolAdded[symbolsBySource.Source] = make(map[string]bool)
+				merged.SymbolsBySource = append(merged.SymbolsBySource, c.AssetGroupSymbolsBySource{
+					Source: symbolsBySource.Source,
+				})
+			}
+
+			index := symbolsBySourceIndex[symbolsBySource.Source]
+
+			for _, symbol := range symbolsBySource.Symbols {
+				if isSymbolAdded[symbolsBySource.Source][symbol] {
+					continue
+				}
+
+				isSymbolAdded[symbolsBySource.Source][symbol] = true
+				merged.SymbolsBySource[index].Symbols = append(merged.SymbolsBySource[index].Symbols, symbol)
+			}
+		}
+
+		for _, symbolAlternatives := range group.SymbolAlternatives {
+			isAdded := slices.ContainsFunc(merged.SymbolAlternatives, func(s c.AssetGroupSymbolAlternatives) bool {
+				return s.Source == symbolAlternatives.Source
+			})
+
+			if !isAdded {
+				merged.SymbolAlternatives = append(merged.SymbolAlternatives, symbolAlternatives)
+			}
+		}
+	}
+
+	return merged
+}
+
+// filterAssetQuotes returns the quotes for symbols in a group
+func filterAssetQuotes(assetQuotes []c.AssetQuote, group c.AssetGroup) []c.AssetQuote {
+
+	isInGroup := make(map[c.QuoteSource]map[string]bool)
+
+	for _, symbolsBySource := range group.SymbolsBySource {
+		if _, exists := isInGroup[symbolsBySource.Source]; !exists {
+			isInGroup[symbolsBySource.Source] = make(map[string]bool)
+		}
+
+		for _, symbol := range symbolsBySource.Symbols {
+			isInGroup[symbolsBySource.Source][strings.ToLower(symbol)] = true
+		}
+	}
+
+	// Quotes from alternatives serving symbols in place of failing sources are also in the group
+	isAlternativeInGroup := make(map[c.QuoteSource]map[string]bool)
+
+	for _, symbolAlternatives := range group.SymbolAlternatives {
+		for _, alternative := range symbolAlternatives.Alternatives {
+			if _, exists := isAlternativeInGroup[alternative.Source]; !exists {
+				isAlternativeInGroup[alternative.Source] = make(map[string]bool)
+			}
+
+			isAlternativeInGroup[alternative.Source][strings.ToLower(alternative.Symbol)] = true
+		}
+	}
+
+	filtered := make([]c.AssetQuote, 0)
+
+	for _, assetQuote := range assetQuotes {
+		symbolInSourceAPI := strings.ToLower(assetQuote.Meta.SymbolInSourceAPI)
+
+		if isInGroup[assetQuote.QuoteSource][symbolInSourceAPI] {
+			filtered = append(filtered, assetQuote)
+
+			continue
+		}
+
+		if assetQuote.Meta.AlternativeSourceName != "" && isAlternativeInGroup[assetQuote.QuoteSource][symbolInSourceAPI] {
+			filtered = append(filtered, assetQuote)
+		}
+	}
+
+	return filtered
+}
+
+// getGroupSymbols returns the symbols in a group's watchlist and lots in the order they are configured
+func getGroupSymbols(group c.AssetGroup) []string {
+
+	symbols := make([]string, 0)
+	isAdded := make(map[string]bool)
+
+	for _, symbol := range group.Watchlist {
+		if !isAdded[symbol] {
+			isAdded[symbol] = true
+			symbols = append(symbols, symbol)
+		}
+	}
+
+	for _, lot := range group.Lots {
+		if !isAdded[lot.Symbol] {
+			isAdded[lot.Symbol] = true
+			symbols = append(symbols, lot.Symbol)
+		}
+	}
+
+	return symbols
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body) //nolint:errcheck,errchkjson
+}
@@ -0,0 +1,398 @@
+package server_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/server"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fakeMonitor struct {
+	assetQuotes   []c.AssetQuote
+	currencyRates c.CurrencyRates
+}
+
+func (m *fakeMonitor) GetAssetGroupQuote(_ ...bool) c.AssetGroupQuote {
+	return c.AssetGroupQuote{AssetQuotes: m.assetQuotes}
+}
+
+func (m *fakeMonitor) GetCurrencyRates() c.CurrencyRates {
+	return m.currencyRates
+}
+
+func getJSON(url string, out any) int {
+	resp, err := http.Get(url) //nolint:noctx
+	Expect(err).NotTo(HaveOccurred())
+	defer resp.Body.Close()
+
+	Expect(resp.Header.Get("Content-Type")).To(Equal("application/json"))
+	Expect(json.NewDecoder(resp.Body).Decode(out)).To(Succeed())
+
+	return resp.StatusCode
+}
+
+// readEvent reads lines from an event stream until the blank line which ends an event
+func readEvent(reader *bufio.Reader) []string {
+	lines := make([]string, 0)
+
+	for {
+		line, err := reader.ReadString('\n')
+		Expect(err).NotTo(HaveOccurred())
+
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return lines
+		}
+
+		lines = append(lines, line)
+	}
+}
+
+var _ = Describe("Server", func() {
+
+	var (
+		s          *server.Server
+		ts         *httptest.Server
+		monitor    *fakeMonitor
+		assetQuote c.AssetQuote
+	)
+
+	BeforeEach(func() {
+		assetQuote = c.AssetQuote{
+			Name:        "Apple Inc.",
+			Symbol:      "AAPL",
+			Class:       c.AssetClassStock,
+			QuoteSource: c.QuoteSourceYahoo,
+			Currency: c.Currency{
+				FromCurrencyCode: "USD",
+			},
+			QuotePrice: c.QuotePrice{
+				Price:          110.0,
+				PricePrevClose: 100.0,
+				Change:         10.0,
+				ChangePercent:  10.0,
+			},
+			Exchange: c.Exchange{
+				Name:     "NasdaqGS",
+				State:    c.ExchangeStateOpen,
+				IsActive: true,
+			},
+			Meta: c.Meta{
+				SymbolInSourceAPI: "AAPL",
+			},
+		}
+
+		monitor = &fakeMonitor{
+			assetQuotes: []c.AssetQuote{
+				assetQuote,
+				{
+					Name:        "Bitcoin",
+					Symbol:      "BTC.X",
+					Class:       c.AssetClassCryptocurrency,
+					QuoteSource: c.QuoteSourceCoinbase,
+					QuotePrice:  c.QuotePrice{Price: 50000.0},
+					Meta:        c.Meta{SymbolInSourceAPI: "BTC-USD"},
+				},
+			},
+			currencyRates: c.CurrencyRates{
+				"EUR": {FromCurrency: "EUR", ToCurrency: "USD", Rate: 1.1},
+			},
+		}
+
+		s = server.NewServer(server.Config{
+			Context: c.Context{
+				Groups: []c.AssetGroup{
+					{
+						ConfigAssetGroup: c.ConfigAssetGroup{
+							Name:      "stocks",
+							Watchlist: []string{"AAPL"},
+							Lots: []c.Lot{
+								{Symbol: "AAPL", UnitCost: 100.0, Quantity: 10.0},
+							},
+						},
+						SymbolsBySource: []c.AssetGroupSymbolsBySource{
+							{Source: c.QuoteSourceYahoo, Symbols: []string{"AAPL"}},
+						},
+					},
+					{
+						ConfigAssetGroup: c.ConfigAssetGroup{
+							Name:      "crypto",
+							Watchlist: []string{"BTC.X"},
+						},
+						SymbolsBySource: []c.AssetGroupSymbolsBySource{
+							{Source: c.QuoteSourceCoinbase, Symbols: []string{"BTC-USD"}},
+						},
+						SymbolAlternatives: []c.AssetGroupSymbolAlternatives{
+							{
+								Symbol: "BTC.X",
+								Source: c.AssetGroupSymbolSource{Symbol: "BTC-USD", Source: c.QuoteSourceCoinbase},
+								Alternatives: []c.AssetGroupSymbolSource{
+									{Symbol: "bitcoin", Source: c.QuoteSourceCoingecko},
+								},
+							},
+						},
+					},
+				},
+			},
+			Monitor:           monitor,
+			KeepAliveInterval: 50 * time.Millisecond,
+		})
+		ts = httptest.NewServer(s.Handler())
+	})
+
+	AfterEach(func() {
+		s.Close()
+		ts.Close()
+	})
+
+	Describe("GET /api/groups", func() {
+		It("should return each group with its symbols", func() {
+			var groups []map[string]any
+
+			Expect(getJSON(ts.URL+"/api/groups", &groups)).To(Equal(http.StatusOK))
+			Expect(groups).To(Equal([]map[string]any{
+				{"id": 0.0, "name": "stocks", "symbols": []any{"AAPL"}},
+				{"id": 1.0, "name": "crypto", "symbols": []any{"BTC.X"}},
+			}))
+		})
+	})
+
+	Describe("GET /api/groups/{group}/quotes", func() {
+		It("should return only the quotes for symbols in the group", func() {
+			var quotes []map[string]any
+
+			Expect(getJSON(ts.URL+"/api/groups/1/quotes", &quotes)).To(Equal(http.StatusOK))
+			Expect(quotes).To(HaveLen(1))
+			Expect(quotes[0]).To(HaveKeyWithValue("symbol", "BTC.X"))
+			Expect(quotes[0]).To(HaveKeyWithValue("class", "cryptocurrency"))
+			Expect(quotes[0]).To(HaveKeyWithValue("source", "coinbase"))
+		})
+
+		When("a quote is from an alternative source in place of a failing source", func() {
+			It("should return the quote from the alternative source", func() {
+				var quotes []map[string]any
+
+				monitor.assetQuotes[1] = c.AssetQuote{
+					Name:        "Bitcoin",
+					Symbol:      "BTC.X",
+					Class:       c.AssetClassCryptocurrency,
+					QuoteSource: c.QuoteSourceCoingecko,
+					QuotePrice:  c.QuotePrice{Price: 50010.0},
+					Meta:        c.Meta{SymbolInSourceAPI: "bitcoin", AlternativeSourceName: "CoinGecko"},
+				}
+
+				Expect(getJSON(ts.URL+"/api/groups/1/quotes", &quotes)).To(Equal(http.StatusOK))
+				Expect(quotes).To(HaveLen(1))
+				Expect(quotes[0]).To(HaveKeyWithValue("symbol", "BTC.X"))
+				Expect(quotes[0]).To(HaveKeyWithValue("source", "coingecko"))
+			})
+		})
+
+		When("the group does not exist", func() {
+			It("should return a not found error", func() {
+				var body map[string]any
+
+				Expect(getJSON(ts.URL+"/api/groups/2/quotes", &body)).To(Equal(http.StatusNotFound))
+				Expect(body).To(Equal(map[string]any{"error": "group '2' not found"}))
+
+				Expect(getJSON(ts.URL+"/api/groups/abc/quotes", &body)).To(Equal(http.StatusNotFound))
+				Expect(body).To(Equal(map[string]any{"error": "group 'abc' not found"}))
+			})
+		})
+	})
+
+	Describe("GET /api/groups/{group}/assets", func() {
+		It("should return assets with their positions", func() {
+			var assets []map[string]any
+
+			Expect(getJSON(ts.URL+"/api/groups/0/assets", &assets)).To(Equal(http.StatusOK))
+			Expect(assets).To(HaveLen(1))
+			Expect(assets[0]).To(HaveKeyWithValue("symbol", "AAPL"))
+			Expect(assets[0]).To(HaveKeyWithValue("exchange", HaveKeyWithValue("state", "open")))
+			Expect(assets[0]).To(HaveKeyWithValue("position", SatisfyAll(
+				HaveKeyWithValue("value", 1100.0),
+				HaveKeyWithValue("cost", 1000.0),
+				HaveKeyWithValue("quantity", 10.0),
+				HaveKeyWithValue("total_change", map[string]any{"amount": 100.0, "percent": 10.0}),
+			)))
+		})
+
+		It("should omit the position for assets without lots", func() {
+			var assets []map[string]any
+
+			Expect(getJSON(ts.URL+"/api/groups/1/assets", &assets)).To(Equal(http.StatusOK))
+			Expect(assets).To(HaveLen(1))
+			Expect(assets[0]).NotTo(HaveKey("position"))
+		})
+	})
+
+	Describe("GET /api/groups/{group}/summary", func() {
+		It("should return the position summary of the group", func() {
+			var summary map[string]any
+
+			Expect(getJSON(ts.URL+"/api/groups/0/summary", &summary)).To(Equal(http.StatusOK))
+			Expect(summary).To(HaveKeyWithValue("value", 1100.0))
+			Expect(summary).To(HaveKeyWithValue("cost", 1000.0))
+			Expect(summary).To(HaveKeyWithValue("total_change", map[string]any{"amount": 100.0, "percent": 10.0}))
+		})
+	})
+
+	Describe("GET /api/currency-rates", func() {
+		It("should return the currency rates by currency code", func() {
+			var rates map[string]any
+
+			Expect(getJSON(ts.URL+"/api/currency-rates", &rates)).To(Equal(http.StatusOK))
+			Expect(rates).To(Equal(map[string]any{
+				"EUR": map[string]any{"from": "EUR", "to": "USD", "rate": 1.1},
+			}))
+		})
+	})
+
+	Describe("GET /api/events", func() {
+
+		var (
+			resp   *http.Response
+			reader *bufio.Reader
+		)
+
+		BeforeEach(func() {
+			var err error
+
+			resp, err = http.Get(ts.URL + "/api/events") //nolint:noctx
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Header.Get("Content-Type")).To(Equal("text/event-stream"))
+
+			reader = bufio.NewReader(resp.Body)
+		})
+
+		AfterEach(func() {
+			resp.Body.Close()
+		})
+
+		It("should push each asset quote update with an increasing sequence", func() {
+			s.PublishAssetQuote("AAPL", assetQuote)
+			s.PublishAssetQuote("AAPL", assetQuote)
+
+			first := readEvent(reader)
+			Expect(first).To(HaveLen(3))
+			Expect(first[0]).To(Equal("id: 1"))
+			Expect(first[1]).To(Equal("event: quote"))
+
+			var update map[string]any
+			Expect(json.Unmarshal([]byte(strings.TrimPrefix(first[2], "data: ")), &update)).To(Succeed())
+			Expect(update).To(HaveKeyWithValue("id", "AAPL"))
+			Expect(update).To(HaveKeyWithValue("sequence", 1.0))
+			Expect(update).To(HaveKeyWithValue("data", HaveKeyWithValue("quote_price", HaveKeyWithValue("price", 110.0))))
+
+			second := readEvent(reader)
+			Expect(second[0]).To(Equal("id: 2"))
+		})
+
+		It("should push triggered alerts", func() {
+			s.PublishAlert(c.Alert{
+				Symbol:    "AAPL",
+				Condition: "above",
+				Message:   "AAPL rose above 105.00 to 110.00",
+				Price:     110.0,
+				Time:      time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC),
+			})
+
+			Expect(readEvent(reader)).To(Equal([]string{
+				"id: 1",
+				"event: alert",
+				`data: {"symbol":"AAPL","condition":"above","message":"AAPL rose above 105.00 to 110.00","price":110,"time":"2025-01-02T15:04:05Z"}`,
+			}))
+		})
+
+		It("should send keep-alive comments while there are no updates", func() {
+			Expect(readEvent(reader)).To(Equal([]string{": keep-alive"}))
+		})
+
+		When("the server is closed", func() {
+			It("should end the stream", func() {
+				s.Close()
+
+				_, err := io.ReadAll(resp.Body)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("GET /metrics", func() {
+		It("should return gauges for quotes, positions, and groups in the text exposition format", func() {
+			resp, err := http.Get(ts.URL + "/metrics") //nolint:noctx
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(resp.Header.Get("Content-Type")).To(HavePrefix("text/plain; version=0.0.4"))
+			Expect(string(body)).To(SatisfyAll(
+				ContainSubstring("# TYPE ticker_quote_price gauge\n"),
+				ContainSubstring(`ticker_quote_price{currency="USD",source="yahoo",symbol="AAPL"} 110`+"\n"),
+				ContainSubstring(`ticker_quote_change_percent{currency="USD",source="yahoo",symbol="AAPL"} 10`+"\n"),
+				ContainSubstring(`ticker_quote_price{currency="",source="coinbase",symbol="BTC.X"} 50000`+"\n"),
+				ContainSubstring(`ticker_quote_market_state{currency="USD",source="yahoo",state="open",symbol="AAPL"} 1`+"\n"),
+				ContainSubstring(`ticker_quote_market_state{currency="USD",source="yahoo",state="closed",symbol="AAPL"} 0`+"\n"),
+				ContainSubstring(`ticker_position_value{currency="USD",group="stocks",symbol="AAPL"} 1100`+"\n"),
+				ContainSubstring(`ticker_position_cost{currency="USD",group="stocks",symbol="AAPL"} 1000`+"\n"),
+				ContainSubstring(`ticker_position_weight{currency="USD",group="stocks",symbol="AAPL"} 100`+"\n"),
+				ContainSubstring(`ticker_group_value{group="stocks"} 1100`+"\n"),
+				ContainSubstring(`ticker_group_value{group="crypto"} 0`+"\n"),
+				ContainSubstring("# TYPE ticker_monitor_errors_total counter\n"),
+			))
+			Expect(string(body)).NotTo(ContainSubstring(`group="crypto",symbol=`))
+		})
+	})
+
+	Describe("MergeAssetGroups", func() {
+		It("should combine the unique symbols of each source across all groups", func() {
+			merged := server.MergeAssetGroups([]c.AssetGroup{
+				{
+					SymbolsBySource: []c.AssetGroupSymbolsBySource{
+						{Source: c.QuoteSourceYahoo, Symbols: []string{"AAPL", "MSFT"}},
+					},
+				},
+				{
+					SymbolsBySource: []c.AssetGroupSymbolsBySource{
+						{Source: c.QuoteSourceCoinbase, Symbols: []string{"BTC-USD"}},
+						{Source: c.QuoteSourceYahoo, Symbols: []string{"MSFT", "GOOG"}},
+					},
+				},
+			})
+
+			Expect(merged.SymbolsBySource).To(Equal([]c.AssetGroupSymbolsBySource{
+				{Source: c.QuoteSourceYahoo, Symbols: []string{"AAPL", "MSFT", "GOOG"}},
+				{Source: c.QuoteSourceCoinbase, Symbols: []string{"BTC-USD"}},
+			}))
+		})
+
+		It("should combine the unique alternative sources of symbols across all groups", func() {
+			symbolAlternatives := c.AssetGroupSymbolAlternatives{
+				Symbol: "BTC.X",
+				Source: c.AssetGroupSymbolSource{Symbol: "BTC-USD", Source: c.QuoteSourceCoinbase},
+				Alternatives: []c.AssetGroupSymbolSource{
+					{Symbol: "bitcoin", Source: c.QuoteSourceCoingecko},
+				},
+			}
+
+			merged := server.MergeAssetGroups([]c.AssetGroup{
+				{SymbolAlternatives: []c.AssetGroupSymbolAlternatives{symbolAlternatives}},
+				{SymbolAlternatives: []c.AssetGroupSymbolAlternatives{symbolAlternatives}},
+			})
+
+			Expect(merged.SymbolAlternatives).To(Equal([]c.AssetGroupSymbolAlternatives{symbolAlternatives}))
+		})
+	})
+
+})
@@ -0,0 +1,153 @@
+package statusline
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Output formats which can be set with the format option
+const (
+	FormatPlain   = "plain"
+	FormatTmux    = "tmux"
+	FormatI3bar   = "i3bar"
+	FormatWaybar  = "waybar"
+	FormatPolybar = "polybar"
+)
+
+const (
+	colorUp   = "#C6FF40"
+	colorDown = "#FF7940"
+)
+
+// direction is whether a value went up, down, or did not change and sets the color it is rendered with
+type direction int
+
+const (
+	directionFlat direction = iota
+	directionUp
+	directionDown
+)
+
+// format renders text for a specific status bar
+type format struct {
+	// header is written once before the first line when running continuously
+	header string
+	// escape makes text from quotes safe to include in a line
+	escape func(text string) string
+	// color wraps text in the markup for the color of a direction
+	color func(text string, d direction) string
+	// line wraps a rendered line in the protocol of the status bar
+	line func(text string, tooltip string, d direction) string
+}
+
+//nolint:gochecknoglobals
+var formats = map[string]format{
+	FormatPlain: {
+		escape: noEscape,
+		color:  func(text string, _ direction) string { return text },
+		line:   func(text string, _ string, _ direction) string { return text },
+	},
+	FormatTmux: {
+		// A literal # is written as ## so it is not read as the start of a style
+		escape: func(text string) string { return strings.ReplaceAll(text, "#", "##") },
+		color: func(text string, d direction) string {
+			if d == directionFlat {
+				return text
+			}
+
+			return "#[fg=" + colorByDirection(d) + "]" + text + "#[fg=default]"
+		},
+		line: func(text string, _ string, _ direction) string { return text },
+	},
+	FormatPolybar: {
+		// A literal % is written as %% so it is not read as the start of a format tag
+		escape: func(text string) string { return strings.ReplaceAll(text, "%", "%%") },
+		color: func(text string, d direction) string {
+			if d == directionFlat {
+				return text
+			}
+
+			return "%{F" + colorByDirection(d) + "}" + text + "%{F-}"
+		},
+		line: func(text string, _ string, _ direction) string { return text },
+	},
+	FormatI3bar: {
+		// Header and the opening of the endless array of status lines in the i3bar protocol
+		header: "{\"version\":1}\n[",
+		escape: escapePango,
+		color:  colorPango,
+		line: func(text string, _ string, _ direction) string {
+			block, _ := json.Marshal([]map[string]string{{ //nolint:errchkjson
+				"name":      "ticker",
+				"full_text": text,
+				"markup":    "pango",
+			}})
+
+			return string(block) + ","
+		},
+	},
+	FormatWaybar: {
+		escape: escapePango,
+		color:  colorPango,
+		line: func(text string, tooltip string, d direction) string {
+			module, _ := json.Marshal(map[string]string{ //nolint:errchkjson
+				"text":    text,
+				"tooltip": tooltip,
+				"class":   classByDirection(d),
+			})
+
+			return string(module)
+		},
+	},
+}
+
+// GetFormats returns the names of all formats
+func GetFormats() []string {
+	return []string{FormatPlain, FormatTmux, FormatI3bar, FormatWaybar, FormatPolybar}
+}
+
+func noEscape(text string) string {
+	return text
+}
+
+func escapePango(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
+
+func colorPango(text string, d direction) string {
+	if d == directionFlat {
+		return text
+	}
+
+	return "<span foreground=\"" + colorByDirection(d) + "\">" + text + "</span>"
+}
+
+func colorByDirection(d direction) string {
+	if d == directionUp {
+		return colorUp
+	}
+
+	return colorDown
+}
+
+func classByDirection(d direction) string {
+	switch d {
+	case directionUp:
+		return "up"
+	case directionDown:
+		return "down"
+	default:
+		return "flat"
+	}
+}
+
+func getDirection(value float64) direction {
+	switch {
+	case value > 0:
+		return directionUp
+	case value < 0:
+		return directionDown
+	default:
+		return directionFlat
+	}
+}
@@ -0,0 +1,328 @@
+package statusline
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/achannarasappa/ticker/v5/internal/asset"
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	mon "github.com/achannarasappa/ticker/v5/internal/monitor"
+	"github.com/achannarasappa/ticker/v5/internal/ui/util"
+
+	"github.com/spf13/cobra"
+)
+
+// Defaults for options which are not set
+const (
+	DefaultTemplate      = "{quotes}"
+	DefaultQuoteTemplate = "{symbol} {price} {change_percent}"
+	DefaultSeparator     = "  "
+)
+
+const cacheKeyPrefix = "statusline-quotes:"
+
+// Options to configure statusline behavior
+type Options struct {
+	Format        string
+	Template      string
+	QuoteTemplate string
+	Separator     string
+	Group         string
+	Watch         bool
+}
+
+// printer writes a line each time the rendered status line changes
+type printer struct {
+	mu       sync.Mutex
+	out      io.Writer
+	format   format
+	options  Options
+	ctx      c.Context
+	lastLine string
+}
+
+// Run prints the status line once or continuously on each quote update when watching
+func Run(dep *c.Dependencies, ctx *c.Context, options *Options) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+
+		f, exists := formats[options.Format]
+		if !exists {
+			return newUnsupportedFormatError(options.Format)
+		}
+
+		group, err := getGroup(ctx.Groups, options.Group)
+		if err != nil {
+			return err
+		}
+
+		p := &printer{
+			out:     cmd.OutOrStdout(),
+			format:  f,
+			options: *options,
+			ctx:     *ctx,
+		}
+
+		if !options.Watch {
+			assetGroupQuote, err := getAssetGroupQuote(dep, ctx, group)
+			if err != nil {
+				return err
+			}
+
+			p.print(assetGroupQuote)
+
+			return nil
+		}
+
+		return watch(cmd, dep, ctx, group, p)
+	}
+}
+
+// watch prints the status line each time it changes until interrupted
+func watch(cmd *cobra.Command, dep *c.Dependencies, ctx *c.Context, group c.AssetGroup, p *printer) error {
+
+	runCtx := cmd.Context()
+	if runCtx == nil {
+		runCtx = context.Background()
+	}
+
+	runCtx, stop := signal.NotifyContext(runCtx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	monitors, err := newMonitor(dep, ctx)
+	if err != nil {
+		return err
+	}
+
+	err = monitors.SetOnUpdate(mon.ConfigUpdateFns{
+		OnUpdateAssetQuote: func(_ string, _ c.AssetQuote, _ int) {
+			p.print(monitors.GetAssetGroupQuote())
+		},
+		OnUpdateAssetGroupQuote: func(assetGroupQuote c.AssetGroupQuote, _ int) {
+			p.print(assetGroupQuote)
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if p.format.header != "" {
+		fmt.Fprintln(p.out, p.format.header)
+	}
+
+	monitors.Start()
+	defer monitors.Stop()
+
+	if err := monitors.SetAssetGroup(group, 0); err != nil {
+		return err
+	}
+
+	<-runCtx.Done()
+
+	return nil
+}
+
+// getAssetGroupQuote gets quotes for a group from the cache when a recent invocation has already fetched them so that
+// status bars which run the command on an interval do not make requests more often than the refresh interval
+func getAssetGroupQuote(dep *c.Dependencies, ctx *c.Context, group c.AssetGroup) (c.AssetGroupQuote, error) {
+
+	key := getCacheKey(group, ctx.Config.Currency)
+	assetQuotes := make([]c.AssetQuote, 0)
+
+	if ctx.Cache != nil && ctx.Cache.Get(key, &assetQuotes) {
+		return c.AssetGroupQuote{AssetGroup: group, AssetQuotes: assetQuotes}, nil
+	}
+
+	monitors, err := newMonitor(dep, ctx)
+	if err != nil {
+		return c.AssetGroupQuote{}, err
+	}
+
+	if err := monitors.SetAssetGroup(group, 0); err != nil {
+		return c.AssetGroupQuote{}, err
+	}
+
+	assetGroupQuote := monitors.GetAssetGroupQuote()
+
+	if ctx.Cache != nil {
+		ctx.Cache.Set(key, assetGroupQuote.AssetQuotes, time.Duration(ctx.Config.RefreshInterval)*time.Second)
+	}
+
+	return assetGroupQuote, nil
+}
+
+func newMonitor(dep *c.Dependencies, ctx *c.Context) (*mon.Monitor, error) {
+	return mon.NewMonitor(mon.ConfigMonitor{
+		RefreshInterval: ctx.Config.RefreshInterval,
+		TargetCurrency:  ctx.Config.Currency,
+		Logger:          ctx.Logger,
+		Cache:           ctx.Cache,
+		ConfigMonitorsYahoo: mon.ConfigMonitorsYahoo{
+			BaseURL:           dep.MonitorYahooBaseURL,
+			SessionRootURL:    dep.MonitorYahooSessionRootURL,
+			SessionCrumbURL:   dep.MonitorYahooSessionCrumbURL,
+			SessionConsentURL: dep.MonitorYahooSessionConsentURL,
+			StreamingURL:      dep.MonitorYahooStreamingURL,
+		},
+		ConfigMonitorPriceCoinbase: mon.ConfigMonitorPriceCoinbase{
+			BaseURL:      dep.MonitorPriceCoinbaseBaseURL,
+			StreamingURL: dep.MonitorPriceCoinbaseStreamingURL,
+		},
+		ConfigMonitorPriceCoingecko: mon.ConfigMonitorPriceCoingecko{
+			BaseURL: dep.MonitorPriceCoingeckoBaseURL,
+		},
+		ConfigMonitorPriceCoinCap: mon.ConfigMonitorPriceCoinCap{
+			BaseURL:      dep.MonitorPriceCoinCapBaseURL,
+			StreamingURL: dep.MonitorPriceCoinCapStreamingURL,
+			APIKey:       ctx.Config.CoinCapAPIKey,
+		},
+		ConfigMonitorPriceUserDefined: mon.ConfigMonitorPriceUserDefined{
+			Prices: ctx.Config.UserDefinedPrices,
+		},
+	})
+}
+
+// print renders the status line and writes it if it is different from the last line written
+func (p *printer) print(assetGroupQuote c.AssetGroupQuote) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	line := render(p.format, p.options, p.ctx, assetGroupQuote)
+
+	if line == p.lastLine {
+		return
+	}
+
+	p.lastLine = line
+	fmt.Fprintln(p.out, line)
+}
+
+// render fills in the template with quotes and the position summary of a group in the markup of a format
+func render(f format, options Options, ctx c.Context, assetGroupQuote c.AssetGroupQuote) string {
+
+	assets, summary := asset.GetAssets(ctx, assetGroupQuote)
+
+	slices.SortStableFunc(assets, func(a, b c.Asset) int {
+		return a.Meta.OrderIndex - b.Meta.OrderIndex
+	})
+
+	quotes := make([]string, 0, len(assets))
+	tooltip := make([]string, 0, len(assets))
+
+	for _, a := range assets {
+		d := getDirection(a.QuotePrice.Change)
+		price := util.ConvertFloatToString(a.QuotePrice.Price, a.Meta.IsVariablePrecision)
+		changePercent := formatChange(a.QuotePrice.ChangePercent, false) + "%"
+
+		quotes = append(quotes, strings.NewReplacer(
+			"{symbol}", f.escape(a.Symbol),
+			"{name}", f.escape(a.Name),
+			"{price}", f.escape(price),
+			"{change}", f.color(f.escape(formatChange(a.QuotePrice.Change, a.Meta.IsVariablePrecision)), d),
+			"{change_percent}", f.color(f.escape(changePercent), d),
+			"{arrow}", f.color(getArrow(d), d),
+		).Replace(getOrDefault(options.QuoteTemplate, DefaultQuoteTemplate)))
+
+		tooltip = append(tooltip, a.Symbol+" "+price+" "+changePercent)
+	}
+
+	dayDirection := getDirection(summary.DayChange.Amount)
+
+	line := strings.NewReplacer(
+		"{quotes}", strings.Join(quotes, getOrDefault(options.Separator, DefaultSeparator)),
+		"{day_change}", f.color(f.escape(formatChange(summary.DayChange.Amount, false)), dayDirection),
+		"{day_change_percent}", f.color(f.escape(formatChange(summary.DayChange.Percent, false)+"%"), dayDirection),
+		"{value}", f.escape(util.ConvertFloatToString(summary.Value, false)),
+		"{group}", f.escape(assetGroupQuote.AssetGroup.Name),
+	).Replace(getOrDefault(options.Template, DefaultTemplate))
+
+	return f.line(line, f.escape(strings.Join(tooltip, "\n")), dayDirection)
+}
+
+// Render renders the status line in a format by name and returns an error if there is no such format
+func Render(name string, options Options, ctx c.Context, assetGroupQuote c.AssetGroupQuote) (string, error) {
+
+	f, exists := formats[name]
+	if !exists {
+		return "", newUnsupportedFormatError(name)
+	}
+
+	return render(f, options, ctx, assetGroupQuote), nil
+}
+
+func newUnsupportedFormatError(name string) error {
+	return fmt.Errorf("format '%s' is not supported, use one of: %s", name, strings.Join(GetFormats(), ", ")) //nolint:goerr113
+}
+
+// getGroup returns the group with a name or the first group if no name is set
+func getGroup(groups []c.AssetGroup, name string) (c.AssetGroup, error) {
+
+	if len(groups) == 0 {
+		return c.AssetGroup{}, fmt.Errorf("no groups are configured") //nolint:goerr113
+	}
+
+	if name == "" {
+		return groups[0], nil
+	}
+
+	for _, group := range groups {
+		if strings.EqualFold(group.Name, name) {
+			return group, nil
+		}
+	}
+
+	return c.AssetGroup{}, fmt.Errorf("group '%s' not found", name) //nolint:goerr113
+}
+
+// getCacheKey returns a key which is unique to the symbols in a group and the currency quotes are converted to so groups
+// with different symbols or currencies do not share cached quotes
+func getCacheKey(group c.AssetGroup, currency string) string {
+
+	symbols := make([]string, 0)
+
+	for _, symbolsBySource := range group.SymbolsBySource {
+		for _, symbol := range symbolsBySource.Symbols {
+			symbols = append(symbols, fmt.Sprintf("%d:%s", symbolsBySource.Source, symbol))
+		}
+	}
+
+	slices.Sort(symbols)
+
+	return cacheKeyPrefix + currency + ":" + strings.Join(symbols, ",")
+}
+
+func formatChange(value float64, isVariablePrecision bool) string {
+
+	if value > 0 {
+		return "+" + util.ConvertFloatToString(value, isVariablePrecision)
+	}
+
+	return util.ConvertFloatToString(value, isVariablePrecision)
+}
+
+func getArrow(d direction) string {
+	switch d {
+	case directionUp:
+		return "▲"
+	case directionDown:
+		return "▼"
+	default:
+		return "•"
+	}
+}
+
+func getOrDefault(value string, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+
+	return value
+}
@@ -0,0 +1,215 @@
+package statusline_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/statusline"
+	"github.com/spf13/cobra"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fakeCache is an in-memory cache which records keys that are set
+type fakeCache struct {
+	entries map[string][]byte
+}
+
+func (f *fakeCache) Get(key string, out any) bool {
+	payload, exists := f.entries[key]
+	if !exists {
+		return false
+	}
+
+	return json.Unmarshal(payload, out) == nil
+}
+
+func (f *fakeCache) Set(key string, value any, _ time.Duration) {
+	payload, _ := json.Marshal(value)
+	f.entries[key] = payload
+}
+
+var _ = Describe("Statusline", func() {
+
+	var (
+		ctx             c.Context
+		assetGroupQuote c.AssetGroupQuote
+		options         statusline.Options
+	)
+
+	BeforeEach(func() {
+		group := c.AssetGroup{
+			ConfigAssetGroup: c.ConfigAssetGroup{
+				Name:      "stocks",
+				Watchlist: []string{"MSFT"},
+				Lots: []c.Lot{
+					{Symbol: "AAPL", UnitCost: 100.0, Quantity: 10.0},
+				},
+			},
+			SymbolsBySource: []c.AssetGroupSymbolsBySource{
+				{Source: c.QuoteSourceYahoo, Symbols: []string{"MSFT", "AAPL"}},
+			},
+		}
+
+		ctx = c.Context{Groups: []c.AssetGroup{group}}
+		assetGroupQuote = c.AssetGroupQuote{
+			AssetGroup: group,
+			AssetQuotes: []c.AssetQuote{
+				{
+					Name:        "Microsoft Corporation",
+					Symbol:      "MSFT",
+					QuoteSource: c.QuoteSourceYahoo,
+					QuotePrice:  c.QuotePrice{Price: 400.0, Change: -4.0, ChangePercent: -1.0},
+				},
+				{
+					Name:        "Apple Inc.",
+					Symbol:      "AAPL",
+					QuoteSource: c.QuoteSourceYahoo,
+					QuotePrice:  c.QuotePrice{Price: 110.0, Change: 2.2, ChangePercent: 2.04},
+				},
+			},
+		}
+		options = statusline.Options{
+			Template: "{quotes} | P/L {day_change}",
+		}
+	})
+
+	Describe("Render", func() {
+
+		It("should fill in the template in the order symbols are configured with lots first", func() {
+			output, err := statusline.Render(statusline.FormatPlain, options, ctx, assetGroupQuote)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(Equal("AAPL 110.00 +2.04%  MSFT 400.00 -1.00% | P/L +22.00"))
+		})
+
+		It("should fill in the quote template and separator", func() {
+			options.QuoteTemplate = "{arrow} {symbol} {change}"
+			options.Separator = " / "
+			options.Template = "{group}: {quotes} {day_change_percent} {value}"
+
+			output, err := statusline.Render(statusline.FormatPlain, options, ctx, assetGroupQuote)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(Equal("stocks: ▲ AAPL +2.20 / ▼ MSFT -4.00 +2.00% 1100.00"))
+		})
+
+		When("the format is tmux", func() {
+			It("should color changes with tmux styles", func() {
+				output, err := statusline.Render(statusline.FormatTmux, options, ctx, assetGroupQuote)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output).To(Equal("AAPL 110.00 #[fg=#C6FF40]+2.04%#[fg=default]  MSFT 400.00 #[fg=#FF7940]-1.00%#[fg=default] | P/L #[fg=#C6FF40]+22.00#[fg=default]"))
+			})
+		})
+
+		When("the format is polybar", func() {
+			It("should color changes with polybar tags and escape percent signs", func() {
+				output, err := statusline.Render(statusline.FormatPolybar, options, ctx, assetGroupQuote)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output).To(Equal("AAPL 110.00 %{F#C6FF40}+2.04%%%{F-}  MSFT 400.00 %{F#FF7940}-1.00%%%{F-} | P/L %{F#C6FF40}+22.00%{F-}"))
+			})
+		})
+
+		When("the format is i3bar", func() {
+			It("should write a block with pango markup as an element of the status line array", func() {
+				output, err := statusline.Render(statusline.FormatI3bar, options, ctx, assetGroupQuote)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output).To(HaveSuffix(","))
+
+				var blocks []map[string]string
+				Expect(json.Unmarshal([]byte(output[:len(output)-1]), &blocks)).To(Succeed())
+				Expect(blocks).To(Equal([]map[string]string{
+					{
+						"name":      "ticker",
+						"markup":    "pango",
+						"full_text": `AAPL 110.00 <span foreground="#C6FF40">+2.04%</span>  MSFT 400.00 <span foreground="#FF7940">-1.00%</span> | P/L <span foreground="#C6FF40">+22.00</span>`,
+					},
+				}))
+			})
+		})
+
+		When("the format is waybar", func() {
+			It("should write a module with a tooltip and a class for the direction of the day change", func() {
+				assetGroupQuote.AssetQuotes[0].Name = "A & B"
+				options.QuoteTemplate = "{name}"
+
+				output, err := statusline.Render(statusline.FormatWaybar, options, ctx, assetGroupQuote)
+
+				Expect(err).NotTo(HaveOccurred())
+
+				var module map[string]string
+				Expect(json.Unmarshal([]byte(output), &module)).To(Succeed())
+				Expect(module).To(Equal(map[string]string{
+					"text":    `Apple Inc.  A &amp; B | P/L <span foreground="#C6FF40">+22.00</span>`,
+					"tooltip": "AAPL 110.00 +2.04%\nMSFT 400.00 -1.00%",
+					"class":   "up",
+				}))
+			})
+		})
+
+		When("the format is not supported", func() {
+			It("should return an error", func() {
+				_, err := statusline.Render("unknown", options, ctx, assetGroupQuote)
+
+				Expect(err).To(MatchError("format 'unknown' is not supported, use one of: plain, tmux, i3bar, waybar, polybar"))
+			})
+		})
+	})
+
+	Describe("Run", func() {
+
+		var (
+			cache *fakeCache
+			out   *bytes.Buffer
+			cmd   *cobra.Command
+		)
+
+		BeforeEach(func() {
+			cache = &fakeCache{entries: make(map[string][]byte)}
+			ctx.Cache = cache
+			out = &bytes.Buffer{}
+			cmd = &cobra.Command{}
+			cmd.SetOut(out)
+			options.Format = statusline.FormatPlain
+		})
+
+		When("quotes for the group were cached by a previous run", func() {
+			It("should print the status line without fetching quotes", func() {
+				ctx.Config.Currency = "EUR"
+				cache.Set("statusline-quotes:EUR:0:AAPL,0:MSFT", assetGroupQuote.AssetQuotes, time.Minute)
+
+				err := statusline.Run(&c.Dependencies{}, &ctx, &options)(cmd, []string{})
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(out.String()).To(Equal("AAPL 110.00 +2.04%  MSFT 400.00 -1.00% | P/L +22.00\n"))
+			})
+		})
+
+		When("the group is not found", func() {
+			It("should return an error", func() {
+				options.Group = "crypto"
+
+				err := statusline.Run(&c.Dependencies{}, &ctx, &options)(cmd, []string{})
+
+				Expect(err).To(MatchError("group 'crypto' not found"))
+			})
+		})
+
+		When("the format is not supported", func() {
+			It("should return an error", func() {
+				options.Format = "unknown"
+
+				err := statusline.Run(&c.Dependencies{}, &ctx, &options)(cmd, []string{})
+
+				Expect(err).To(MatchError(ContainSubstring("format 'unknown' is not supported")))
+			})
+		})
+	})
+
+})
@@ -0,0 +1,77 @@
+package alerts
+
+import (
+	"fmt"
+	"strings"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const widthTime = 20
+
+// Model for the alert history screen
+type Model struct {
+	width  int
+	alerts []c.Alert
+	styles c.Styles
+}
+
+// SetAlertsMsg sets the triggered alerts to show ordered from most to least recent
+type SetAlertsMsg []c.Alert
+
+// NewModel returns a model with default values
+func NewModel(ctx c.Context) *Model {
+	return &Model{
+		width:  80,
+		alerts: make([]c.Alert, 0),
+		styles: ctx.Reference.Styles,
+	}
+}
+
+// Init initializes the alert history component
+func (m *Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the alert history component
+func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+
+		return m, nil
+	case SetAlertsMsg:
+		m.alerts = msg
+
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// View rendering hook for bubbletea
+func (m *Model) View() string {
+
+	if m.width < 80 {
+		return fmt.Sprintf("Terminal window too narrow to render content\nResize to fix (%d/80)", m.width)
+	}
+
+	lines := []string{m.styles.TextBold("Alerts"), ""}
+
+	if len(m.alerts) == 0 {
+		lines = append(lines, m.styles.TextLabel("  No alerts have been triggered"))
+	}
+
+	for _, alert := range m.alerts {
+		lines = append(lines, "  "+
+			m.styles.TextLabel(fmt.Sprintf("%-*s", widthTime, alert.Time.Format("Mon Jan 2 15:04:05")))+
+			m.styles.Text(alert.Message),
+		)
+	}
+
+	lines = append(lines, "", m.styles.TextLabel("esc: back"))
+
+	return strings.Join(lines, "\n")
+}
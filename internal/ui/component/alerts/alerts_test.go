@@ -0,0 +1,55 @@
+package alerts_test
+
+import (
+	"time"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	. "github.com/achannarasappa/ticker/v5/internal/ui/component/alerts"
+
+	tea "github.com/charmbracelet/bubbletea"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Alerts", func() {
+
+	ctxFixture := c.Context{Reference: c.Reference{Styles: c.Styles{
+		Text:      func(v string) string { return v },
+		TextLight: func(v string) string { return v },
+		TextLabel: func(v string) string { return v },
+		TextBold:  func(v string) string { return v },
+		TextLine:  func(v string) string { return v },
+		TextPrice: func(percent float64, text string) string { return text },
+		Tag:       func(v string) string { return v },
+	}}}
+
+	It("should show a message when no alerts have been triggered", func() {
+		m := NewModel(ctxFixture)
+
+		Expect(m.View()).To(Equal("Alerts\n\n  No alerts have been triggered\n\nesc: back"))
+	})
+
+	It("should show the time and message of each alert", func() {
+		m := NewModel(ctxFixture)
+		m, _ = m.Update(SetAlertsMsg{
+			{Symbol: "TWKS", Message: "TWKS rose above 100.00 to 101.00", Time: time.Date(2025, 3, 4, 14, 5, 6, 0, time.Local)},
+			{Symbol: "BTC-USD", Message: "BTC-USD is down 5.00% today at 60000.00", Time: time.Date(2025, 3, 4, 9, 30, 0, 0, time.Local)},
+		})
+
+		Expect(m.View()).To(Equal(
+			"Alerts\n\n" +
+				"  Tue Mar 4 14:05:06  TWKS rose above 100.00 to 101.00\n" +
+				"  Tue Mar 4 09:30:00  BTC-USD is down 5.00% today at 60000.00\n" +
+				"\nesc: back",
+		))
+	})
+
+	When("the terminal is too narrow", func() {
+		It("should show a message to resize", func() {
+			m := NewModel(ctxFixture)
+			m, _ = m.Update(tea.WindowSizeMsg{Width: 70})
+
+			Expect(m.View()).To(Equal("Terminal window too narrow to render content\nResize to fix (70/80)"))
+		})
+	})
+})
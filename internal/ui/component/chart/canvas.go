@@ -0,0 +1,80 @@
+package chart
+
+// canvas is a grid of braille characters where each character is a block of dots two wide and four high. Dot
+// positions are measured from the bottom left of the canvas.
+type canvas struct {
+	width  int
+	height int
+	cells  [][]rune
+}
+
+func newCanvas(width int, height int) *canvas {
+
+	cells := make([][]rune, height)
+	for row := range cells {
+		cells[row] = make([]rune, width)
+	}
+
+	return &canvas{
+		width:  width,
+		height: height,
+		cells:  cells,
+	}
+}
+
+func (c *canvas) widthDots() int {
+	return c.width * dotsPerColumn
+}
+
+func (c *canvas) heightDots() int {
+	return c.height * dotsPerRow
+}
+
+// set turns on the dot at a position and ignores positions outside of the canvas
+func (c *canvas) set(x int, y int) {
+
+	if x < 0 || y < 0 || x >= c.widthDots() || y >= c.heightDots() {
+		return
+	}
+
+	yFromTop := c.heightDots() - 1 - y
+
+	c.cells[yFromTop/dotsPerRow][x/dotsPerColumn] |= brailleBits[x%dotsPerColumn][yFromTop%dotsPerRow]
+}
+
+// line draws a line between two positions by filling the vertical distance covered at each horizontal position
+func (c *canvas) line(x0 int, y0 int, x1 int, y1 int) {
+
+	if x0 == x1 {
+		c.verticalLine(x0, y0, y1)
+
+		return
+	}
+
+	for x := x0; x <= x1; x++ {
+		yStart := y0 + (y1-y0)*(x-x0)/(x1-x0)
+		yEnd := y0 + (y1-y0)*min(x+1-x0, x1-x0)/(x1-x0)
+
+		// The line covers half of the vertical distance to the next position so that steep lines are continuous
+		c.verticalLine(x, yStart, yStart+(yEnd-yStart)/2)
+
+		if x < x1 {
+			c.verticalLine(x+1, yStart+(yEnd-yStart)/2, yEnd)
+		}
+	}
+}
+
+func (c *canvas) verticalLine(x int, y0 int, y1 int) {
+
+	for y := min(y0, y1); y <= max(y0, y1); y++ {
+		c.set(x, y)
+	}
+}
+
+// horizontalLine draws a dotted line across the canvas
+func (c *canvas) horizontalLine(y int) {
+
+	for x := 0; x < c.widthDots(); x += referenceSpace {
+		c.set(x, y)
+	}
+}
@@ -0,0 +1,426 @@
+package chart
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	u "github.com/achannarasappa/ticker/v5/internal/ui/util"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	heightHeader   = 2 // Title and a blank line
+	heightVolume   = 3 // Rows of volume bars
+	heightFooter   = 3 // Time axis labels, a blank line, and the legend
+	heightPlotMin  = 4
+	widthAxisGap   = 1
+	dotsPerColumn  = 2 // Braille characters are two dots wide
+	dotsPerRow     = 4 // Braille characters are four dots high
+	brailleBlank   = 0x2800
+	volumeBlocks   = "▁▂▃▄▅▆▇█"
+	referenceSpace = 2 // Horizontal distance in dots between the dots of a reference line
+)
+
+// brailleBits are the bits of a braille character for each dot indexed by column and then row
+//
+//nolint:gochecknoglobals
+var brailleBits = [dotsPerColumn][dotsPerRow]rune{
+	{0x01, 0x02, 0x04, 0x40},
+	{0x08, 0x10, 0x20, 0x80},
+}
+
+// ranges are the selectable price history ranges in the order they are shown
+//
+//nolint:gochecknoglobals
+var ranges = []struct {
+	historyRange c.PriceHistoryRange
+	label        string
+	timeLayout   string
+}{
+	{c.PriceHistoryRange1D, "1D", "15:04"},
+	{c.PriceHistoryRange5D, "5D", "Mon 15:04"},
+	{c.PriceHistoryRange1M, "1M", "Jan 02"},
+	{c.PriceHistoryRange6M, "6M", "Jan 02"},
+	{c.PriceHistoryRange1Y, "1Y", "Jan 2006"},
+	{c.PriceHistoryRange5Y, "5Y", "Jan 2006"},
+}
+
+// Model for the price history chart screen
+type Model struct {
+	width      int
+	height     int
+	asset      c.Asset
+	rangeIndex int
+	prices     []c.PricePoint
+	err        error
+	isLoading  bool
+	styles     c.Styles
+}
+
+// referenceLine is a price shown as a dotted line across the chart
+type referenceLine struct {
+	label string
+	price float64
+	style c.StyleFn
+}
+
+// Messages for setting the asset to chart and its latest quote and position
+type SetAssetMsg c.Asset
+
+// Messages for moving the selected range by a number of ranges (negative moves to shorter ranges)
+type ChangeRangeMsg int
+
+// Messages for setting the price history of a symbol over a range
+type SetPricesMsg struct {
+	Symbol string
+	Range  c.PriceHistoryRange
+	Prices []c.PricePoint
+	Err    error
+}
+
+// NewModel returns a model with default values
+func NewModel(ctx c.Context) *Model {
+	return &Model{
+		width:  80,
+		height: 24,
+		styles: ctx.Reference.Styles,
+		prices: make([]c.PricePoint, 0),
+	}
+}
+
+// Init initializes the chart component
+func (m *Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the chart component
+func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+		return m, nil
+	case SetAssetMsg:
+		// Price history is cleared when a different asset is charted until it is set for the new asset
+		if m.asset.Symbol != msg.Symbol {
+			m.prices = make([]c.PricePoint, 0)
+			m.err = nil
+			m.isLoading = true
+		}
+
+		m.asset = c.Asset(msg)
+
+		return m, nil
+	case ChangeRangeMsg:
+		rangeIndex := max(0, min(m.rangeIndex+int(msg), len(ranges)-1))
+
+		if rangeIndex != m.rangeIndex {
+			m.rangeIndex = rangeIndex
+			m.prices = make([]c.PricePoint, 0)
+			m.err = nil
+			m.isLoading = true
+		}
+
+		return m, nil
+	case SetPricesMsg:
+		// Ignore price history requested for a different asset or range
+		if msg.Symbol != m.asset.Symbol || msg.Range != m.Range() {
+			return m, nil
+		}
+
+		m.prices = msg.Prices
+		m.err = msg.Err
+		m.isLoading = false
+
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// Range returns the selected price history range
+func (m *Model) Range() c.PriceHistoryRange {
+	return ranges[m.rangeIndex].historyRange
+}
+
+// View rendering hook for bubbletea
+func (m *Model) View() string {
+
+	if m.width < 80 {
+		return fmt.Sprintf("Terminal window too narrow to render content\nResize to fix (%d/80)", m.width)
+	}
+
+	heightPlot := max(heightPlotMin, m.height-heightHeader-heightVolume-heightFooter)
+	lines := []string{m.textTitle(), ""}
+
+	switch {
+	case m.err != nil:
+		lines = append(lines, m.textMessage(m.err.Error(), heightPlot)...)
+	case m.isLoading:
+		lines = append(lines, m.textMessage("Loading...", heightPlot)...)
+	case len(m.prices) < 2:
+		lines = append(lines, m.textMessage("No price history available", heightPlot)...)
+	default:
+		lines = append(lines, m.textChart(heightPlot)...)
+	}
+
+	lines = append(lines, "", m.textLegend())
+
+	return strings.Join(lines, "\n")
+}
+
+func (m *Model) textTitle() string {
+
+	a := m.asset
+	title := m.styles.TextBold(a.Symbol) + m.styles.TextLabel(" • ") + m.styles.Text(a.Name) + "  " +
+		m.styles.Text(u.ConvertFloatToString(a.QuotePrice.Price, a.Meta.IsVariablePrecision))
+
+	if len(m.prices) > 0 && m.prices[0].Price != 0 {
+		change := a.QuotePrice.Price - m.prices[0].Price
+		changePercent := change / m.prices[0].Price * 100
+		title += " " + changeText(change, changePercent, a.Meta.IsVariablePrecision, m.styles)
+	}
+
+	labels := make([]string, 0, len(ranges))
+
+	for i, r := range ranges {
+		if i == m.rangeIndex {
+			labels = append(labels, m.styles.Tag(" "+r.label+" "))
+
+			continue
+		}
+
+		labels = append(labels, m.styles.TextLabel(" "+r.label+" "))
+	}
+
+	return title + "  " + strings.Join(labels, "")
+}
+
+func (m *Model) textMessage(message string, heightPlot int) []string {
+
+	lines := make([]string, heightPlot+heightVolume+1)
+	lines[heightPlot/2] = "  " + m.styles.TextLabel(message)
+
+	return lines
+}
+
+func (m *Model) textLegend() string {
+
+	legend := make([]string, 0)
+
+	for _, reference := range m.referenceLines() {
+		legend = append(legend, reference.style("⠒ "+reference.label+" "+u.ConvertFloatToString(reference.price, m.asset.Meta.IsVariablePrecision)))
+	}
+
+	legend = append(legend, m.styles.TextLabel("←/→: change range  esc: back"))
+
+	return strings.Join(legend, "   ")
+}
+
+// referenceLines returns the previous close, which is only meaningful for the 1D range, and the average cost of the
+// position in the asset if there is one
+func (m *Model) referenceLines() []referenceLine {
+
+	references := make([]referenceLine, 0)
+
+	if m.Range() == c.PriceHistoryRange1D && m.asset.QuotePrice.PricePrevClose > 0 {
+		references = append(references, referenceLine{label: "previous close", price: m.asset.QuotePrice.PricePrevClose, style: m.styles.TextLabel})
+	}
+
+	if m.asset.Position.UnitCost > 0 {
+		references = append(references, referenceLine{label: "cost basis", price: m.asset.Position.UnitCost, style: m.styles.TextLight})
+	}
+
+	return references
+}
+
+// textChart renders the price line with reference lines and a price axis followed by volume bars and a time axis
+func (m *Model) textChart(heightPlot int) []string {
+
+	priceMin, priceMax := m.prices[0].Price, m.prices[0].Price
+	for _, point := range m.prices {
+		priceMin = math.Min(priceMin, point.Price)
+		priceMax = math.Max(priceMax, point.Price)
+	}
+
+	references := m.referenceLines()
+
+	// Reference lines are always shown so the range of prices is extended to include them
+	for _, reference := range references {
+		priceMin = math.Min(priceMin, reference.price)
+		priceMax = math.Max(priceMax, reference.price)
+	}
+
+	if priceMax == priceMin {
+		priceMax += math.Max(math.Abs(priceMax)*0.01, 0.01)
+		priceMin -= math.Max(math.Abs(priceMin)*0.01, 0.01)
+	}
+
+	isVariablePrecision := m.asset.Meta.IsVariablePrecision
+	labelsAxis := map[int]string{
+		0:              u.ConvertFloatToString(priceMax, isVariablePrecision),
+		heightPlot / 2: u.ConvertFloatToString((priceMax+priceMin)/2, isVariablePrecision),
+		heightPlot - 1: u.ConvertFloatToString(priceMin, isVariablePrecision),
+	}
+
+	widthAxis := 0
+	for _, label := range labelsAxis {
+		widthAxis = max(widthAxis, len(label))
+	}
+
+	widthPlot := m.width - widthAxis - widthAxisGap
+	plot := newCanvas(widthPlot, heightPlot)
+	priceToDot := func(price float64) int {
+		return int(math.Round((price - priceMin) / (priceMax - priceMin) * float64(plot.heightDots()-1)))
+	}
+
+	plotsReference := make([]*canvas, len(references))
+	for i, reference := range references {
+		plotsReference[i] = newCanvas(widthPlot, heightPlot)
+		plotsReference[i].horizontalLine(priceToDot(reference.price))
+	}
+
+	xs := pricePositions(len(m.prices), plot.widthDots())
+	for i := 1; i < len(m.prices); i++ {
+		plot.line(xs[i-1], priceToDot(m.prices[i-1].Price), xs[i], priceToDot(m.prices[i].Price))
+	}
+
+	changePercent := 0.0
+	if m.prices[0].Price != 0 {
+		changePercent = (m.prices[len(m.prices)-1].Price - m.prices[0].Price) / m.prices[0].Price * 100
+	}
+	lines := make([]string, 0, heightPlot+heightVolume+1)
+
+	for row := range heightPlot {
+		lines = append(lines, m.textPlotRow(plot, plotsReference, references, row, changePercent)+
+			strings.Repeat(" ", widthAxisGap)+
+			m.styles.TextLabel(fmt.Sprintf("%*s", widthAxis, labelsAxis[row])))
+	}
+
+	lines = append(lines, m.textVolume(xs, widthPlot)...)
+	lines = append(lines, m.textTimeAxis(widthPlot))
+
+	return lines
+}
+
+// textPlotRow renders a row of the chart with the price line styled by the direction of the price change over the
+// range. Reference lines are hidden where they cross the price line.
+func (m *Model) textPlotRow(plot *canvas, plotsReference []*canvas, references []referenceLine, row int, changePercent float64) string {
+
+	var out strings.Builder
+
+	for column := range plot.width {
+		out.WriteString(m.textPlotCell(plot, plotsReference, references, row, column, changePercent))
+	}
+
+	return out.String()
+}
+
+func (m *Model) textPlotCell(plot *canvas, plotsReference []*canvas, references []referenceLine, row int, column int, changePercent float64) string {
+
+	if dots := plot.cells[row][column]; dots != 0 {
+		return m.styles.TextPrice(changePercent, string(brailleBlank+dots))
+	}
+
+	for i, plotReference := range plotsReference {
+		if dots := plotReference.cells[row][column]; dots != 0 {
+			return references[i].style(string(brailleBlank + dots))
+		}
+	}
+
+	return " "
+}
+
+// textVolume renders the total volume traded over the prices in each column as bars
+func (m *Model) textVolume(xs []int, widthPlot int) []string {
+
+	volumes := make([]float64, widthPlot)
+	volumeMax := 0.0
+
+	for i, point := range m.prices {
+		column := xs[i] / dotsPerColumn
+		volumes[column] += point.Volume
+		volumeMax = math.Max(volumeMax, volumes[column])
+	}
+
+	lines := make([]string, heightVolume)
+	blocks := []rune(volumeBlocks)
+
+	if volumeMax == 0 {
+		return lines
+	}
+
+	for row := range heightVolume {
+		var line strings.Builder
+
+		// Levels below the row are shown as a full block and levels above the row are blank
+		levelRowMin := (heightVolume - row - 1) * len(blocks)
+
+		for _, volume := range volumes {
+			level := int(math.Round(volume/volumeMax*float64(heightVolume*len(blocks)))) - levelRowMin
+
+			switch {
+			case level <= 0:
+				line.WriteRune(' ')
+			case level >= len(blocks):
+				line.WriteRune(blocks[len(blocks)-1])
+			default:
+				line.WriteRune(blocks[level-1])
+			}
+		}
+
+		lines[row] = m.styles.TextLabel(line.String())
+	}
+
+	return lines
+}
+
+// textTimeAxis renders the time of the first, middle, and last price aligned to the start, center, and end of the chart
+func (m *Model) textTimeAxis(widthPlot int) string {
+
+	layout := ranges[m.rangeIndex].timeLayout
+	labelStart := time.Unix(m.prices[0].Time, 0).Format(layout)
+	labelMiddle := time.Unix(m.prices[len(m.prices)/2].Time, 0).Format(layout)
+	labelEnd := time.Unix(m.prices[len(m.prices)-1].Time, 0).Format(layout)
+
+	gap := widthPlot - len(labelStart) - len(labelMiddle) - len(labelEnd)
+
+	if gap < 2 {
+		return m.styles.TextLabel(labelStart + strings.Repeat(" ", max(1, widthPlot-len(labelStart)-len(labelEnd))) + labelEnd)
+	}
+
+	return m.styles.TextLabel(labelStart + strings.Repeat(" ", gap/2) + labelMiddle + strings.Repeat(" ", gap-gap/2) + labelEnd)
+}
+
+// pricePositions returns the horizontal position in dots of each price with prices spread evenly across the chart
+func pricePositions(count int, widthDots int) []int {
+
+	xs := make([]int, count)
+
+	if count < 2 {
+		return xs
+	}
+
+	for i := range xs {
+		xs[i] = i * (widthDots - 1) / (count - 1)
+	}
+
+	return xs
+}
+
+func changeText(change float64, changePercent float64, isVariablePrecision bool, styles c.Styles) string {
+	if change == 0.0 {
+		return styles.TextPrice(changePercent, u.ConvertFloatToString(change, isVariablePrecision)+" ("+u.ConvertFloatToString(changePercent, false)+"%)")
+	}
+
+	if change > 0.0 {
+		return styles.TextPrice(changePercent, "↑ "+u.ConvertFloatToString(change, isVariablePrecision)+" ("+u.ConvertFloatToString(changePercent, false)+"%)")
+	}
+
+	return styles.TextPrice(changePercent, "↓ "+u.ConvertFloatToString(change, isVariablePrecision)+" ("+u.ConvertFloatToString(changePercent, false)+"%)")
+}
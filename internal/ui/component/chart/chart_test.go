@@ -0,0 +1,139 @@
+package chart_test
+
+import (
+	"errors"
+	"strings"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	. "github.com/achannarasappa/ticker/v5/internal/ui/component/chart"
+
+	"github.com/acarl005/stripansi"
+	tea "github.com/charmbracelet/bubbletea"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func removeFormatting(text string) string {
+	return stripansi.Strip(text)
+}
+
+var _ = Describe("Chart", func() {
+
+	ctxFixture := c.Context{Reference: c.Reference{Styles: c.Styles{
+		Text:      func(v string) string { return v },
+		TextLight: func(v string) string { return v },
+		TextLabel: func(v string) string { return v },
+		TextBold:  func(v string) string { return v },
+		TextLine:  func(v string) string { return v },
+		TextPrice: func(percent float64, text string) string { return text },
+		Tag:       func(v string) string { return "[" + v + "]" },
+	}}}
+
+	assetFixture := c.Asset{
+		Symbol: "TWKS",
+		Name:   "ThoughtWorks",
+		QuotePrice: c.QuotePrice{
+			Price:          120,
+			PricePrevClose: 110,
+		},
+	}
+
+	pricesFixture := []c.PricePoint{
+		{Time: 1700000000, Price: 100, Volume: 10},
+		{Time: 1700000300, Price: 110, Volume: 20},
+		{Time: 1700000600, Price: 120, Volume: 40},
+	}
+
+	var m *Model
+
+	BeforeEach(func() {
+		m = NewModel(ctxFixture)
+		m, _ = m.Update(tea.WindowSizeMsg{Width: 100, Height: 20})
+		m, _ = m.Update(SetAssetMsg(assetFixture))
+	})
+
+	It("should show that the price history is loading until it is set", func() {
+		Expect(removeFormatting(m.View())).To(ContainSubstring("Loading..."))
+	})
+
+	It("should render the price history with a price axis and volume bars filling the height", func() {
+		m, _ = m.Update(SetPricesMsg{Symbol: "TWKS", Range: c.PriceHistoryRange1D, Prices: pricesFixture})
+
+		view := removeFormatting(m.View())
+		lines := strings.Split(view, "\n")
+
+		Expect(lines).To(HaveLen(20))
+		Expect(lines[0]).To(HavePrefix("TWKS • ThoughtWorks  120.00 ↑ 20.00 (20.00%)  [ 1D ] 5D  1M "))
+		Expect(lines[2]).To(HaveSuffix(" 120.00"))
+		Expect(lines[13]).To(HaveSuffix(" 100.00"))
+		Expect(lines[2]).To(MatchRegexp(`[\x{2801}-\x{28FF}] 120\.00$`))
+		Expect(lines[13]).To(MatchRegexp(`^[\x{2801}-\x{28FF}]`))
+		Expect(lines[16]).To(ContainSubstring("█"))
+		Expect(lines[19]).To(Equal("⠒ previous close 110.00   ←/→: change range  esc: back"))
+	})
+
+	When("there is a position in the asset", func() {
+		It("should show the cost basis as a reference line", func() {
+			assetWithPosition := assetFixture
+			assetWithPosition.Position = c.Position{UnitCost: 90, Quantity: 10}
+
+			m, _ = m.Update(SetAssetMsg(assetWithPosition))
+			m, _ = m.Update(SetPricesMsg{Symbol: "TWKS", Range: c.PriceHistoryRange1D, Prices: pricesFixture})
+
+			lines := strings.Split(removeFormatting(m.View()), "\n")
+
+			Expect(lines[13]).To(HaveSuffix(" 90.00"))
+			Expect(lines[13]).To(HavePrefix("⡀⡀⡀"))
+			Expect(lines[19]).To(ContainSubstring("⠒ cost basis 90.00"))
+		})
+	})
+
+	When("the price history request fails", func() {
+		It("should show the error", func() {
+			m, _ = m.Update(SetPricesMsg{Symbol: "TWKS", Range: c.PriceHistoryRange1D, Err: errors.New("request failed")})
+
+			Expect(removeFormatting(m.View())).To(ContainSubstring("request failed"))
+		})
+	})
+
+	When("the price history is for a different asset", func() {
+		It("should ignore the price history", func() {
+			m, _ = m.Update(SetPricesMsg{Symbol: "AAPL", Range: c.PriceHistoryRange1D, Prices: pricesFixture})
+
+			Expect(removeFormatting(m.View())).To(ContainSubstring("Loading..."))
+		})
+	})
+
+	Describe("ChangeRangeMsg", func() {
+		It("should select the next range and wait for its price history", func() {
+			m, _ = m.Update(SetPricesMsg{Symbol: "TWKS", Range: c.PriceHistoryRange1D, Prices: pricesFixture})
+			m, _ = m.Update(ChangeRangeMsg(1))
+
+			Expect(m.Range()).To(Equal(c.PriceHistoryRange5D))
+			Expect(removeFormatting(m.View())).To(ContainSubstring("Loading..."))
+			Expect(removeFormatting(m.View())).To(ContainSubstring(" 1D [ 5D ]"))
+
+			m, _ = m.Update(SetPricesMsg{Symbol: "TWKS", Range: c.PriceHistoryRange1D, Prices: pricesFixture})
+			Expect(removeFormatting(m.View())).To(ContainSubstring("Loading..."))
+
+			m, _ = m.Update(SetPricesMsg{Symbol: "TWKS", Range: c.PriceHistoryRange5D, Prices: pricesFixture})
+			Expect(removeFormatting(m.View())).NotTo(ContainSubstring("previous close"))
+		})
+
+		It("should not move past the shortest or longest range", func() {
+			m, _ = m.Update(ChangeRangeMsg(-1))
+			Expect(m.Range()).To(Equal(c.PriceHistoryRange1D))
+
+			m, _ = m.Update(ChangeRangeMsg(10))
+			Expect(m.Range()).To(Equal(c.PriceHistoryRange5Y))
+		})
+	})
+
+	When("the terminal is too narrow", func() {
+		It("should show a message to resize", func() {
+			m, _ = m.Update(tea.WindowSizeMsg{Width: 70, Height: 20})
+
+			Expect(m.View()).To(Equal("Terminal window too narrow to render content\nResize to fix (70/80)"))
+		})
+	})
+})
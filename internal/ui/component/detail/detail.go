@@ -0,0 +1,231 @@
+package detail
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/achannarasappa/ticker/v5/internal/asset"
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	u "github.com/achannarasappa/ticker/v5/internal/ui/util"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	widthLabel     = 18
+	widthLotColumn = 14
+)
+
+// Model for the asset detail screen
+type Model struct {
+	width  int
+	asset  c.Asset
+	lots   []asset.LotPosition
+	styles c.Styles
+}
+
+// Messages for setting the asset and its open lots to show
+type SetAssetMsg struct {
+	Asset c.Asset
+	Lots  []asset.LotPosition
+}
+
+// NewModel returns a model with default values
+func NewModel(ctx c.Context) *Model {
+	return &Model{
+		width:  80,
+		styles: ctx.Reference.Styles,
+		lots:   make([]asset.LotPosition, 0),
+	}
+}
+
+// Init initializes the detail component
+func (m *Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the detail component
+func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+
+		return m, nil
+	case SetAssetMsg:
+		m.asset = msg.Asset
+		m.lots = msg.Lots
+
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// View rendering hook for bubbletea
+func (m *Model) View() string {
+
+	if m.width < 80 {
+		return fmt.Sprintf("Terminal window too narrow to render content\nResize to fix (%d/80)", m.width)
+	}
+
+	a := m.asset
+	isVariablePrecision := a.Meta.IsVariablePrecision
+	lines := []string{m.styles.TextBold(a.Symbol) + m.styles.TextLabel(" • ") + m.styles.Text(a.Name)}
+
+	lines = append(lines, m.section("Quote",
+		m.field("Price", u.ConvertFloatToString(a.QuotePrice.Price, isVariablePrecision)),
+		m.fieldStyled("Change", changeText(a.QuotePrice.Change, a.QuotePrice.ChangePercent, isVariablePrecision, m.styles)),
+		m.field("Previous Close", u.ConvertFloatToString(a.QuotePrice.PricePrevClose, isVariablePrecision)),
+		m.field("Open", u.ConvertFloatToString(a.QuotePrice.PriceOpen, isVariablePrecision)),
+		m.field("Day High", u.ConvertFloatToString(a.QuotePrice.PriceDayHigh, isVariablePrecision)),
+		m.field("Day Low", u.ConvertFloatToString(a.QuotePrice.PriceDayLow, isVariablePrecision)),
+		m.field("52 Week High", u.ConvertFloatToString(a.QuoteExtended.FiftyTwoWeekHigh, isVariablePrecision)),
+		m.field("52 Week Low", u.ConvertFloatToString(a.QuoteExtended.FiftyTwoWeekLow, isVariablePrecision)),
+		m.field("Market Cap", u.ConvertFloatToString(a.QuoteExtended.MarketCap, true)),
+		m.field("Volume", u.ConvertFloatToString(a.QuoteExtended.Volume, true)),
+	)...)
+
+	if a.Class == c.AssetClassFuturesContract {
+		lines = append(lines, m.section("Futures",
+			m.field("Underlying", a.QuoteFutures.SymbolUnderlying),
+			m.field("Index Price", u.ConvertFloatToString(a.QuoteFutures.IndexPrice, isVariablePrecision)),
+			m.field("Basis", u.ConvertFloatToString(a.QuoteFutures.Basis, false)+"%"),
+			m.field("Open Interest", u.ConvertFloatToString(a.QuoteFutures.OpenInterest, true)),
+			m.field("Expiry", a.QuoteFutures.Expiry),
+			m.field("Contract Size", u.ConvertFloatToString(a.QuoteFutures.ContractSize, true)),
+		)...)
+	}
+
+	lines = append(lines, m.section("Exchange",
+		m.field("Name", a.Exchange.Name),
+		m.field("State", exchangeStateText(a.Exchange)),
+		m.field("Delay", exchangeDelayText(a.Exchange.Delay, a.Exchange.DelayText)),
+		m.field("Currency", currencyText(a.Currency)),
+	)...)
+
+	if a.Position != (c.Position{}) {
+		lines = append(lines, m.section("Position",
+			m.field("Quantity", u.ConvertFloatToString(a.Position.Quantity, isVariablePrecision)),
+			m.field("Average Cost", u.ConvertFloatToString(a.Position.UnitCost, isVariablePrecision)),
+			m.field("Value", u.ConvertFloatToString(a.Position.Value, false)),
+			m.field("Cost", u.ConvertFloatToString(a.Position.Cost, false)),
+			m.fieldStyled("Day Change", changeText(a.Position.DayChange.Amount, a.Position.DayChange.Percent, false, m.styles)),
+			m.fieldStyled("Total Change", changeText(a.Position.TotalChange.Amount, a.Position.TotalChange.Percent, false, m.styles)),
+			m.field("Weight", u.ConvertFloatToString(a.Position.Weight, false)+"%"),
+			m.field("Realized Gain", u.ConvertFloatToString(a.Position.RealizedGain, false)),
+			m.field("Income", u.ConvertFloatToString(a.Position.Income, false)),
+			m.fieldStyled("Total Return", changeText(a.Position.TotalReturn.Amount, a.Position.TotalReturn.Percent, false, m.styles)),
+		)...)
+	}
+
+	if len(m.lots) > 0 {
+		lines = append(lines, m.section("Lots", m.lotLines()...)...)
+	}
+
+	lines = append(lines, "", m.styles.TextLabel("esc: back"))
+
+	return strings.Join(lines, "\n")
+}
+
+func (m *Model) section(title string, fields ...string) []string {
+	return append([]string{"", m.styles.TextBold(title)}, fields...)
+}
+
+func (m *Model) field(label string, value string) string {
+	return m.fieldStyled(label, m.styles.Text(value))
+}
+
+func (m *Model) fieldStyled(label string, value string) string {
+	return "  " + m.styles.TextLabel(fmt.Sprintf("%-*s", widthLabel, label)) + value
+}
+
+func (m *Model) lotLines() []string {
+
+	isVariablePrecision := m.asset.Meta.IsVariablePrecision
+	header := fmt.Sprintf("  %-4s", "#")
+
+	for _, label := range []string{"Quantity", "Unit Cost", "Fixed Cost", "Cost", "Value"} {
+		header += fmt.Sprintf("%*s", widthLotColumn, label)
+	}
+
+	lines := []string{m.styles.TextLabel(header + "  Gain/Loss")}
+
+	for i, lot := range m.lots {
+		text := fmt.Sprintf("  %-4s", strconv.Itoa(i+1))
+
+		for _, value := range []string{
+			u.ConvertFloatToString(lot.Lot.Quantity, isVariablePrecision),
+			u.ConvertFloatToString(lot.Lot.UnitCost, isVariablePrecision),
+			u.ConvertFloatToString(lot.Lot.FixedCost, false),
+			u.ConvertFloatToString(lot.Cost, false),
+			u.ConvertFloatToString(lot.Value, false),
+		} {
+			text += fmt.Sprintf("%*s", widthLotColumn, value)
+		}
+
+		lines = append(lines, m.styles.Text(text)+"  "+changeText(lot.TotalChange.Amount, lot.TotalChange.Percent, false, m.styles))
+	}
+
+	return lines
+}
+
+func changeText(change float64, changePercent float64, isVariablePrecision bool, styles c.Styles) string {
+	if change == 0.0 {
+		return styles.TextPrice(changePercent, u.ConvertFloatToString(change, isVariablePrecision)+" ("+u.ConvertFloatToString(changePercent, false)+"%)")
+	}
+
+	if change > 0.0 {
+		return styles.TextPrice(changePercent, "↑ "+u.ConvertFloatToString(change, isVariablePrecision)+" ("+u.ConvertFloatToString(changePercent, false)+"%)")
+	}
+
+	return styles.TextPrice(changePercent, "↓ "+u.ConvertFloatToString(change, isVariablePrecision)+" ("+u.ConvertFloatToString(changePercent, false)+"%)")
+}
+
+func exchangeStateText(exchange c.Exchange) string {
+
+	var state string
+
+	switch exchange.State {
+	case c.ExchangeStateOpen:
+		state = "Open"
+	case c.ExchangeStatePremarket:
+		state = "Pre-market"
+	case c.ExchangeStatePostmarket:
+		state = "Post-market"
+	case c.ExchangeStateClosed:
+		state = "Closed"
+	}
+
+	if exchange.IsRegularTradingSession {
+		return state + " (regular session)"
+	}
+
+	if exchange.IsActive {
+		return state + " (extended session)"
+	}
+
+	return state
+}
+
+func exchangeDelayText(delay float64, delayText string) string {
+
+	if delayText != "" {
+		return delayText
+	}
+
+	if delay <= 0 {
+		return "Live"
+	}
+
+	return "Delayed " + strconv.FormatFloat(delay, 'f', 0, 64) + "min"
+}
+
+func currencyText(currency c.Currency) string {
+
+	if currency.ToCurrencyCode != "" && currency.ToCurrencyCode != currency.FromCurrencyCode {
+		return currency.FromCurrencyCode + " → " + currency.ToCurrencyCode
+	}
+
+	return currency.FromCurrencyCode
+}
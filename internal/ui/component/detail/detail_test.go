@@ -0,0 +1,181 @@
+package detail_test
+
+import (
+	"strings"
+
+	"github.com/achannarasappa/ticker/v5/internal/asset"
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	. "github.com/achannarasappa/ticker/v5/internal/ui/component/detail"
+
+	"github.com/acarl005/stripansi"
+	tea "github.com/charmbracelet/bubbletea"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func removeFormatting(text string) string {
+	return stripansi.Strip(text)
+}
+
+var _ = Describe("Detail", func() {
+
+	ctxFixture := c.Context{Reference: c.Reference{Styles: c.Styles{
+		Text:      func(v string) string { return v },
+		TextLight: func(v string) string { return v },
+		TextLabel: func(v string) string { return v },
+		TextBold:  func(v string) string { return v },
+		TextLine:  func(v string) string { return v },
+		TextPrice: func(percent float64, text string) string { return text },
+		Tag:       func(v string) string { return v },
+	}}}
+
+	assetFixture := c.Asset{
+		Symbol:   "TWKS",
+		Name:     "ThoughtWorks",
+		Class:    c.AssetClassStock,
+		Currency: c.Currency{FromCurrencyCode: "USD", ToCurrencyCode: "EUR"},
+		QuotePrice: c.QuotePrice{
+			Price:          110,
+			PricePrevClose: 100,
+			PriceOpen:      101,
+			PriceDayHigh:   112,
+			PriceDayLow:    99,
+			Change:         10,
+			ChangePercent:  10,
+		},
+		QuoteExtended: c.QuoteExtended{
+			FiftyTwoWeekHigh: 150,
+			FiftyTwoWeekLow:  50,
+			MarketCap:        5000000000,
+			Volume:           2000000,
+		},
+		Exchange: c.Exchange{
+			Name:                    "NASDAQ",
+			Delay:                   15,
+			State:                   c.ExchangeStateOpen,
+			IsActive:                true,
+			IsRegularTradingSession: true,
+		},
+	}
+
+	It("should render the quote and exchange details", func() {
+		m := NewModel(ctxFixture)
+		m, _ = m.Update(tea.WindowSizeMsg{Width: 100})
+		m, _ = m.Update(SetAssetMsg{Asset: assetFixture})
+
+		Expect(removeFormatting(m.View())).To(Equal(strings.Join([]string{
+			"TWKS • ThoughtWorks",
+			"",
+			"Quote",
+			"  Price             110.00",
+			"  Change            ↑ 10.00 (10.00%)",
+			"  Previous Close    100.00",
+			"  Open              101.00",
+			"  Day High          112.00",
+			"  Day Low           99.00",
+			"  52 Week High      150.00",
+			"  52 Week Low       50.00",
+			"  Market Cap        5.0000 B",
+			"  Volume            2.0000 M",
+			"",
+			"Exchange",
+			"  Name              NASDAQ",
+			"  State             Open (regular session)",
+			"  Delay             Delayed 15min",
+			"  Currency          USD → EUR",
+			"",
+			"esc: back",
+		}, "\n")))
+	})
+
+	When("the asset is a futures contract", func() {
+		It("should render the futures details", func() {
+			inputAsset := assetFixture
+			inputAsset.Class = c.AssetClassFuturesContract
+			inputAsset.QuoteFutures = c.QuoteFutures{
+				SymbolUnderlying: "BTC-USD",
+				IndexPrice:       109,
+				Basis:            0.5,
+				OpenInterest:     1200,
+				Expiry:           "3d 4h",
+				ContractSize:     0.01,
+			}
+
+			m := NewModel(ctxFixture)
+			m, _ = m.Update(SetAssetMsg{Asset: inputAsset})
+
+			Expect(removeFormatting(m.View())).To(ContainSubstring(strings.Join([]string{
+				"Futures",
+				"  Underlying        BTC-USD",
+				"  Index Price       109.00",
+				"  Basis             0.50%",
+				"  Open Interest     1200.00",
+				"  Expiry            3d 4h",
+				"  Contract Size     0.0100",
+			}, "\n")))
+		})
+	})
+
+	When("there is a position", func() {
+		It("should render the position and each lot with its gain or loss", func() {
+			inputAsset := assetFixture
+			inputAsset.Position = c.Position{
+				Value:        1650,
+				Cost:         1305,
+				Quantity:     15,
+				UnitCost:     87,
+				DayChange:    c.PositionChange{Amount: 150, Percent: 10},
+				TotalChange:  c.PositionChange{Amount: 345, Percent: 26.44},
+				Weight:       100,
+				RealizedGain: 90,
+			}
+
+			m := NewModel(ctxFixture)
+			m, _ = m.Update(SetAssetMsg{
+				Asset: inputAsset,
+				Lots: []asset.LotPosition{
+					{
+						Lot:         c.Lot{Symbol: "TWKS", UnitCost: 100, Quantity: 5, FixedCost: 5},
+						Value:       550,
+						Cost:        505,
+						TotalChange: c.PositionChange{Amount: 45, Percent: 8.91},
+					},
+					{
+						Lot:         c.Lot{Symbol: "TWKS", UnitCost: 120, Quantity: 10},
+						Value:       1100,
+						Cost:        1200,
+						TotalChange: c.PositionChange{Amount: -100, Percent: -8.33},
+					},
+				},
+			})
+
+			Expect(removeFormatting(m.View())).To(ContainSubstring(strings.Join([]string{
+				"Position",
+				"  Quantity          15.00",
+				"  Average Cost      87.00",
+				"  Value             1650.00",
+				"  Cost              1305.00",
+				"  Day Change        ↑ 150.00 (10.00%)",
+				"  Total Change      ↑ 345.00 (26.44%)",
+				"  Weight            100.00%",
+				"  Realized Gain     90.00",
+				"  Income            0.00",
+				"  Total Return      0.00 (0.00%)",
+				"",
+				"Lots",
+				"  #         Quantity     Unit Cost    Fixed Cost          Cost         Value  Gain/Loss",
+				"  1             5.00        100.00          5.00        505.00        550.00  ↑ 45.00 (8.91%)",
+				"  2            10.00        120.00          0.00       1200.00       1100.00  ↓ -100.00 (-8.33%)",
+			}, "\n")))
+		})
+	})
+
+	When("the window width is less than the minimum", func() {
+		It("should render a message to resize the window", func() {
+			m := NewModel(ctxFixture)
+			m, _ = m.Update(tea.WindowSizeMsg{Width: 70})
+
+			Expect(m.View()).To(Equal("Terminal window too narrow to render content\nResize to fix (70/80)"))
+		})
+	})
+})
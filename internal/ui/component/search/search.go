@@ -0,0 +1,228 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/reflow/truncate"
+)
+
+const (
+	widthSymbol   = 16
+	widthExchange = 14
+	widthType     = 16
+)
+
+// Config represents the configuration for the search component
+type Config struct {
+	Search func(query string) ([]c.SymbolSearchResult, error)
+	Styles c.Styles
+}
+
+// Model for the symbol search overlay
+type Model struct {
+	width         int
+	query         string
+	searchedQuery string
+	results       []c.SymbolSearchResult
+	cursor        int
+	isSearching   bool
+	status        string
+	config        Config
+}
+
+// Messages for resetting the search when the overlay is opened
+type OpenMsg struct{}
+
+// Messages sent when the overlay should be closed
+type CloseMsg struct{}
+
+// Messages for setting the results of a search
+type SetResultsMsg struct {
+	Query   string
+	Results []c.SymbolSearchResult
+	Err     error
+}
+
+// Messages sent when a result is selected to be added to the current group and optionally saved to the config file
+type SelectMsg struct {
+	Result  c.SymbolSearchResult
+	Persist bool
+}
+
+// Messages for showing the outcome of an action
+type SetStatusMsg string
+
+// NewModel returns a model with default values
+func NewModel(config Config) *Model {
+	return &Model{
+		width:   80,
+		config:  config,
+		results: make([]c.SymbolSearchResult, 0),
+	}
+}
+
+// Init initializes the search component
+func (m *Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the search component
+func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+
+		return m, nil
+	case OpenMsg:
+		m.query = ""
+		m.searchedQuery = ""
+		m.results = make([]c.SymbolSearchResult, 0)
+		m.cursor = 0
+		m.isSearching = false
+		m.status = ""
+
+		return m, nil
+	case SetResultsMsg:
+		m.isSearching = false
+		m.searchedQuery = msg.Query
+		m.results = msg.Results
+		m.cursor = 0
+		m.status = ""
+
+		if msg.Err != nil {
+			m.status = "Search failed: " + msg.Err.Error()
+		} else if len(msg.Results) == 0 {
+			m.status = "No symbols found for '" + msg.Query + "'"
+		}
+
+		return m, nil
+	case SetStatusMsg:
+		m.status = string(msg)
+
+		return m, nil
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+
+	return m, nil
+}
+
+func (m *Model) handleKey(msg tea.KeyMsg) (*Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		return m, func() tea.Msg { return CloseMsg{} }
+	case tea.KeyUp, tea.KeyCtrlP:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+
+		return m, nil
+	case tea.KeyDown, tea.KeyCtrlN:
+		if m.cursor < len(m.results)-1 {
+			m.cursor++
+		}
+
+		return m, nil
+	case tea.KeyBackspace:
+		if len(m.query) > 0 {
+			runes := []rune(m.query)
+			m.query = string(runes[:len(runes)-1])
+		}
+
+		return m, nil
+	case tea.KeyRunes, tea.KeySpace:
+		m.query += string(msg.Runes)
+
+		return m, nil
+	case tea.KeyCtrlS:
+		return m, m.selectResult(true)
+	case tea.KeyEnter:
+		// Results are only selectable when they match the current query otherwise a new search is started
+		if m.hasCurrentResults() {
+			return m, m.selectResult(false)
+		}
+
+		return m, m.startSearch()
+	}
+
+	return m, nil
+}
+
+func (m *Model) hasCurrentResults() bool {
+	return len(m.results) > 0 && m.query == m.searchedQuery
+}
+
+func (m *Model) selectResult(persist bool) tea.Cmd {
+	if !m.hasCurrentResults() {
+		return nil
+	}
+
+	result := m.results[m.cursor]
+
+	return func() tea.Msg {
+		return SelectMsg{Result: result, Persist: persist}
+	}
+}
+
+func (m *Model) startSearch() tea.Cmd {
+	query := m.query
+
+	if strings.TrimSpace(query) == "" || m.isSearching || m.config.Search == nil {
+		return nil
+	}
+
+	m.isSearching = true
+	m.status = "Searching..."
+	search := m.config.Search
+
+	return func() tea.Msg {
+		results, err := search(strings.TrimSpace(query))
+
+		return SetResultsMsg{Query: query, Results: results, Err: err}
+	}
+}
+
+// View rendering hook for bubbletea
+func (m *Model) View() string {
+
+	styles := m.config.Styles
+	lines := []string{
+		styles.TextLabel("Search: ") + styles.TextBold(m.query+"█"),
+		"",
+	}
+
+	widthName := max(m.width-widthSymbol-widthExchange-widthType-2, 0)
+
+	for i, result := range m.results {
+		text := fmt.Sprintf("%-*s%-*s%-*s%s",
+			widthSymbol, truncate.StringWithTail(result.Symbol, widthSymbol-1, "…"),
+			widthName, truncate.StringWithTail(result.Name, uint(max(widthName-1, 0)), "…"),
+			widthExchange, truncate.StringWithTail(result.Exchange, widthExchange-1, "…"),
+			truncate.StringWithTail(result.Type, widthType, "…"),
+		)
+
+		if i == m.cursor && m.hasCurrentResults() {
+			lines = append(lines, styles.TextBold("› "+text))
+
+			continue
+		}
+
+		lines = append(lines, styles.TextLight("  "+text))
+	}
+
+	if len(m.results) > 0 {
+		lines = append(lines, "")
+	}
+
+	if m.status != "" {
+		lines = append(lines, styles.Text(m.status))
+	}
+
+	lines = append(lines, styles.TextLabel("enter: search/add to group • ctrl+s: add and save to config • ↑/↓: select • esc: close"))
+
+	return strings.Join(lines, "\n")
+}
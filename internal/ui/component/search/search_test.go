@@ -0,0 +1,158 @@
+package search_test
+
+import (
+	"errors"
+	"strings"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	. "github.com/achannarasappa/ticker/v5/internal/ui/component/search"
+
+	"github.com/acarl005/stripansi"
+	tea "github.com/charmbracelet/bubbletea"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func removeFormatting(text string) string {
+	return stripansi.Strip(text)
+}
+
+func typeText(m *Model, text string) *Model {
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)})
+
+	return m
+}
+
+var _ = Describe("Search", func() {
+
+	var (
+		inputQueries []string
+		inputResults []c.SymbolSearchResult
+		inputErr     error
+		m            *Model
+	)
+
+	stylesFixture := c.Styles{
+		Text:      func(v string) string { return v },
+		TextLight: func(v string) string { return v },
+		TextLabel: func(v string) string { return v },
+		TextBold:  func(v string) string { return v },
+		TextLine:  func(v string) string { return v },
+		TextPrice: func(percent float64, text string) string { return text },
+		Tag:       func(v string) string { return v },
+	}
+
+	BeforeEach(func() {
+		inputQueries = make([]string, 0)
+		inputResults = []c.SymbolSearchResult{
+			{Symbol: "AAPL", SymbolInSourceAPI: "AAPL", Name: "Apple Inc.", Exchange: "NASDAQ", Type: "Equity", Source: c.QuoteSourceYahoo},
+			{Symbol: "APLE", SymbolInSourceAPI: "APLE", Name: "Apple Hospitality REIT, Inc.", Exchange: "NYSE", Type: "Equity", Source: c.QuoteSourceYahoo},
+		}
+		inputErr = nil
+		m = NewModel(Config{
+			Search: func(query string) ([]c.SymbolSearchResult, error) {
+				inputQueries = append(inputQueries, query)
+
+				return inputResults, inputErr
+			},
+			Styles: stylesFixture,
+		})
+		m, _ = m.Update(tea.WindowSizeMsg{Width: 80})
+	})
+
+	When("enter is pressed after typing a query", func() {
+		It("should search for the query and show the results", func() {
+			m = typeText(m, "apple")
+			m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			Expect(removeFormatting(m.View())).To(ContainSubstring("Searching..."))
+
+			m, _ = m.Update(cmd())
+
+			Expect(inputQueries).To(Equal([]string{"apple"}))
+			Expect(removeFormatting(m.View())).To(Equal(strings.Join([]string{
+				"Search: apple█",
+				"",
+				"› AAPL            Apple Inc.                      NASDAQ        Equity",
+				"  APLE            Apple Hospitality REIT, Inc.    NYSE          Equity",
+				"",
+				"enter: search/add to group • ctrl+s: add and save to config • ↑/↓: select • esc: close",
+			}, "\n")))
+		})
+	})
+
+	When("a result is selected", func() {
+		BeforeEach(func() {
+			m = typeText(m, "apple")
+			_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			m, _ = m.Update(cmd())
+			m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+		})
+
+		It("should send a message to add the selected result", func() {
+			_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			Expect(cmd()).To(Equal(SelectMsg{Result: inputResults[1], Persist: false}))
+		})
+
+		It("should send a message to add and save the selected result when ctrl+s is pressed", func() {
+			_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+			Expect(cmd()).To(Equal(SelectMsg{Result: inputResults[1], Persist: true}))
+		})
+
+		When("the query has changed since the search", func() {
+			It("should search again instead of selecting a result", func() {
+				m, _ = m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+				_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+				Expect(cmd()).To(Equal(SetResultsMsg{Query: "appl", Results: inputResults}))
+			})
+		})
+	})
+
+	When("the search fails", func() {
+		It("should show the error", func() {
+			inputErr = errors.New("network error")
+			m = typeText(m, "apple")
+			_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			m, _ = m.Update(cmd())
+
+			Expect(removeFormatting(m.View())).To(ContainSubstring("Search failed: network error"))
+		})
+	})
+
+	When("there are no results", func() {
+		It("should show a message", func() {
+			m, _ = m.Update(SetResultsMsg{Query: "zzz", Results: []c.SymbolSearchResult{}})
+
+			Expect(removeFormatting(m.View())).To(ContainSubstring("No symbols found for 'zzz'"))
+		})
+	})
+
+	When("the query is empty", func() {
+		It("should not search", func() {
+			_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+			Expect(cmd).To(BeNil())
+		})
+	})
+
+	When("esc is pressed", func() {
+		It("should send a message to close the overlay", func() {
+			_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+			Expect(cmd()).To(Equal(CloseMsg{}))
+		})
+	})
+
+	When("the overlay is opened again", func() {
+		It("should clear the previous search", func() {
+			m = typeText(m, "apple")
+			m, _ = m.Update(SetStatusMsg("Added AAPL to group 'default'"))
+			m, _ = m.Update(OpenMsg{})
+
+			Expect(removeFormatting(m.View())).To(Equal(strings.Join([]string{
+				"Search: █",
+				"",
+				"enter: search/add to group • ctrl+s: add and save to config • ↑/↓: select • esc: close",
+			}, "\n")))
+		})
+	})
+})
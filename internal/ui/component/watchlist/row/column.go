@@ -0,0 +1,318 @@
+package row
+
+import (
+	"slices"
+
+	c "github.com/achannarasappa/ticker/v5/internal/common"
+	u "github.com/achannarasappa/ticker/v5/internal/ui/util"
+
+	grid "github.com/achannarasappa/term-grid"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Names of columns which can be set with the columns option
+const (
+	ColumnPrice         = "price"
+	ColumnChange        = "change"
+	ColumnChangePercent = "change-percent"
+	ColumnPrevClose     = "prev-close"
+	ColumnOpen          = "open"
+	ColumnDayRange      = "day-range"
+	Column52WeekRange   = "52-week-range"
+	ColumnVolume        = "volume"
+	ColumnMarketCap     = "market-cap"
+	ColumnUnitCost      = "unit-cost"
+	ColumnQuantity      = "quantity"
+	ColumnValue         = "value"
+	ColumnWeight        = "weight"
+	ColumnDayChange     = "day-change"
+	ColumnTotalChange   = "total-change"
+	ColumnBasis         = "basis"
+	ColumnExpiry        = "expiry"
+)
+
+// column is a field of an asset shown as a cell with its value above a label
+type column struct {
+	label string
+	// value returns the text of the field without styles which is also used to size the cell
+	value func(asset *c.Asset) string
+	// style returns the text of the field with styles applied
+	style func(asset *c.Asset, styles c.Styles, value string) string
+}
+
+//nolint:gochecknoglobals
+var columns = map[string]column{
+	ColumnPrice: {
+		label: "Price",
+		value: func(asset *c.Asset) string {
+			return u.ConvertFloatToString(asset.QuotePrice.Price, asset.Meta.IsVariablePrecision)
+		},
+		style: styleText,
+	},
+	ColumnChange: {
+		label: "Change",
+		value: func(asset *c.Asset) string {
+			return u.ConvertFloatToString(asset.QuotePrice.Change, asset.Meta.IsVariablePrecision)
+		},
+		style: styleQuoteChange,
+	},
+	ColumnChangePercent: {
+		label: "Change %",
+		value: func(asset *c.Asset) string {
+			return u.ConvertFloatToString(asset.QuotePrice.ChangePercent, false) + "%"
+		},
+		style: styleQuoteChange,
+	},
+	ColumnPrevClose: {
+		label: "Prev. Close",
+		value: func(asset *c.Asset) string {
+			return priceText(asset.QuotePrice.PricePrevClose, asset)
+		},
+		style: styleText,
+	},
+	ColumnOpen: {
+		label: "Open",
+		value: func(asset *c.Asset) string {
+			return priceText(asset.QuotePrice.PriceOpen, asset)
+		},
+		style: styleText,
+	},
+	ColumnDayRange: {
+		label: "Day Range",
+		value: func(asset *c.Asset) string {
+			return rangeText(asset.QuotePrice.PriceDayLow, asset.QuotePrice.PriceDayHigh, asset)
+		},
+		style: styleText,
+	},
+	Column52WeekRange: {
+		label: "52wk Range",
+		value: func(asset *c.Asset) string {
+			return rangeText(asset.QuoteExtended.FiftyTwoWeekLow, asset.QuoteExtended.FiftyTwoWeekHigh, asset)
+		},
+		style: styleText,
+	},
+	ColumnVolume: {
+		label: "Volume",
+		value: func(asset *c.Asset) string {
+			return u.ConvertFloatToString(asset.QuoteExtended.Volume, true)
+		},
+		style: styleText,
+	},
+	ColumnMarketCap: {
+		label: "Market Cap",
+		value: func(asset *c.Asset) string {
+			if asset.QuoteExtended.MarketCap == 0.0 {
+				return ""
+			}
+
+			return u.ConvertFloatToString(asset.QuoteExtended.MarketCap, true)
+		},
+		style: styleText,
+	},
+	ColumnUnitCost: {
+		label: "Avg. Cost",
+		value: func(asset *c.Asset) string {
+			if asset.Position.Quantity == 0.0 {
+				return ""
+			}
+
+			return u.ConvertFloatToString(asset.Position.UnitCost, asset.Meta.IsVariablePrecision)
+		},
+		style: styleText,
+	},
+	ColumnQuantity: {
+		label: "Quantity",
+		value: func(asset *c.Asset) string {
+			if asset.Position.Quantity == 0.0 {
+				return ""
+			}
+
+			return u.ConvertFloatToString(asset.Position.Quantity, asset.Meta.IsVariablePrecision)
+		},
+		style: styleText,
+	},
+	ColumnValue: {
+		label: "Value",
+		value: func(asset *c.Asset) string {
+			if asset.Position.Value == 0.0 {
+				return ""
+			}
+
+			return u.ConvertFloatToString(asset.Position.Value, asset.Meta.IsVariablePrecision)
+		},
+		style: styleText,
+	},
+	ColumnWeight: {
+		label: "Weight",
+		value: func(asset *c.Asset) string {
+			if asset.Position.Value == 0.0 {
+				return ""
+			}
+
+			return u.ConvertFloatToString(asset.Position.Weight, false) + "%"
+		},
+		style: styleText,
+	},
+	ColumnDayChange: {
+		label: "Day P/L",
+		value: func(asset *c.Asset) string {
+			if asset.Position.Quantity == 0.0 {
+				return ""
+			}
+
+			return changeText(asset.Position.DayChange.Amount, asset.Position.DayChange.Percent, asset.Meta.IsVariablePrecision)
+		},
+		style: func(asset *c.Asset, styles c.Styles, value string) string {
+			return styles.TextPrice(asset.Position.DayChange.Percent, value)
+		},
+	},
+	ColumnTotalChange: {
+		label: "Total P/L",
+		value: func(asset *c.Asset) string {
+			if asset.Position.Quantity == 0.0 {
+				return ""
+			}
+
+			return changeText(asset.Position.TotalChange.Amount, asset.Position.TotalChange.Percent, asset.Meta.IsVariablePrecision)
+		},
+		style: func(asset *c.Asset, styles c.Styles, value string) string {
+			return styles.TextPrice(asset.Position.TotalChange.Percent, value)
+		},
+	},
+	ColumnBasis: {
+		label: "Basis",
+		value: func(asset *c.Asset) string {
+			if asset.Class != c.AssetClassFuturesContract || asset.QuoteFutures.IndexPrice == 0.0 {
+				return ""
+			}
+
+			return u.ConvertFloatToString(asset.QuoteFutures.Basis, false) + "%"
+		},
+		style: styleText,
+	},
+	ColumnExpiry: {
+		label: "Expiry",
+		value: func(asset *c.Asset) string {
+			if asset.Class != c.AssetClassFuturesContract {
+				return ""
+			}
+
+			return asset.QuoteFutures.Expiry
+		},
+		style: styleText,
+	},
+}
+
+// GetColumns returns the names of all columns
+func GetColumns() []string {
+	return []string{
+		ColumnPrice,
+		ColumnChange,
+		ColumnChangePercent,
+		ColumnPrevClose,
+		ColumnOpen,
+		ColumnDayRange,
+		Column52WeekRange,
+		ColumnVolume,
+		ColumnMarketCap,
+		ColumnUnitCost,
+		ColumnQuantity,
+		ColumnValue,
+		ColumnWeight,
+		ColumnDayChange,
+		ColumnTotalChange,
+		ColumnBasis,
+		ColumnExpiry,
+	}
+}
+
+// IsValidColumn returns whether a column is one which can be shown
+func IsValidColumn(name string) bool {
+	_, exists := columns[name]
+
+	return exists
+}
+
+// GetColumnWidth returns the width needed to show a column for an asset
+func GetColumnWidth(name string, asset *c.Asset) int {
+
+	col, exists := columns[name]
+	if !exists {
+		return 0
+	}
+
+	return max(lipgloss.Width(col.value(asset)), len(col.label))
+}
+
+// buildCellsColumns returns the name and market state followed by a cell for each configured column. Columns at the end
+// are hidden first when the terminal is too narrow to show all of them.
+func (m *Model) buildCellsColumns() []grid.Cell {
+
+	cells := []grid.Cell{
+		{Text: textName(m.config.Asset, m.config.Styles, m.isSelected), Width: WidthName},
+		{Text: ""},
+		{Text: textMarketState(m.config.Asset, m.config.Styles), Width: WidthMarketState, Align: grid.Right},
+	}
+	widthMinTerm := WidthName + WidthMarketState + (2 * WidthGutter)
+
+	for _, name := range m.config.Columns {
+		col, exists := columns[name]
+		if !exists {
+			continue
+		}
+
+		width := m.cellWidths.WidthColumns[name]
+		widthMinTerm += width + WidthGutter
+
+		cells = append(cells, grid.Cell{
+			Text:            m.textColumn(name, col) + "\n" + m.config.Styles.TextLabel(col.label),
+			Width:           width,
+			Align:           grid.Right,
+			VisibleMinWidth: widthMinTerm,
+		})
+	}
+
+	if m.cellWidths.WidthSparkline > 0 {
+		cells = slices.Insert(cells, 3, m.buildCellSparkline(widthMinTerm))
+	}
+
+	return cells
+}
+
+// textColumn returns the styled value of a column and animates the price when it changes
+func (m *Model) textColumn(name string, col column) string {
+
+	if name == ColumnPrice {
+		return m.priceNoChangeSegment + m.priceStyle.Render(m.priceChangeSegment)
+	}
+
+	return col.style(m.config.Asset, m.config.Styles, col.value(m.config.Asset))
+}
+
+func styleText(_ *c.Asset, styles c.Styles, value string) string {
+	return styles.Text(value)
+}
+
+func styleQuoteChange(asset *c.Asset, styles c.Styles, value string) string {
+	return styles.TextPrice(asset.QuotePrice.ChangePercent, value)
+}
+
+func priceText(price float64, asset *c.Asset) string {
+
+	if price == 0.0 {
+		return ""
+	}
+
+	return u.ConvertFloatToString(price, asset.Meta.IsVariablePrecision)
+}
+
+func rangeText(low float64, high float64, asset *c.Asset) string {
+
+	if low == 0.0 || high == 0.0 {
+		return ""
+	}
+
+	return u.ConvertFloatToString(low, asset.Meta.IsVariablePrecision) +
+		" - " +
+		u.ConvertFloatToString(high, asset.Meta.IsVariablePrecision)
+}
@@ -1,6 +1,7 @@
 package row
 
 import (
+	"slices"
 	"strconv"
 	"strings"
 	"sync/atomic"
@@ -22,8 +23,12 @@ const (
 	WidthPositionGutter = 2
 	WidthChangeStatic   = 12 // "↓ " + " (100.00%)" = 12 length
 	WidthRangeStatic    = 3  // " - " = 3 length
+	WidthSparkline      = 24
 )
 
+// sparklineBlocks are the characters for each eighth of the height of a line in a sparkline
+const sparklineBlocks = "▁▂▃▄▅▆▇█"
+
 var lastID int64 //nolint:gochecknoglobals
 
 type SetCellWidthsMsg struct {
@@ -39,7 +44,10 @@ type CellWidthsContainer struct {
 	WidthQuoteRange       int
 	WidthPosition         int
 	WidthPositionExtended int
+	WidthPositionIncome   int
 	WidthVolumeMarketCap  int
+	WidthSparkline        int
+	WidthColumns          map[string]int
 }
 
 type Config struct {
@@ -48,12 +56,19 @@ type Config struct {
 	ShowPositions         bool
 	ExtraInfoExchange     bool
 	ExtraInfoFundamentals bool
+	Columns               []string
 	Styles                c.Styles
 	Asset                 *c.Asset
 }
 
 type UpdateAssetMsg *c.Asset
 
+// Messages for setting whether the row is the selected row in the watchlist
+type SetSelectedMsg bool
+
+// Messages for setting the prices shown in the sparkline in chronological order
+type SetSparklineMsg []float64
+
 type FrameMsg int
 
 // Model for watchlist row
@@ -67,6 +82,8 @@ type Model struct {
 	priceChangeSegment   string
 	priceNoChangeSegment string
 	priceChangeDirection int
+	isSelected           bool
+	sparkline            []float64
 }
 
 // New returns a model with default values
@@ -103,6 +120,16 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 
 		return m, nil
 
+	case SetSelectedMsg:
+		m.isSelected = bool(msg)
+
+		return m, nil
+
+	case SetSparklineMsg:
+		m.sparkline = msg
+
+		return m, nil
+
 	case UpdateAssetMsg:
 
 		// If symbol has not changed and price has changed then start the price animation
@@ -237,18 +264,27 @@ func (m *Model) View() string {
 
 func (m *Model) buildCells() []grid.Cell {
 
+	if len(m.config.Columns) > 0 {
+		return m.buildCellsColumns()
+	}
+
 	if !m.config.ExtraInfoFundamentals && !m.config.ShowPositions {
 
-		return []grid.Cell{
-			{Text: textName(m.config.Asset, m.config.Styles)},
+		cells := []grid.Cell{
+			{Text: textName(m.config.Asset, m.config.Styles, m.isSelected)},
 			{Text: textMarketState(m.config.Asset, m.config.Styles), Width: WidthMarketState, Align: grid.Right},
 			{Text: textQuote(m.config.Asset, m.config.Styles, m.priceStyle, m.priceNoChangeSegment, m.priceChangeSegment), Width: m.cellWidths.WidthQuote, Align: grid.Right},
 		}
 
+		if m.cellWidths.WidthSparkline > 0 {
+			cells = slices.Insert(cells, 1, m.buildCellSparkline(WidthName+WidthMarketState+m.cellWidths.WidthQuote+(3*WidthGutter)))
+		}
+
+		return cells
 	}
 
 	cellName := []grid.Cell{
-		{Text: textName(m.config.Asset, m.config.Styles), Width: WidthName},
+		{Text: textName(m.config.Asset, m.config.Styles, m.isSelected), Width: WidthName},
 		{Text: ""},
 		{Text: textMarketState(m.config.Asset, m.config.Styles), Width: WidthMarketState, Align: grid.Right},
 	}
@@ -285,6 +321,30 @@ func (m *Model) buildCells() []grid.Cell {
 			cells...,
 		)
 		widthMinTerm = widthHoldings
+
+		// Income is only shown when at least one asset in the watchlist has paid out dividends or distributions
+		if m.cellWidths.WidthPositionIncome > 0 {
+			widthIncome := widthMinTerm + m.cellWidths.WidthPositionIncome + (2 * WidthGutter) + WidthLabel
+
+			cells = append(
+				[]grid.Cell{
+					{
+						Text:            textPositionIncomeLabels(m.config.Asset, m.config.Styles),
+						Width:           WidthLabel,
+						Align:           grid.Right,
+						VisibleMinWidth: widthIncome,
+					},
+					{
+						Text:            textPositionIncome(m.config.Asset, m.config.Styles),
+						Width:           m.cellWidths.WidthPositionIncome,
+						Align:           grid.Right,
+						VisibleMinWidth: widthMinTerm + m.cellWidths.WidthPositionIncome + WidthGutter,
+					},
+				},
+				cells...,
+			)
+			widthMinTerm = widthIncome
+		}
 	}
 
 	if m.config.ExtraInfoFundamentals {
@@ -329,6 +389,12 @@ func (m *Model) buildCells() []grid.Cell {
 			},
 			cells...,
 		)
+		widthMinTerm += m.cellWidths.WidthQuoteExtended + (6 * WidthGutter) + (3 * WidthLabel) + m.cellWidths.WidthQuoteRange + m.cellWidths.WidthVolumeMarketCap
+	}
+
+	// The sparkline is shown alongside the quote and is the first cell hidden when the terminal is narrow
+	if m.cellWidths.WidthSparkline > 0 {
+		cells = slices.Insert(cells, len(cells)-1, m.buildCellSparkline(widthMinTerm))
 	}
 
 	cells = append(
@@ -340,13 +406,37 @@ func (m *Model) buildCells() []grid.Cell {
 
 }
 
-func textName(asset *c.Asset, styles c.Styles) string {
+// buildCellSparkline returns the sparkline cell which is visible when the terminal is wide enough for it and all other cells
+func (m *Model) buildCellSparkline(widthOtherCells int) grid.Cell {
+	return grid.Cell{
+		Text:            textSparkline(m.sparkline, m.cellWidths.WidthSparkline, m.config.Asset.QuotePrice.ChangePercent, m.config.Styles),
+		Width:           m.cellWidths.WidthSparkline,
+		Align:           grid.Right,
+		VisibleMinWidth: widthOtherCells + m.cellWidths.WidthSparkline + WidthGutter,
+	}
+}
+
+func textName(asset *c.Asset, styles c.Styles, isSelected bool) string {
 
 	if len(asset.Name) > 20 {
 		asset.Name = asset.Name[:20]
 	}
 
-	return styles.TextBold(asset.Symbol) +
+	source := ""
+
+	// Quotes from an alternative source in place of a failing source show which source is serving them next to the symbol
+	if asset.Meta.AlternativeSourceName != "" {
+		source = styles.TextLabel(" via " + asset.Meta.AlternativeSourceName)
+	}
+
+	// The selected row is highlighted by showing the symbol as a tag
+	if isSelected {
+		return styles.Tag(" "+asset.Symbol+" ") + source +
+			"\n" +
+			styles.TextLabel(asset.Name)
+	}
+
+	return styles.TextBold(asset.Symbol) + source +
 		"\n" +
 		styles.TextLabel(asset.Name)
 }
@@ -446,6 +536,28 @@ func textPositionExtendedLabels(asset *c.Asset, styles c.Styles) string {
 		styles.TextLabel("Quantity:")
 }
 
+func textPositionIncome(asset *c.Asset, styles c.Styles) string {
+
+	if asset.Position.Income == 0.0 {
+		return ""
+	}
+
+	return styles.Text(u.ConvertFloatToString(asset.Position.Income, asset.Meta.IsVariablePrecision)) +
+		"\n" +
+		quoteChangeText(asset.Position.TotalReturn.Amount, asset.Position.TotalReturn.Percent, asset.Meta.IsVariablePrecision, styles)
+}
+
+func textPositionIncomeLabels(asset *c.Asset, styles c.Styles) string {
+
+	if asset.Position.Income == 0.0 {
+		return ""
+	}
+
+	return styles.TextLabel("Income:") +
+		"\n" +
+		styles.TextLabel("Total Return:")
+}
+
 func textQuoteRange(asset *c.Asset, styles c.Styles) string {
 
 	if asset.Class == c.AssetClassFuturesContract {
@@ -524,6 +636,11 @@ func textVolumeMarketCapLabels(asset *c.Asset, styles c.Styles) string {
 }
 
 func textMarketState(asset *c.Asset, styles c.Styles) string {
+	// Stale quotes are marked in place of the market state since the market state may also be out of date
+	if asset.Meta.IsStale {
+		return styles.TextPrice(-1, " ⚠  ")
+	}
+
 	if asset.Exchange.IsRegularTradingSession {
 		return styles.TextLabel(" ●  ")
 	}
@@ -536,15 +653,70 @@ func textMarketState(asset *c.Asset, styles c.Styles) string {
 }
 
 func quoteChangeText(change float64, changePercent float64, isVariablePrecision bool, styles c.Styles) string {
+	return styles.TextPrice(changePercent, changeText(change, changePercent, isVariablePrecision))
+}
+
+func changeText(change float64, changePercent float64, isVariablePrecision bool) string {
 	if change == 0.0 {
-		return styles.TextPrice(changePercent, "  "+u.ConvertFloatToString(change, isVariablePrecision)+" ("+u.ConvertFloatToString(changePercent, false)+"%)")
+		return "  " + u.ConvertFloatToString(change, isVariablePrecision) + " (" + u.ConvertFloatToString(changePercent, false) + "%)"
 	}
 
 	if change > 0.0 {
-		return styles.TextPrice(changePercent, "↑ "+u.ConvertFloatToString(change, isVariablePrecision)+" ("+u.ConvertFloatToString(changePercent, false)+"%)")
+		return "↑ " + u.ConvertFloatToString(change, isVariablePrecision) + " (" + u.ConvertFloatToString(changePercent, false) + "%)"
+	}
+
+	return "↓ " + u.ConvertFloatToString(change, isVariablePrecision) + " (" + u.ConvertFloatToString(changePercent, false) + "%)"
+}
+
+// textSparkline renders prices as a chart two lines high with one column per price. When there are more prices than
+// columns, the last price in each group of prices is shown.
+func textSparkline(prices []float64, width int, changePercent float64, styles c.Styles) string {
+
+	if len(prices) < 2 || width <= 0 {
+		return "\n"
 	}
 
-	return styles.TextPrice(changePercent, "↓ "+u.ConvertFloatToString(change, isVariablePrecision)+" ("+u.ConvertFloatToString(changePercent, false)+"%)")
+	if len(prices) > width {
+		sampled := make([]float64, width)
+		for i := range sampled {
+			sampled[i] = prices[((i+1)*len(prices))/width-1]
+		}
+		prices = sampled
+	}
+
+	priceMin, priceMax := prices[0], prices[0]
+	for _, price := range prices {
+		priceMin = min(priceMin, price)
+		priceMax = max(priceMax, price)
+	}
+
+	blocks := []rune(sparklineBlocks)
+	levels := len(blocks) * 2
+
+	var lineTop, lineBottom strings.Builder
+
+	for _, price := range prices {
+
+		// Each price is shown with a height of at least one eighth of a line so that flat sections are visible
+		level := levels / 2
+		if priceMax > priceMin {
+			level = 1 + int((price-priceMin)/(priceMax-priceMin)*float64(levels-1)+0.5)
+		}
+
+		if level > len(blocks) {
+			lineTop.WriteRune(blocks[level-len(blocks)-1])
+			lineBottom.WriteRune(blocks[len(blocks)-1])
+
+			continue
+		}
+
+		lineTop.WriteRune(' ')
+		lineBottom.WriteRune(blocks[level-1])
+	}
+
+	return styles.TextPrice(changePercent, lineTop.String()) +
+		"\n" +
+		styles.TextPrice(changePercent, lineBottom.String())
 }
 
 func textSeparator(width int, styles c.Styles) string {
@@ -291,6 +291,207 @@ var _ = Describe("Row", func() {
 
 		})
 
+		Describe("SetSparklineMsg", func() {
+
+			var inputRow *row.Model
+
+			BeforeEach(func() {
+				inputRow = row.New(row.Config{
+					Styles: styles,
+					Asset: &c.Asset{
+						Symbol: "AAPL",
+						QuotePrice: c.QuotePrice{
+							Price: 150.00,
+						},
+					},
+				})
+				inputRow, _ = inputRow.Update(row.SetCellWidthsMsg{
+					Width: 100,
+					CellWidths: row.CellWidthsContainer{
+						WidthQuote:     20,
+						WidthSparkline: 4,
+					},
+				})
+			})
+
+			It("should render the prices as a chart two lines high", func() {
+				outputRow, cmd := inputRow.Update(row.SetSparklineMsg([]float64{100, 115, 107.5, 130}))
+				Expect(cmd).To(BeNil())
+
+				lines := strings.Split(outputRow.View(), "\n")
+				Expect(lines[0]).To(ContainSubstring(" ▁ █"))
+				Expect(lines[1]).To(ContainSubstring("▁█▅█"))
+			})
+
+			When("there are more prices than columns", func() {
+				It("should show the last price in each group of prices", func() {
+					outputRow, _ := inputRow.Update(row.SetSparklineMsg([]float64{100, 130, 100, 100, 100, 130, 100, 130}))
+
+					lines := strings.Split(outputRow.View(), "\n")
+					Expect(lines[0]).To(ContainSubstring("█ ██"))
+					Expect(lines[1]).To(ContainSubstring("█▁██"))
+				})
+			})
+
+			When("the terminal is too narrow for the sparkline", func() {
+				It("should not show the sparkline", func() {
+					outputRow, _ := inputRow.Update(row.SetSparklineMsg([]float64{100, 130}))
+					outputRow, _ = outputRow.Update(row.SetCellWidthsMsg{
+						Width: 30,
+						CellWidths: row.CellWidthsContainer{
+							WidthQuote:     20,
+							WidthSparkline: 4,
+						},
+					})
+
+					Expect(outputRow.View()).NotTo(ContainSubstring("█"))
+				})
+			})
+		})
+
+		Describe("Columns", func() {
+
+			var inputRow *row.Model
+
+			BeforeEach(func() {
+				asset := &c.Asset{
+					Symbol: "AAPL",
+					Name:   "Apple Inc.",
+					QuotePrice: c.QuotePrice{
+						Price:         150.00,
+						PriceDayLow:   148.00,
+						PriceDayHigh:  152.50,
+						ChangePercent: 1.25,
+					},
+				}
+				columns := []string{row.ColumnDayRange, row.ColumnChangePercent, row.ColumnPrice}
+
+				inputRow = row.New(row.Config{
+					Styles:  styles,
+					Columns: columns,
+					Asset:   asset,
+				})
+
+				cellWidths := row.CellWidthsContainer{WidthColumns: map[string]int{}}
+				for _, column := range columns {
+					cellWidths.WidthColumns[column] = row.GetColumnWidth(column, asset)
+				}
+
+				inputRow, _ = inputRow.Update(row.SetCellWidthsMsg{Width: 80, CellWidths: cellWidths})
+			})
+
+			It("should show the value and label of each column in order", func() {
+				lines := strings.Split(inputRow.View(), "\n")
+
+				Expect(lines[0]).To(MatchRegexp(`^AAPL\s+148\.00 - 152\.50    1\.25% 150\.00$`))
+				Expect(lines[1]).To(MatchRegexp(`^Apple Inc\.\s+Day Range Change %  Price$`))
+			})
+
+			When("the terminal is too narrow for all columns", func() {
+				It("should hide the columns at the end first", func() {
+					outputRow, _ := inputRow.Update(row.SetCellWidthsMsg{
+						Width: 50,
+						CellWidths: row.CellWidthsContainer{
+							WidthColumns: map[string]int{row.ColumnDayRange: 15, row.ColumnChangePercent: 8, row.ColumnPrice: 6},
+						},
+					})
+
+					Expect(outputRow.View()).To(ContainSubstring("148.00 - 152.50"))
+					Expect(outputRow.View()).NotTo(ContainSubstring("150.00"))
+				})
+			})
+
+		})
+
+		Describe("Staleness", func() {
+
+			It("should mark the row when the quote is stale in place of the market state", func() {
+				inputAsset := &c.Asset{
+					Symbol: "AAPL",
+					QuotePrice: c.QuotePrice{
+						Price: 150.00,
+					},
+					Exchange: c.Exchange{
+						IsActive:                true,
+						IsRegularTradingSession: true,
+					},
+				}
+				inputRow := row.New(row.Config{
+					Styles: styles,
+					Asset:  inputAsset,
+				})
+				inputRow, _ = inputRow.Update(row.SetCellWidthsMsg{Width: 100})
+
+				Expect(inputRow.View()).To(ContainSubstring("●"))
+				Expect(inputRow.View()).NotTo(ContainSubstring("⚠"))
+
+				staleAsset := *inputAsset
+				staleAsset.Meta.IsStale = true
+				outputRow, _ := inputRow.Update(row.UpdateAssetMsg(&staleAsset))
+
+				Expect(outputRow.View()).To(ContainSubstring("⚠"))
+				Expect(outputRow.View()).NotTo(ContainSubstring("●"))
+			})
+
+		})
+
+		Describe("Alternative source", func() {
+
+			It("should show the source serving the quote next to the symbol when it is from an alternative source", func() {
+				inputAsset := &c.Asset{
+					Symbol: "BTC.X",
+					Name:   "Bitcoin",
+					QuotePrice: c.QuotePrice{
+						Price: 50000.00,
+					},
+				}
+				inputRow := row.New(row.Config{
+					Styles: styles,
+					Asset:  inputAsset,
+				})
+				inputRow, _ = inputRow.Update(row.SetCellWidthsMsg{Width: 100})
+
+				Expect(inputRow.View()).To(ContainSubstring("Bitcoin"))
+				Expect(inputRow.View()).NotTo(ContainSubstring("via"))
+
+				alternativeAsset := *inputAsset
+				alternativeAsset.Meta.AlternativeSourceName = "CoinGecko"
+				outputRow, _ := inputRow.Update(row.UpdateAssetMsg(&alternativeAsset))
+
+				Expect(outputRow.View()).To(ContainSubstring("BTC.X via CoinGecko"))
+				Expect(outputRow.View()).To(ContainSubstring("Bitcoin"))
+			})
+
+		})
+
+		Describe("SetSelectedMsg", func() {
+
+			It("should highlight the symbol when the row is selected", func() {
+				inputRow := row.New(row.Config{
+					Styles: styles,
+					Asset: &c.Asset{
+						Symbol: "AAPL",
+						QuotePrice: c.QuotePrice{
+							Price: 150.00,
+						},
+					},
+				})
+				inputRow, _ = inputRow.Update(row.SetCellWidthsMsg{Width: 100})
+
+				Expect(inputRow.View()).To(HavePrefix("AAPL "))
+
+				outputRow, cmd := inputRow.Update(row.SetSelectedMsg(true))
+
+				Expect(cmd).To(BeNil())
+				Expect(outputRow.View()).To(HavePrefix(" AAPL "))
+
+				outputRow, _ = outputRow.Update(row.SetSelectedMsg(false))
+
+				Expect(outputRow.View()).To(HavePrefix("AAPL "))
+			})
+
+		})
+
 	})
 
 })
@@ -3,6 +3,7 @@ package watchlist
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	c "github.com/achannarasappa/ticker/v5/internal/common"
 	s "github.com/achannarasappa/ticker/v5/internal/sorter"
@@ -18,6 +19,8 @@ type Config struct {
 	ShowPositions         bool
 	ExtraInfoExchange     bool
 	ExtraInfoFundamentals bool
+	ShowSparkline         bool
+	Columns               []string
 	Sort                  string
 	Styles                c.Styles
 }
@@ -32,8 +35,26 @@ type Model struct {
 	cellWidths     row.CellWidthsContainer
 	rows           []*row.Model
 	rowsBySymbol   map[string]*row.Model
+	cursor         int
+	selectedSymbol string
+	intradayPrices map[string][]c.PricePoint
+	intradayQuotes map[string]intradayQuote // Latest quote added to the intraday prices of each symbol
+	sparklineRows  []string                 // Symbol of the intraday prices set on the sparkline of each row
 }
 
+// intradayQuote identifies a quote by its price and when it was received so that it is only added to intraday prices once
+type intradayQuote struct {
+	price       float64
+	lastUpdated time.Time
+}
+
+const (
+	// sparklineInterval is the length of time covered by each price in a sparkline
+	sparklineInterval = 5 * time.Minute
+	// sparklineMaxPrices is the number of prices kept for a sparkline which covers one day
+	sparklineMaxPrices = int(24 * time.Hour / sparklineInterval)
+)
+
 // Messages for replacing assets
 type SetAssetsMsg []c.Asset
 
@@ -43,6 +64,12 @@ type UpdateAssetsMsg []c.Asset
 // Messages for changing sort
 type ChangeSortMsg string
 
+// Messages for replacing the intraday prices shown in sparklines keyed by symbol
+type SetIntradayPricesMsg map[string][]c.PricePoint
+
+// Messages for moving the cursor by a number of rows (negative moves up). The first move shows the cursor on the first row.
+type MoveCursorMsg int
+
 // NewModel returns a model with default values
 func NewModel(config Config) *Model {
 	return &Model{
@@ -52,6 +79,8 @@ func NewModel(config Config) *Model {
 		assetsBySymbol: make(map[string]*c.Asset),
 		sorter:         s.NewSorter(config.Sort),
 		rowsBySymbol:   make(map[string]*row.Model),
+		intradayPrices: make(map[string][]c.PricePoint),
+		intradayQuotes: make(map[string]intradayQuote),
 	}
 }
 
@@ -90,6 +119,7 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 					ExtraInfoExchange:     m.config.ExtraInfoExchange,
 					ExtraInfoFundamentals: m.config.ExtraInfoFundamentals,
 					ShowPositions:         m.config.ShowPositions,
+					Columns:               m.config.Columns,
 					Styles:                m.config.Styles,
 					Asset:                 asset,
 				}))
@@ -103,9 +133,14 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 
 		m.assets = assets
 		m.assetsBySymbol = assetsBySymbol
+		m.updateCursor()
+
+		if m.config.ShowSparkline {
+			m.updateSparklines(m.addIntradayPrices(time.Now()))
+		}
 
 		// TODO: only set conditionally if all assets have changed
-		m.cellWidths = getCellWidths(m.assets)
+		m.cellWidths = getCellWidths(m.assets, m.config.ShowSparkline, m.config.Columns)
 		for i, r := range m.rows {
 			m.rows[i], _ = r.Update(row.SetCellWidthsMsg{
 				Width:      m.width,
@@ -115,10 +150,22 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 
 		return m, tea.Batch(cmds...)
 
+	case SetIntradayPricesMsg:
+
+		for symbol, prices := range msg {
+			m.intradayPrices[symbol] = prices
+			// The latest quote is added again on the next update since it is not in the replaced prices
+			delete(m.intradayQuotes, symbol)
+		}
+
+		m.updateSparklines(nil)
+
+		return m, nil
+
 	case tea.WindowSizeMsg:
 
 		m.width = msg.Width
-		m.cellWidths = getCellWidths(m.assets)
+		m.cellWidths = getCellWidths(m.assets, m.config.ShowSparkline, m.config.Columns)
 		for i, r := range m.rows {
 			m.rows[i], _ = r.Update(row.SetCellWidthsMsg{
 				Width:      m.width,
@@ -160,8 +207,27 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 			cmds = append(cmds, cmd)
 		}
 
+		m.updateCursor()
+		m.updateSparklines(map[string]bool{})
+
 		return m, tea.Batch(cmds...)
 
+	case MoveCursorMsg:
+
+		if len(m.assets) == 0 {
+			return m, nil
+		}
+
+		if m.selectedSymbol != "" {
+			m.cursor += int(msg)
+		}
+
+		m.cursor = max(0, min(m.cursor, len(m.assets)-1))
+		m.selectedSymbol = m.assets[m.cursor].Symbol
+		m.updateCursor()
+
+		return m, nil
+
 	}
 
 	return m, nil
@@ -182,11 +248,162 @@ func (m *Model) View() string {
 	return strings.Join(rows, "\n")
 
 }
-func getCellWidths(assets []*c.Asset) row.CellWidthsContainer {
 
-	cellMaxWidths := row.CellWidthsContainer{}
+// SelectedAsset returns the asset in the row under the cursor
+func (m *Model) SelectedAsset() (c.Asset, bool) {
+
+	if m.selectedSymbol == "" || m.cursor >= len(m.assets) {
+		return c.Asset{}, false
+	}
+
+	return *m.assets[m.cursor], true
+}
+
+// SelectedLines returns the first and last (exclusive) lines of the view occupied by the row under the cursor
+func (m *Model) SelectedLines() (int, int) {
+
+	var rowStart int
+
+	for i, r := range m.rows {
+		rowEnd := rowStart + strings.Count(r.View(), "\n") + 1
+
+		if i == m.cursor {
+			return rowStart, rowEnd
+		}
+
+		rowStart = rowEnd
+	}
+
+	return 0, 0
+}
+
+// updateCursor keeps the cursor on the selected symbol after assets are re-sorted or replaced and
+// otherwise keeps the cursor at the same position if the selected symbol was removed
+func (m *Model) updateCursor() {
+
+	if m.selectedSymbol == "" {
+		return
+	}
+
+	for i, asset := range m.assets {
+		if asset.Symbol == m.selectedSymbol {
+			m.cursor = i
+
+			break
+		}
+	}
+
+	if len(m.assets) == 0 {
+		m.cursor = 0
+		m.selectedSymbol = ""
+
+		return
+	}
+
+	m.cursor = min(m.cursor, len(m.assets)-1)
+	m.selectedSymbol = m.assets[m.cursor].Symbol
+
+	for i, r := range m.rows {
+		m.rows[i], _ = r.Update(row.SetSelectedMsg(i == m.cursor))
+	}
+}
+
+// addIntradayPrices adds the latest price of each asset to its intraday prices so that sparklines move with live
+// prices and assets from sources without intraday prices accumulate them over time. The latest price replaces the
+// last intraday price until the interval it covers has passed. Assets are set on every refresh so only assets with a
+// new price or quote are added and their symbols are returned.
+func (m *Model) addIntradayPrices(now time.Time) map[string]bool {
+
+	symbolsUpdated := make(map[string]bool)
+
+	for _, asset := range m.assets {
+		if asset.QuotePrice.Price == 0 {
+			continue
+		}
+
+		quote := intradayQuote{price: asset.QuotePrice.Price, lastUpdated: asset.Meta.LastUpdated}
+
+		if quoteAdded, exists := m.intradayQuotes[asset.Symbol]; exists && quoteAdded == quote {
+			continue
+		}
+
+		prices := m.intradayPrices[asset.Symbol]
+
+		if len(prices) > 0 && now.Unix()-prices[len(prices)-1].Time < int64(sparklineInterval.Seconds()) {
+			prices[len(prices)-1].Price = asset.QuotePrice.Price
+		} else {
+			prices = append(prices, c.PricePoint{Time: now.Unix(), Price: asset.QuotePrice.Price})
+		}
+
+		if len(prices) > sparklineMaxPrices {
+			prices = prices[len(prices)-sparklineMaxPrices:]
+		}
+
+		m.intradayPrices[asset.Symbol] = prices
+		m.intradayQuotes[asset.Symbol] = quote
+		symbolsUpdated[asset.Symbol] = true
+	}
+
+	// Prices for symbols no longer in the watchlist are dropped
+	for symbol := range m.intradayPrices {
+		if _, exists := m.assetsBySymbol[symbol]; !exists {
+			delete(m.intradayPrices, symbol)
+			delete(m.intradayQuotes, symbol)
+		}
+	}
+
+	return symbolsUpdated
+}
+
+// updateSparklines sets the intraday prices for the asset in each row which shows a different asset than before or an
+// asset in symbolsUpdated. All rows are updated if symbolsUpdated is nil.
+func (m *Model) updateSparklines(symbolsUpdated map[string]bool) {
+
+	m.sparklineRows = m.sparklineRows[:min(len(m.sparklineRows), len(m.rows))]
+
+	for i, r := range m.rows {
+		if i >= len(m.assets) {
+			break
+		}
+
+		symbol := m.assets[i].Symbol
+
+		if symbolsUpdated != nil && !symbolsUpdated[symbol] && i < len(m.sparklineRows) && m.sparklineRows[i] == symbol {
+			continue
+		}
+
+		if i < len(m.sparklineRows) {
+			m.sparklineRows[i] = symbol
+		} else {
+			m.sparklineRows = append(m.sparklineRows, symbol)
+		}
+
+		prices := m.intradayPrices[symbol]
+		sparkline := make([]float64, len(prices))
+
+		for j, price := range prices {
+			sparkline[j] = price.Price
+		}
+
+		m.rows[i], _ = r.Update(row.SetSparklineMsg(sparkline))
+	}
+}
+
+func getCellWidths(assets []*c.Asset, showSparkline bool, columns []string) row.CellWidthsContainer {
+
+	cellMaxWidths := row.CellWidthsContainer{
+		WidthColumns: make(map[string]int, len(columns)),
+	}
+
+	if showSparkline {
+		cellMaxWidths.WidthSparkline = row.WidthSparkline
+	}
 
 	for _, asset := range assets {
+		for _, column := range columns {
+			cellMaxWidths.WidthColumns[column] = max(cellMaxWidths.WidthColumns[column], row.GetColumnWidth(column, asset))
+		}
+
 		var quoteLength int
 
 		volumeMarketCapLength := len(u.ConvertFloatToString(asset.QuoteExtended.MarketCap, true))
@@ -232,6 +449,13 @@ func getCellWidths(assets []*c.Asset) row.CellWidthsContainer {
 				cellMaxWidths.WidthPositionExtended = positionUnitCostLength
 			}
 
+			if asset.Position.Income != 0.0 {
+				positionIncomeLength := len(u.ConvertFloatToString(asset.Position.Income, asset.Meta.IsVariablePrecision))
+				positionTotalReturnLength := len(u.ConvertFloatToString(asset.Position.TotalReturn.Amount, asset.Meta.IsVariablePrecision)) + row.WidthChangeStatic
+
+				cellMaxWidths.WidthPositionIncome = max(cellMaxWidths.WidthPositionIncome, positionIncomeLength, positionTotalReturnLength)
+			}
+
 		}
 
 	}
@@ -3,6 +3,7 @@ package watchlist_test
 import (
 	"io/ioutil"
 	"strings"
+	"time"
 
 	"github.com/acarl005/stripansi"
 	. "github.com/onsi/ginkgo/v2"
@@ -612,10 +613,80 @@ var _ = Describe("Watchlist", func() {
 				m.Update(SetAssetsMsg(setAssetsMsg))
 				Expect(removeFormatting(m.View())).ToNot(ContainSubstring("Quantity"))
 				Expect(removeFormatting(m.View())).ToNot(ContainSubstring("Avg. Cost"))
+				Expect(removeFormatting(m.View())).ToNot(ContainSubstring("Income"))
+			})
+		})
+
+		When("there is income from the position", func() {
+			It("should render the income and total return", func() {
+				m := NewModel(Config{
+					Styles:        stylesFixture,
+					ShowPositions: true,
+				})
+				m.Update(tea.WindowSizeMsg{Width: 160})
+				setAssetsMsg := []c.Asset{
+					{
+						Symbol: "PTON",
+						Name:   "Peloton",
+						QuotePrice: c.QuotePrice{
+							Price:         100.0,
+							Change:        10.0,
+							ChangePercent: 10.0,
+						},
+						Position: c.Position{
+							Quantity:    100.0,
+							Cost:        50.0,
+							Value:       105.0,
+							DayChange:   c.PositionChange{Amount: 5.0, Percent: 5.0},
+							TotalChange: c.PositionChange{Amount: 55.0, Percent: 110.0},
+							Income:      12.5,
+							TotalReturn: c.PositionChange{Amount: 67.5, Percent: 135.0},
+						},
+						Exchange: c.Exchange{
+							IsActive:                true,
+							IsRegularTradingSession: true,
+						},
+					},
+				}
+				m.Update(SetAssetsMsg(setAssetsMsg))
+				Expect(removeFormatting(m.View())).To(ContainSubstring("Income:"))
+				Expect(removeFormatting(m.View())).To(ContainSubstring("12.50"))
+				Expect(removeFormatting(m.View())).To(ContainSubstring("Total Return:"))
+				Expect(removeFormatting(m.View())).To(ContainSubstring("↑ 67.50 (135.00%)"))
 			})
 		})
 	})
 
+	When("columns are set", func() {
+		It("should render only the columns in the order they are set", func() {
+			m := NewModel(Config{
+				Styles:        stylesFixture,
+				ShowPositions: true,
+				Columns:       []string{"value", "price", "change-percent"},
+			})
+			m.Update(tea.WindowSizeMsg{Width: 120})
+			m.Update(SetAssetsMsg([]c.Asset{
+				{
+					Symbol:     "PTON",
+					Name:       "Peloton",
+					QuotePrice: c.QuotePrice{Price: 100.0, Change: 10.0, ChangePercent: 10.0},
+					Position:   c.Position{Quantity: 100.0, UnitCost: 50.0, Value: 10000.0},
+				},
+				{
+					Symbol:     "ARKW",
+					Name:       "ARK Web",
+					QuotePrice: c.QuotePrice{Price: 5.0, Change: -0.25, ChangePercent: -4.76},
+				},
+			}))
+
+			view := removeFormatting(m.View())
+			Expect(view).NotTo(ContainSubstring("Quantity"))
+			Expect(getLine(view, 0)).To(MatchRegexp(`^PTON\s+10000\.00 100\.00   10\.00%$`))
+			Expect(getLine(view, 1)).To(MatchRegexp(`^Peloton\s+Value  Price Change %$`))
+			Expect(getLine(view, 2)).To(MatchRegexp(`^ARKW\s+5\.00   -4\.76%$`))
+		})
+	})
+
 	When("no quotes are set", func() {
 		It("should render an empty watchlist", func() {
 			m := NewModel(Config{
@@ -658,6 +729,165 @@ var _ = Describe("Watchlist", func() {
 		})
 	})
 
+	Describe("MoveCursorMsg", func() {
+
+		var m *Model
+
+		BeforeEach(func() {
+			m = NewModel(Config{
+				Styles:   stylesFixture,
+				Separate: true,
+				Sort:     "alpha",
+			})
+			m, _ = m.Update(tea.WindowSizeMsg{Width: 100})
+			m, _ = m.Update(SetAssetsMsg([]c.Asset{
+				{Symbol: "GOOG", Name: "Google Inc.", QuotePrice: c.QuotePrice{Price: 2523.53}, Meta: c.Meta{OrderIndex: 0}},
+				{Symbol: "AAPL", Name: "Apple Inc.", QuotePrice: c.QuotePrice{Price: 150.00}, Meta: c.Meta{OrderIndex: 1}},
+				{Symbol: "MSFT", Name: "Microsoft Corporation", QuotePrice: c.QuotePrice{Price: 300.00}, Meta: c.Meta{OrderIndex: 2}},
+			}))
+		})
+
+		When("the cursor has not been moved", func() {
+			It("should not select or highlight a row", func() {
+				_, ok := m.SelectedAsset()
+				Expect(ok).To(BeFalse())
+				Expect(removeFormatting(m.View())).ToNot(ContainSubstring(" AAPL "))
+			})
+		})
+
+		It("should select the first row on the first move and then move row by row", func() {
+			m, _ = m.Update(MoveCursorMsg(1))
+			outputAsset, ok := m.SelectedAsset()
+			Expect(ok).To(BeTrue())
+			Expect(outputAsset.Symbol).To(Equal("AAPL"))
+			Expect(removeFormatting(m.View())).To(ContainSubstring(" AAPL "))
+
+			m, _ = m.Update(MoveCursorMsg(1))
+			outputAsset, _ = m.SelectedAsset()
+			Expect(outputAsset.Symbol).To(Equal("GOOG"))
+			Expect(removeFormatting(m.View())).To(ContainSubstring(" GOOG "))
+			Expect(removeFormatting(m.View())).ToNot(ContainSubstring(" AAPL "))
+		})
+
+		It("should not move past the first or last row", func() {
+			m, _ = m.Update(MoveCursorMsg(0))
+			m, _ = m.Update(MoveCursorMsg(-1))
+			outputAsset, _ := m.SelectedAsset()
+			Expect(outputAsset.Symbol).To(Equal("AAPL"))
+
+			m, _ = m.Update(MoveCursorMsg(10))
+			outputAsset, _ = m.SelectedAsset()
+			Expect(outputAsset.Symbol).To(Equal("MSFT"))
+		})
+
+		It("should keep the cursor on the same symbol when the assets are re-sorted", func() {
+			m, _ = m.Update(MoveCursorMsg(0))
+			m, _ = m.Update(MoveCursorMsg(1))
+			m, _ = m.Update(ChangeSortMsg("user"))
+
+			outputAsset, _ := m.SelectedAsset()
+			Expect(outputAsset.Symbol).To(Equal("GOOG"))
+
+			start, _ := m.SelectedLines()
+			Expect(start).To(Equal(0))
+			Expect(removeFormatting(m.View())).To(HavePrefix(" GOOG "))
+		})
+
+		It("should keep the cursor on the same symbol when the assets are updated", func() {
+			m, _ = m.Update(MoveCursorMsg(0))
+			m, _ = m.Update(SetAssetsMsg([]c.Asset{
+				{Symbol: "AAPL", Name: "Apple Inc.", QuotePrice: c.QuotePrice{Price: 155.00}},
+				{Symbol: "AMZN", Name: "Amazon.com, Inc.", QuotePrice: c.QuotePrice{Price: 180.00}},
+			}))
+
+			outputAsset, _ := m.SelectedAsset()
+			Expect(outputAsset.Symbol).To(Equal("AAPL"))
+			Expect(outputAsset.QuotePrice.Price).To(Equal(155.00))
+
+			start, end := m.SelectedLines()
+			Expect(start).To(Equal(strings.Count(removeFormatting(m.View())[:strings.Index(removeFormatting(m.View()), " AAPL ")], "\n")))
+			Expect(end).To(BeNumerically(">", start))
+		})
+
+		When("the selected symbol is removed", func() {
+			It("should keep the cursor at the same position", func() {
+				m, _ = m.Update(MoveCursorMsg(0))
+				m, _ = m.Update(MoveCursorMsg(2))
+				m, _ = m.Update(SetAssetsMsg([]c.Asset{
+					{Symbol: "GOOG", Name: "Google Inc.", QuotePrice: c.QuotePrice{Price: 2523.53}},
+					{Symbol: "AAPL", Name: "Apple Inc.", QuotePrice: c.QuotePrice{Price: 150.00}},
+				}))
+
+				outputAsset, _ := m.SelectedAsset()
+				Expect(outputAsset.Symbol).To(Equal("GOOG"))
+			})
+		})
+	})
+
+	Describe("SetIntradayPricesMsg", func() {
+
+		var m *Model
+
+		BeforeEach(func() {
+			m = NewModel(Config{
+				Styles:        stylesFixture,
+				ShowSparkline: true,
+				Sort:          "alpha",
+			})
+			m, _ = m.Update(tea.WindowSizeMsg{Width: 100})
+			m, _ = m.Update(SetAssetsMsg([]c.Asset{
+				{Symbol: "AAPL", Name: "Apple Inc.", QuotePrice: c.QuotePrice{Price: 130.00}},
+			}))
+		})
+
+		When("there are no intraday prices", func() {
+			It("should not show a sparkline", func() {
+				m, _ = m.Update(SetAssetsMsg([]c.Asset{
+					{Symbol: "AAPL", Name: "Apple Inc.", QuotePrice: c.QuotePrice{Price: 100.00}},
+				}))
+
+				Expect(removeFormatting(m.View())).NotTo(ContainSubstring("▁"))
+			})
+		})
+
+		It("should show a sparkline with the intraday prices followed by the latest price", func() {
+			now := time.Now().Unix()
+
+			m, _ = m.Update(SetIntradayPricesMsg{
+				"AAPL": {
+					{Time: now - 900, Price: 100.00},
+					{Time: now - 600, Price: 130.00},
+				},
+			})
+			m, _ = m.Update(SetAssetsMsg([]c.Asset{
+				{Symbol: "AAPL", Name: "Apple Inc.", QuotePrice: c.QuotePrice{Price: 100.00}},
+			}))
+
+			Expect(getLine(removeFormatting(m.View()), 0)).To(ContainSubstring(" █ "))
+			Expect(getLine(removeFormatting(m.View()), 1)).To(ContainSubstring("▁█▁"))
+		})
+
+		When("the latest price is within the interval of the last intraday price", func() {
+			It("should replace the last intraday price", func() {
+				now := time.Now().Unix()
+
+				m, _ = m.Update(SetIntradayPricesMsg{
+					"AAPL": {
+						{Time: now - 300, Price: 100.00},
+						{Time: now - 60, Price: 130.00},
+					},
+				})
+				m, _ = m.Update(SetAssetsMsg([]c.Asset{
+					{Symbol: "AAPL", Name: "Apple Inc.", QuotePrice: c.QuotePrice{Price: 120.00}},
+				}))
+
+				Expect(getLine(removeFormatting(m.View()), 0)).To(ContainSubstring(" █"))
+				Expect(getLine(removeFormatting(m.View()), 1)).To(ContainSubstring("▁█"))
+				Expect(getLine(removeFormatting(m.View()), 1)).NotTo(ContainSubstring("▁█▁"))
+			})
+		})
+	})
+
 	Describe("ChangeSortMsg", func() {
 		It("should update the sort order when ChangeSortMsg is received", func() {
 			m := NewModel(Config{
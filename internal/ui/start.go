@@ -7,34 +7,51 @@ import (
 )
 
 // Start launches the command line interface and starts capturing input
-func Start(dep *c.Dependencies, ctx *c.Context, version string) func() error {
+func Start(dep *c.Dependencies, ctx *c.Context, configPath *string, version string) func() error {
 	return func() error {
 
-		monitors, _ := mon.NewMonitor(mon.ConfigMonitor{
+		monitors, err := mon.NewMonitor(mon.ConfigMonitor{
 			RefreshInterval: ctx.Config.RefreshInterval,
 			TargetCurrency:  ctx.Config.Currency,
 			Logger:          ctx.Logger,
 			Cache:           ctx.Cache,
+			Alerts:          ctx.Config.Alerts,
+			Notifiers:       ctx.Config.Notifiers,
+			LotMatching:     ctx.Config.LotMatching,
 			ConfigMonitorsYahoo: mon.ConfigMonitorsYahoo{
 				BaseURL:           dep.MonitorYahooBaseURL,
 				SessionRootURL:    dep.MonitorYahooSessionRootURL,
 				SessionCrumbURL:   dep.MonitorYahooSessionCrumbURL,
 				SessionConsentURL: dep.MonitorYahooSessionConsentURL,
+				StreamingURL:      dep.MonitorYahooStreamingURL,
 			},
 			ConfigMonitorPriceCoinbase: mon.ConfigMonitorPriceCoinbase{
 				BaseURL:      dep.MonitorPriceCoinbaseBaseURL,
 				StreamingURL: dep.MonitorPriceCoinbaseStreamingURL,
 			},
+			ConfigMonitorPriceCoingecko: mon.ConfigMonitorPriceCoingecko{
+				BaseURL: dep.MonitorPriceCoingeckoBaseURL,
+			},
+			ConfigMonitorPriceCoinCap: mon.ConfigMonitorPriceCoinCap{
+				BaseURL:      dep.MonitorPriceCoinCapBaseURL,
+				StreamingURL: dep.MonitorPriceCoinCapStreamingURL,
+				APIKey:       ctx.Config.CoinCapAPIKey,
+			},
+			ConfigMonitorPriceUserDefined: mon.ConfigMonitorPriceUserDefined{
+				Prices: ctx.Config.UserDefinedPrices,
+			},
 		})
 
+		if err != nil {
+			return err
+		}
+
 		p := tea.NewProgram(
-			NewModel(*dep, *ctx, monitors, version),
+			NewModel(*dep, *ctx, monitors, *configPath, version),
 			tea.WithMouseCellMotion(),
 			tea.WithAltScreen(),
 		)
 
-		var err error
-
 		err = monitors.SetOnUpdate(mon.ConfigUpdateFns{
 			OnUpdateAssetQuote: func(symbol string, assetQuote c.AssetQuote, versionVector int) {
 				p.Send(SetAssetQuoteMsg{
@@ -49,6 +66,11 @@ func Start(dep *c.Dependencies, ctx *c.Context, version string) func() error {
 					versionVector:   versionVector,
 				})
 			},
+			OnAlert: func(alert c.Alert) {
+				p.Send(SetAlertMsg{
+					alert: alert,
+				})
+			},
 		})
 
 		if err != nil {
@@ -1,14 +1,24 @@
 package ui
 
 import (
+	"bytes"
 	"fmt"
+	"math"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
 	grid "github.com/achannarasappa/term-grid"
 	"github.com/achannarasappa/ticker/v5/internal/asset"
+	"github.com/achannarasappa/ticker/v5/internal/calendar"
 	c "github.com/achannarasappa/ticker/v5/internal/common"
+	"github.com/achannarasappa/ticker/v5/internal/configfile"
 	mon "github.com/achannarasappa/ticker/v5/internal/monitor"
+	"github.com/achannarasappa/ticker/v5/internal/ui/component/alerts"
+	"github.com/achannarasappa/ticker/v5/internal/ui/component/chart"
+	"github.com/achannarasappa/ticker/v5/internal/ui/component/detail"
+	"github.com/achannarasappa/ticker/v5/internal/ui/component/search"
 	"github.com/achannarasappa/ticker/v5/internal/ui/component/summary"
 	"github.com/achannarasappa/ticker/v5/internal/ui/component/watchlist"
 	"github.com/achannarasappa/ticker/v5/internal/ui/component/watchlist/row"
@@ -18,6 +28,7 @@ import (
 
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/afero"
 )
 
@@ -26,10 +37,16 @@ var (
 	styleLogo  = util.NewStyle("#ffffd7", "#ff8700", true)
 	styleGroup = util.NewStyle("#8a8a8a", "#303030", false)
 	styleHelp  = util.NewStyle("#4e4e4e", "", true)
+	styleAlert = util.NewStyle("#ff8700", "", true)
+	styleOK    = util.NewStyle("#779929", "", true)
 )
 
 const (
 	footerHeight = 1
+	// alertHistoryMaxLength is the number of most recent alerts kept in the alert history
+	alertHistoryMaxLength = 100
+	// alertFooterDuration is how long the most recent alert is shown in the footer
+	alertFooterDuration = time.Minute
 )
 
 // Model for UI
@@ -46,7 +63,23 @@ type Model struct {
 	viewport           viewport.Model
 	watchlist          *watchlist.Model
 	summary            *summary.Model
+	search             *search.Model
+	isSearchOpen       bool
+	detail             *detail.Model
+	detailSymbol       string
+	chart              *chart.Model
+	chartSymbol        string
+	alerts             *alerts.Model
+	alertHistory       []c.Alert
+	isAlertsOpen       bool
+	alertFooterText    string
+	alertFooterExpiry  time.Time
+	intradayVersion    int
+	listYOffset        int
 	lastUpdateTime     string
+	marketCountdown    string
+	sourceStatusText   string
+	sourceHealthText   string
 	groupSelectedIndex int
 	groupMaxIndex      int
 	groupSelectedName  string
@@ -57,6 +90,7 @@ type Model struct {
 	latestVersion      string
 	releasesURL        string
 	fs                 afero.Fs
+	configPath         string
 }
 
 type tickMsg struct {
@@ -73,13 +107,26 @@ type SetAssetQuoteMsg struct {
 	versionVector int
 }
 
+type SetAlertMsg struct {
+	alert c.Alert
+}
+
+type intradayTickMsg struct {
+	versionVector int
+}
+
+type setIntradayPricesMsg struct {
+	pricesBySymbol map[string][]c.PricePoint
+	versionVector  int
+}
+
 type SetAssetGroupQuoteMsg struct {
 	assetGroupQuote c.AssetGroupQuote
 	versionVector   int
 }
 
 // NewModel is the constructor for UI model
-func NewModel(dep c.Dependencies, ctx c.Context, monitors *mon.Monitor, version string) *Model {
+func NewModel(dep c.Dependencies, ctx c.Context, monitors *mon.Monitor, configPath string, version string) *Model {
 
 	groupMaxIndex := len(ctx.Groups) - 1
 
@@ -99,17 +146,29 @@ func NewModel(dep c.Dependencies, ctx c.Context, monitors *mon.Monitor, version
 			ShowPositions:         ctx.Config.ShowPositions,
 			ExtraInfoExchange:     ctx.Config.ExtraInfoExchange,
 			ExtraInfoFundamentals: ctx.Config.ExtraInfoFundamentals,
+			ShowSparkline:         ctx.Config.ShowSparkline,
+			Columns:               ctx.Config.Columns,
 			Styles:                ctx.Reference.Styles,
 		}),
 		summary:            summary.NewModel(ctx),
+		detail:             detail.NewModel(ctx),
+		chart:              chart.NewModel(ctx),
+		alerts:             alerts.NewModel(ctx),
+		alertHistory:       make([]c.Alert, 0),
 		groupMaxIndex:      groupMaxIndex,
 		groupSelectedIndex: 0,
 		groupSelectedName:  "       ",
 		currentSort:        ctx.Config.Sort,
+		intradayVersion:    -1,
 		monitors:           monitors,
 		version:            version,
 		releasesURL:        dep.GitHubReleasesURL,
 		fs:                 dep.Fs,
+		configPath:         configPath,
+		search: search.NewModel(search.Config{
+			Search: monitors.SearchSymbols,
+			Styles: ctx.Reference.Styles,
+		}),
 	}
 }
 
@@ -143,6 +202,83 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:maintidx
 	switch msg := msg.(type) {
 
 	case tea.KeyMsg:
+		// All keys other than ctrl+c are handled by the search overlay while it is open
+		if m.isSearchOpen && msg.String() != "ctrl+c" {
+			m.search, cmd = m.search.Update(msg)
+
+			return m, cmd
+		}
+
+		// The chart screen only handles changing the range and is closed with esc
+		if m.chartSymbol != "" && msg.String() != "ctrl+c" {
+			switch msg.String() {
+			case "esc":
+				m.mu.Lock()
+				m.chartSymbol = ""
+				m.viewport.YOffset = m.listYOffset
+				m.mu.Unlock()
+
+				return m, nil
+			case "left", "right":
+				m.mu.Lock()
+				defer m.mu.Unlock()
+
+				rangeChange := 1
+				if msg.String() == "left" {
+					rangeChange = -1
+				}
+
+				rangePrevious := m.chart.Range()
+				m.chart, _ = m.chart.Update(chart.ChangeRangeMsg(rangeChange))
+
+				if m.chart.Range() == rangePrevious {
+					return m, nil
+				}
+
+				return m, m.getPriceHistory()
+			default:
+				return m, nil
+			}
+		}
+
+		// The alert history screen only handles scrolling and is closed with esc
+		if m.isAlertsOpen && msg.String() != "ctrl+c" {
+			switch msg.String() {
+			case "esc":
+				m.mu.Lock()
+				m.isAlertsOpen = false
+				m.viewport.YOffset = m.listYOffset
+				m.mu.Unlock()
+
+				return m, nil
+			case "up", "down", "pgup", "pgdown":
+				m.viewport, cmd = m.viewport.Update(msg)
+
+				return m, cmd
+			default:
+				return m, nil
+			}
+		}
+
+		// The detail screen only handles scrolling and is closed with esc without changing the group or quotes being monitored
+		if m.detailSymbol != "" && msg.String() != "ctrl+c" {
+			switch msg.String() {
+			case "esc":
+				m.mu.Lock()
+				m.detailSymbol = ""
+				m.viewport.YOffset = m.listYOffset
+				m.mu.Unlock()
+
+				return m, nil
+			case "up", "down", "pgup", "pgdown":
+				m.viewport, cmd = m.viewport.Update(msg)
+
+				return m, cmd
+			default:
+				return m, nil
+			}
+		}
+
 		switch msg.String() {
 
 		case "tab", "shift+tab":
@@ -172,11 +308,13 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:maintidx
 		case "q":
 			return m, tea.Quit
 		case "up":
-			m.viewport, cmd = m.viewport.Update(msg)
+			m.watchlist, cmd = m.watchlist.Update(watchlist.MoveCursorMsg(-1))
+			m.scrollToCursor()
 
 			return m, cmd
 		case "down":
-			m.viewport, cmd = m.viewport.Update(msg)
+			m.watchlist, cmd = m.watchlist.Update(watchlist.MoveCursorMsg(1))
+			m.scrollToCursor()
 
 			return m, cmd
 		case "pgup":
@@ -210,10 +348,99 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:maintidx
 			// Update watchlist component with new sort
 			m.watchlist, cmd = m.watchlist.Update(watchlist.ChangeSortMsg(m.currentSort))
 
+			return m, cmd
+		case "enter":
+			m.mu.Lock()
+			defer m.mu.Unlock()
+
+			// Show the cursor on the first row if no row has been selected yet
+			m.watchlist, _ = m.watchlist.Update(watchlist.MoveCursorMsg(0))
+			selectedAsset, ok := m.watchlist.SelectedAsset()
+
+			if !ok {
+				return m, nil
+			}
+
+			m.detailSymbol = selectedAsset.Symbol
+			m.listYOffset = m.viewport.YOffset
+			m.viewport.GotoTop()
+			m.updateDetail()
+
+			return m, nil
+		case "c":
+			m.mu.Lock()
+			defer m.mu.Unlock()
+
+			// Show the cursor on the first row if no row has been selected yet
+			m.watchlist, _ = m.watchlist.Update(watchlist.MoveCursorMsg(0))
+			selectedAsset, ok := m.watchlist.SelectedAsset()
+
+			if !ok {
+				return m, nil
+			}
+
+			m.chartSymbol = selectedAsset.Symbol
+			m.listYOffset = m.viewport.YOffset
+			m.viewport.GotoTop()
+			m.chart, _ = m.chart.Update(chart.SetAssetMsg(selectedAsset))
+
+			return m, m.getPriceHistory()
+		case "a":
+			m.mu.Lock()
+			defer m.mu.Unlock()
+
+			m.isAlertsOpen = true
+			m.alertFooterText = ""
+			m.listYOffset = m.viewport.YOffset
+			m.viewport.GotoTop()
+
+			return m, nil
+		case "/":
+			m.isSearchOpen = true
+			m.search, cmd = m.search.Update(search.OpenMsg{})
+
 			return m, cmd
 
 		}
 
+	case search.CloseMsg:
+		m.isSearchOpen = false
+
+		return m, nil
+
+	case search.SetResultsMsg, search.SetStatusMsg:
+		m.search, cmd = m.search.Update(msg)
+
+		return m, cmd
+
+	case search.SelectMsg:
+		return m, m.addSymbol(msg.Result, msg.Persist)
+
+	case SetAlertMsg:
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		m.alertHistory = slices.Insert(m.alertHistory, 0, msg.alert)
+
+		if len(m.alertHistory) > alertHistoryMaxLength {
+			m.alertHistory = m.alertHistory[:alertHistoryMaxLength]
+		}
+
+		m.alerts, _ = m.alerts.Update(alerts.SetAlertsMsg(m.alertHistory))
+		m.alertFooterText = msg.alert.Message
+		m.alertFooterExpiry = msg.alert.Time.Add(alertFooterDuration)
+
+		return m, nil
+
+	case chart.SetPricesMsg:
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		msg.Prices = m.convertPrices(map[string][]c.PricePoint{msg.Symbol: msg.Prices})[msg.Symbol]
+		m.chart, _ = m.chart.Update(msg)
+
+		return m, nil
+
 	case tea.WindowSizeMsg:
 
 		var cmd tea.Cmd
@@ -234,6 +461,10 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:maintidx
 		// Forward window size message to watchlist and summary component
 		m.watchlist, cmd = m.watchlist.Update(msg)
 		m.summary, _ = m.summary.Update(msg)
+		m.search, _ = m.search.Update(msg)
+		m.detail, _ = m.detail.Update(msg)
+		m.chart, _ = m.chart.Update(tea.WindowSizeMsg{Width: msg.Width, Height: viewportHeight})
+		m.alerts, _ = m.alerts.Update(msg)
 
 		return m, cmd
 
@@ -251,15 +482,34 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:maintidx
 			return m, nil
 		}
 
+		// Mark quotes from sources which have been failing since they were last received as stale
+		sourceStatuses := m.monitors.GetSourceStatuses()
+		setStale(m.assets, sourceStatuses)
+
 		// Update watchlist and summary components
 		m.watchlist, cmd = m.watchlist.Update(watchlist.SetAssetsMsg(m.assets))
 		m.summary, _ = m.summary.Update(summary.SetSummaryMsg(m.positionSummary))
+		m.updateDetail()
+		m.updateChart()
 
 		cmds = append(cmds, cmd)
 
 		// Set the current tick time
 		m.lastUpdateTime = getTime()
 
+		// Set the time until the markets for the current group open or close
+		exchanges, _ := calendar.GetExchanges(m.assetQuotes)
+		m.marketCountdown = calendar.GetCountdown(exchanges, time.Now())
+
+		// Set which sources are failing and when they will be retried along with the time since each last updated
+		m.sourceStatusText = getSourceStatusText(sourceStatuses, time.Now())
+		m.sourceHealthText = getSourceHealthText(sourceStatuses, time.Now())
+
+		// Stop showing the most recent alert in the footer once it is no longer recent
+		if m.alertFooterText != "" && time.Now().After(m.alertFooterExpiry) {
+			m.alertFooterText = ""
+		}
+
 		// Update the viewport
 		if m.ready {
 			m.viewport, cmd = m.viewport.Update(msg)
@@ -292,6 +542,37 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:maintidx
 
 		m.groupSelectedName = m.ctx.Groups[m.groupSelectedIndex].Name
 
+		// Intraday prices for sparklines are requested once the first quotes for a group are available and periodically after
+		if m.ctx.Config.ShowSparkline && m.intradayVersion != msg.versionVector {
+			m.intradayVersion = msg.versionVector
+
+			return m, tea.Batch(m.getIntradayPrices(), intradayTick(msg.versionVector))
+		}
+
+		return m, nil
+
+	case intradayTickMsg:
+
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+
+		if msg.versionVector != m.versionVector {
+			return m, nil
+		}
+
+		return m, tea.Batch(m.getIntradayPrices(), intradayTick(msg.versionVector))
+
+	case setIntradayPricesMsg:
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		if msg.versionVector != m.versionVector {
+			return m, nil
+		}
+
+		m.watchlist, _ = m.watchlist.Update(watchlist.SetIntradayPricesMsg(m.convertPrices(msg.pricesBySymbol)))
+
 		return m, nil
 
 	case SetAssetQuoteMsg:
@@ -368,7 +649,18 @@ func (m *Model) View() string {
 		return "\n  Initializing..."
 	}
 
-	m.viewport.SetContent(m.watchlist.View())
+	switch {
+	case m.isSearchOpen:
+		m.viewport.SetContent(m.search.View())
+	case m.isAlertsOpen:
+		m.viewport.SetContent(m.alerts.View())
+	case m.chartSymbol != "":
+		m.viewport.SetContent(m.chart.View())
+	case m.detailSymbol != "":
+		m.viewport.SetContent(m.detail.View())
+	default:
+		m.viewport.SetContent(m.watchlist.View())
+	}
 
 	viewSummary := ""
 
@@ -378,11 +670,266 @@ func (m *Model) View() string {
 
 	return viewSummary +
 		m.viewport.View() + "\n" +
-		footer(m.viewport.Width, m.lastUpdateTime, m.groupSelectedName, m.currentSort, m.latestVersion)
+		footer(m.viewport.Width, m.lastUpdateTime, m.groupSelectedName, m.currentSort, m.latestVersion, m.alertFooterText, m.marketCountdown, m.sourceStatusText, m.sourceHealthText)
+
+}
+
+// getIntradayPrices requests intraday prices for the current assets in the background
+func (m *Model) getIntradayPrices() tea.Cmd {
+	assets := slices.Clone(m.assets)
+	versionVector := m.versionVector
+
+	return func() tea.Msg {
+		return setIntradayPricesMsg{
+			pricesBySymbol: m.monitors.GetIntradayPrices(assets),
+			versionVector:  versionVector,
+		}
+	}
+}
+
+// convertPrices converts prices fetched from a source into the currency that the price of each asset is shown in
+func (m *Model) convertPrices(pricesBySymbol map[string][]c.PricePoint) map[string][]c.PricePoint {
+
+	for _, a := range m.assets {
+		prices, exists := pricesBySymbol[a.Symbol]
+		if !exists {
+			continue
+		}
+
+		i, exists := m.assetQuotesLookup[a.Symbol]
+		if !exists || i >= len(m.assetQuotes) || m.assetQuotes[i].QuotePrice.Price == 0 {
+			continue
+		}
+
+		rate := a.QuotePrice.Price / m.assetQuotes[i].QuotePrice.Price
+
+		if rate == 1 || rate == 0 {
+			continue
+		}
+
+		for j := range prices {
+			prices[j].Price *= rate
+		}
+	}
+
+	return pricesBySymbol
+}
+
+// scrollToCursor scrolls the viewport so that the row under the cursor is visible
+func (m *Model) scrollToCursor() {
+
+	start, end := m.watchlist.SelectedLines()
+
+	if start < m.viewport.YOffset {
+		m.viewport.YOffset = start
+	}
+
+	if end > m.viewport.YOffset+m.viewport.Height {
+		m.viewport.YOffset = end - m.viewport.Height
+	}
+}
+
+// getPriceHistory requests the price history of the charted asset over the selected range in the background
+func (m *Model) getPriceHistory() tea.Cmd {
+	historyRange := m.chart.Range()
+
+	for _, a := range m.assets {
+		if a.Symbol != m.chartSymbol {
+			continue
+		}
+
+		return func() tea.Msg {
+			prices, err := m.monitors.GetPriceHistory(a, historyRange)
+
+			return chart.SetPricesMsg{
+				Symbol: a.Symbol,
+				Range:  historyRange,
+				Prices: prices,
+				Err:    err,
+			}
+		}
+	}
+
+	return nil
+}
+
+// updateChart sets the latest quote and position of the asset on the chart screen when it is open
+func (m *Model) updateChart() {
+
+	if m.chartSymbol == "" {
+		return
+	}
+
+	for _, a := range m.assets {
+		if a.Symbol == m.chartSymbol {
+			m.chart, _ = m.chart.Update(chart.SetAssetMsg(a))
+
+			return
+		}
+	}
+}
+
+// updateDetail sets the latest quote and lots of the asset on the detail screen when it is open
+func (m *Model) updateDetail() {
+
+	if m.detailSymbol == "" {
+		return
+	}
+
+	for _, a := range m.assets {
+		if a.Symbol != m.detailSymbol {
+			continue
+		}
+
+		assetGroupQuote := c.AssetGroupQuote{
+			AssetQuotes: m.assetQuotes,
+			AssetGroup:  m.ctx.Groups[m.groupSelectedIndex],
+		}
+
+		m.detail, _ = m.detail.Update(detail.SetAssetMsg{
+			Asset: a,
+			Lots:  asset.GetLotPositions(m.ctx, assetGroupQuote, a.Symbol),
+		})
+
+		return
+	}
+}
+
+// addSymbol adds a symbol to the current group and sets the updated group on the monitors to start getting quotes for it
+func (m *Model) addSymbol(result c.SymbolSearchResult, persist bool) tea.Cmd {
+
+	m.mu.Lock()
+
+	groupName := m.ctx.Groups[m.groupSelectedIndex].Name
+	configGroupName := m.getConfigGroupName()
+	assetGroup, ok := addSymbolToGroup(m.ctx.Groups[m.groupSelectedIndex], result)
+
+	// A symbol already added to the group may still need to be saved to the config file
+	if !ok {
+		m.mu.Unlock()
+
+		status := result.Symbol + " is already in group '" + groupName + "'"
+
+		return func() tea.Msg {
+			if !persist {
+				return search.SetStatusMsg(status)
+			}
+
+			return search.SetStatusMsg(m.saveSymbolWithStatus(status, configGroupName, result.Symbol))
+		}
+	}
+
+	m.ctx.Groups[m.groupSelectedIndex] = assetGroup
+
+	// Invalidate all previous ticks, incremental price updates, and full price updates
+	m.versionVector++
+	versionVector := m.versionVector
+
+	m.mu.Unlock()
+
+	return tea.Batch(
+		tickImmediate(versionVector),
+		func() tea.Msg {
+			err := m.monitors.SetAssetGroup(assetGroup, versionVector)
+
+			if m.ctx.Config.Debug && err != nil {
+				m.ctx.Logger.Println(err)
+			}
+
+			status := "Added " + result.Symbol + " to group '" + groupName + "'"
+
+			if !persist {
+				return search.SetStatusMsg(status)
+			}
+
+			return search.SetStatusMsg(m.saveSymbolWithStatus(status, configGroupName, result.Symbol))
+		},
+	)
+}
 
+// saveSymbolWithStatus saves a symbol to the watchlist of a group in the config file and returns the status followed by
+// the outcome of saving
+func (m *Model) saveSymbolWithStatus(status string, configGroupName string, symbol string) string {
+
+	path, saved, err := saveSymbol(m.fs, m.configPath, configGroupName, symbol)
+
+	if err != nil {
+		return status + " but failed to save to config: " + err.Error()
+	}
+
+	if !saved {
+		return status + " and already saved to " + path
+	}
+
+	return status + " and saved to " + path
 }
 
-func footer(width int, time string, groupSelectedName string, currentSort string, latestVersion string) string {
+// getConfigGroupName returns the name of the current group in the config file or an empty string when the group is
+// the default group made up of the top level watchlist and lots
+func (m *Model) getConfigGroupName() string {
+
+	hasDefaultGroup := len(m.ctx.Groups) > len(m.ctx.Config.AssetGroup)
+
+	if hasDefaultGroup && m.groupSelectedIndex == 0 {
+		return ""
+	}
+
+	return m.ctx.Groups[m.groupSelectedIndex].Name
+}
+
+// addSymbolToGroup returns a copy of the asset group with the symbol added to the watchlist and false if the group
+// already has the symbol
+func addSymbolToGroup(assetGroup c.AssetGroup, result c.SymbolSearchResult) (c.AssetGroup, bool) {
+
+	for _, symbolsBySource := range assetGroup.SymbolsBySource {
+		if symbolsBySource.Source == result.Source && slices.Contains(symbolsBySource.Symbols, result.SymbolInSourceAPI) {
+			return assetGroup, false
+		}
+	}
+
+	assetGroup.ConfigAssetGroup.Watchlist = append(slices.Clone(assetGroup.ConfigAssetGroup.Watchlist), result.Symbol)
+	assetGroup.SymbolsBySource = slices.Clone(assetGroup.SymbolsBySource)
+
+	for i, symbolsBySource := range assetGroup.SymbolsBySource {
+		if symbolsBySource.Source == result.Source {
+			assetGroup.SymbolsBySource[i].Symbols = append(slices.Clone(symbolsBySource.Symbols), result.SymbolInSourceAPI)
+
+			return assetGroup, true
+		}
+	}
+
+	assetGroup.SymbolsBySource = append(assetGroup.SymbolsBySource, c.AssetGroupSymbolsBySource{
+		Source:  result.Source,
+		Symbols: []string{result.SymbolInSourceAPI},
+	})
+
+	return assetGroup, true
+}
+
+// saveSymbol adds a symbol to the watchlist of a group in the config file and returns the path of the config file and
+// false if the symbol was already in the watchlist
+func saveSymbol(fs afero.Fs, configPath string, groupName string, symbol string) (string, bool, error) {
+
+	path, contents, err := configfile.Read(fs, configPath)
+
+	if err != nil {
+		return "", false, err
+	}
+
+	updated, err := configfile.AppendWatchlist(contents, groupName, []string{symbol})
+
+	if err != nil {
+		return "", false, err
+	}
+
+	if bytes.Equal(updated, contents) {
+		return path, false, nil
+	}
+
+	return path, true, configfile.Write(fs, path, contents, updated)
+}
+
+func footer(width int, time string, groupSelectedName string, currentSort string, latestVersion string, alertText string, marketCountdown string, sourceStatusText string, sourceHealthText string) string {
 
 	if width < 80 {
 		return styleLogo(" ticker ")
@@ -403,7 +950,7 @@ func footer(width int, time string, groupSelectedName string, currentSort string
 		sortDisplayName = "user"
 	}
 
-	baseHelpText := " q: exit ↑: scroll up ↓: scroll down ⭾: change group"
+	baseHelpText := " q: exit ↑↓: select ⏎: details c: chart a: alerts /: search ⭾: change group"
 	sortHelpText := " s: change sort (" + sortDisplayName + ")"
 
 	rightText := "↻  " + time
@@ -411,28 +958,76 @@ func footer(width int, time string, groupSelectedName string, currentSort string
 		rightText = "↑ " + latestVersion + " available"
 	}
 
+	rightText = styleHelp(rightText)
+
+	// Failing sources are shown in place of the last update time since quotes may be stale
+	if sourceStatusText != "" {
+		rightText = styleAlert("⚠ " + sourceStatusText)
+	}
+
+	// The most recent alert is shown in place of the key bindings until it is no longer recent
+	helpText := styleHelp(baseHelpText)
+	if alertText != "" {
+		helpText = styleAlert(truncate(" ⚑ "+alertText, 75))
+	}
+
 	// Calculate minimum width for sort help text to appear
 	// Longest sort text is "s: change sort (change)" = 24 characters
-	// Minimum width needed: logo(8) + max group(14) + base help(52) + sort help(24) + time(12) = 110
-	const sortHelpMinWidth = 114
+	// Minimum width needed: logo(8) + max group(14) + base help(75) + sort help(24) + time(12) = 133
+	const sortHelpMinWidth = 137
+
+	cells := []grid.Cell{
+		{Text: styleLogo(" ticker "), Width: 8},
+		{Text: styleGroup(" " + groupSelectedName + " "), Width: len(groupSelectedName) + 2, VisibleMinWidth: 118},
+		{Text: helpText, Width: 75},
+		{Text: styleHelp(sortHelpText), Width: len(sortHelpText), VisibleMinWidth: sortHelpMinWidth},
+		{Text: rightText, Align: grid.Right},
+	}
+
+	// The time until markets open or close and the health of each source are shown only when there is space for them
+	// after the sort help text
+	visibleMinWidth := sortHelpMinWidth
+
+	if marketCountdown != "" {
+		visibleMinWidth += len(marketCountdown) + 1
+		cells = slices.Insert(cells, len(cells)-1, grid.Cell{
+			Text:            styleHelp(marketCountdown),
+			Width:           len(marketCountdown),
+			VisibleMinWidth: visibleMinWidth,
+		})
+	}
+
+	if sourceHealthText != "" {
+		visibleMinWidth += lipgloss.Width(sourceHealthText) + 1
+		cells = slices.Insert(cells, len(cells)-1, grid.Cell{
+			Text:            sourceHealthText,
+			Width:           lipgloss.Width(sourceHealthText),
+			VisibleMinWidth: visibleMinWidth,
+		})
+	}
 
 	return grid.Render(grid.Grid{
 		Rows: []grid.Row{
 			{
 				Width: width,
-				Cells: []grid.Cell{
-					{Text: styleLogo(" ticker "), Width: 8},
-					{Text: styleGroup(" " + groupSelectedName + " "), Width: len(groupSelectedName) + 2, VisibleMinWidth: 95},
-					{Text: styleHelp(baseHelpText), Width: 52},
-					{Text: styleHelp(sortHelpText), Width: len(sortHelpText), VisibleMinWidth: sortHelpMinWidth},
-					{Text: styleHelp(rightText), Align: grid.Right},
-				},
+				Cells: cells,
 			},
 		},
 	})
 
 }
 
+// truncate shortens text to a maximum number of characters
+func truncate(text string, width int) string {
+	runes := []rune(text)
+
+	if len(runes) <= width {
+		return text
+	}
+
+	return string(runes[:width-1]) + "…"
+}
+
 func getVerticalMargin(config c.Config) int {
 	if config.ShowSummary {
 		return 2
@@ -447,6 +1042,15 @@ func updateCheckTick() tea.Cmd {
 	})
 }
 
+// Send a new intraday tick message with the versionVector once the period covered by a sparkline price has passed
+func intradayTick(versionVector int) tea.Cmd {
+	return tea.Tick(5*time.Minute, func(time.Time) tea.Msg {
+		return intradayTickMsg{
+			versionVector: versionVector,
+		}
+	})
+}
+
 // Send a new tick message with the versionVector 200ms from now
 func tick(versionVector int) tea.Cmd {
 	return tea.Tick(time.Second/5, func(time.Time) tea.Msg {
@@ -466,6 +1070,93 @@ func tickImmediate(versionVector int) tea.Cmd {
 	}
 }
 
+// getSourceStatusText returns the sources with failing requests and the time until each is retried
+func getSourceStatusText(statuses []c.SourceStatus, now time.Time) string {
+
+	texts := make([]string, 0)
+
+	for _, status := range statuses {
+		if status.Failures == 0 {
+			continue
+		}
+
+		state := "degraded"
+		if status.BreakerState != c.BreakerStateClosed {
+			state = "unavailable"
+		}
+
+		retryIn := status.RetryAt.Sub(now)
+		if retryIn <= 0 || status.BreakerState == c.BreakerStateHalfOpen {
+			texts = append(texts, status.Name+" "+state+", retrying")
+
+			continue
+		}
+
+		texts = append(texts, status.Name+" "+state+", retrying in "+formatRetryIn(retryIn))
+	}
+
+	return strings.Join(texts, ", ")
+}
+
+// getSourceHealthText returns each source with a marker for whether it is failing and the time since it last updated
+func getSourceHealthText(statuses []c.SourceStatus, now time.Time) string {
+
+	texts := make([]string, 0, len(statuses))
+
+	for _, status := range statuses {
+		marker := styleOK("●")
+		if status.Failures > 0 {
+			marker = styleAlert("●")
+		}
+
+		text := marker + styleHelp(" "+status.Name)
+		if !status.LastUpdate.IsZero() {
+			text += styleHelp(" " + formatAge(now.Sub(status.LastUpdate)))
+		}
+
+		texts = append(texts, text)
+	}
+
+	return strings.Join(texts, styleHelp("  "))
+}
+
+// setStale marks assets from sources which have been failing since the asset was last updated as stale
+func setStale(assets []c.Asset, statuses []c.SourceStatus) {
+
+	for i := range assets {
+		for _, status := range statuses {
+			if status.Source == assets[i].QuoteSource {
+				assets[i].Meta.IsStale = mon.IsStale(assets[i].Meta.LastUpdated, status)
+			}
+		}
+	}
+}
+
+// formatAge returns a duration rounded down to the largest of seconds, minutes, or hours
+func formatAge(d time.Duration) string {
+
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", max(int(d.Seconds()), 0))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+}
+
+// formatRetryIn returns a duration rounded up to the second when less than a minute and otherwise to the minute
+func formatRetryIn(d time.Duration) string {
+
+	seconds := int(math.Ceil(d.Seconds()))
+
+	if seconds < 60 {
+		return fmt.Sprintf("%ds", seconds)
+	}
+
+	return fmt.Sprintf("%dm", int(math.Ceil(float64(seconds)/60)))
+}
+
 func getTime() string {
 	t := time.Now()
 
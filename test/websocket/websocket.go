@@ -3,6 +3,9 @@ package websocket
 import (
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 )
@@ -33,3 +36,124 @@ func NewTestServer(messages []string) *httptest.Server {
 		}
 	}))
 }
+
+// Server is a test WebSocket server which keeps connections open after sending the provided messages so that tests
+// can drop them on demand or stop answering pings to simulate a half-open connection
+type Server struct {
+	*httptest.Server
+	messages       []string
+	conns          map[*websocket.Conn]bool
+	connections    int
+	received       []string
+	requestURIs    []string
+	isUnresponsive bool
+	mu             sync.Mutex
+}
+
+// NewServer creates a new test WebSocket server that sends the provided messages on each new connection
+func NewServer(messages []string) *Server {
+	s := &Server{
+		messages: messages,
+		conns:    make(map[*websocket.Conn]bool),
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	return s
+}
+
+// DropConnections closes all open connections without a close message as happens when the network drops
+func (s *Server) DropConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for conn := range s.conns {
+		conn.NetConn().Close()
+		delete(s.conns, conn)
+	}
+}
+
+// SetUnresponsive sets whether pings are ignored which leaves the connection open but unable to deliver messages
+func (s *Server) SetUnresponsive(isUnresponsive bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.isUnresponsive = isUnresponsive
+}
+
+// GetConnectionCount returns the number of connections opened since the server started
+func (s *Server) GetConnectionCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.connections
+}
+
+// GetReceivedMessages returns the messages received from all connections in order
+func (s *Server) GetReceivedMessages() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.received)
+}
+
+// GetRequestURIs returns the request URI of each connection in the order they were opened
+func (s *Server) GetRequestURIs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.requestURIs)
+}
+
+// Close drops all open connections and shuts down the server
+func (s *Server) Close() {
+	s.DropConnections()
+	s.Server.Close()
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	s.mu.Lock()
+	s.conns[conn] = true
+	s.connections++
+	s.requestURIs = append(s.requestURIs, r.RequestURI)
+	s.mu.Unlock()
+
+	conn.SetPingHandler(func(data string) error {
+		s.mu.Lock()
+		isUnresponsive := s.isUnresponsive
+		s.mu.Unlock()
+
+		if isUnresponsive {
+			return nil
+		}
+
+		return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+	})
+
+	for _, msg := range s.messages {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+			return
+		}
+	}
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			s.mu.Lock()
+			delete(s.conns, conn)
+			s.mu.Unlock()
+
+			return
+		}
+
+		s.mu.Lock()
+		s.received = append(s.received, string(message))
+		s.mu.Unlock()
+	}
+}